@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/paulozy/costurai/configs"
+	"github.com/paulozy/costurai/internal/infra/database/firestore"
+	"github.com/paulozy/costurai/internal/infra/database/firestore/repositories"
+)
+
+// Writes the Geohash field on every dressmaker stored in Firestore before
+// proximity search started relying on it.
+func main() {
+	fmt.Println("Backfilling dressmaker geohashes...")
+
+	configs, err := configs.LoadConfig("../")
+	if err != nil {
+		panic(err)
+	}
+
+	client := firestore.NewFirestoreClient(configs.FirebaseProjectId)
+	defer client.Close()
+
+	dressmakerRepository := repositories.NewFirestoreDressmakerRepository(client)
+
+	updated, err := dressmakerRepository.BackfillGeohashes(context.Background())
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("Updated %d dressmakers\n", updated)
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/paulozy/costurai/configs"
+	"github.com/paulozy/costurai/internal/infra/database/firestore"
+	"github.com/paulozy/costurai/internal/infra/database/firestore/repositories"
+)
+
+// Fills the review count, grade sum and score of every dressmaker stored in
+// Firestore from the reviews written before they were kept incrementally.
+func main() {
+	fmt.Println("Backfilling dressmaker review aggregates...")
+
+	configs, err := configs.LoadConfig("../")
+	if err != nil {
+		panic(err)
+	}
+
+	client := firestore.NewFirestoreClient(configs.FirebaseProjectId)
+	defer client.Close()
+
+	dressmakerRepository := repositories.NewFirestoreDressmakerRepository(client)
+	reviewsRepository := repositories.NewFirestoreReviewsRepository(client)
+
+	updated, err := dressmakerRepository.BackfillReviewAggregates(context.Background(), reviewsRepository)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("Updated %d dressmakers\n", updated)
+}
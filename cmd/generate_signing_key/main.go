@@ -0,0 +1,106 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/paulozy/costurai/pkg"
+)
+
+// Creates a JWT signing key in JWT_KEYS_DIR, or retires one by replacing its
+// private key with the public half so tokens it signed still verify.
+//
+//	go run ./cmd/generate_signing_key -dir keys -alg EdDSA
+//	go run ./cmd/generate_signing_key -dir keys -retire 2024-01-01
+func main() {
+	dir := flag.String("dir", "keys", "directory holding the signing keys")
+	alg := flag.String("alg", "EdDSA", "key algorithm, EdDSA or RS256")
+	kid := flag.String("kid", time.Now().UTC().Format("2006-01-02"), "id of the new key")
+	retire := flag.String("retire", "", "id of a key to keep for verification only")
+	flag.Parse()
+
+	if err := os.MkdirAll(*dir, 0o700); err != nil {
+		panic(err)
+	}
+
+	if *retire != "" {
+		retireKey(*dir, *retire)
+		return
+	}
+
+	var private any
+	switch *alg {
+	case "EdDSA":
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			panic(err)
+		}
+		private = key
+	case "RS256":
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			panic(err)
+		}
+		private = key
+	default:
+		panic(fmt.Sprintf("unsupported algorithm: %s", *alg))
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		panic(err)
+	}
+
+	path := filepath.Join(*dir, *kid+".pem")
+	writeNew(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600)
+
+	fmt.Printf("Created %s key %s at %s\n", *alg, *kid, path)
+}
+
+func retireKey(dir, kid string) {
+	privatePath := filepath.Join(dir, kid+".pem")
+
+	raw, err := os.ReadFile(privatePath)
+	if err != nil {
+		panic(err)
+	}
+
+	key, err := pkg.ParsePrivateSigningKey(kid, raw)
+	if err != nil {
+		panic(err)
+	}
+
+	der, err := x509.MarshalPKIXPublicKey(key.Public)
+	if err != nil {
+		panic(err)
+	}
+
+	publicPath := filepath.Join(dir, kid+".pub.pem")
+	writeNew(publicPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o644)
+
+	if err := os.Remove(privatePath); err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("Retired key %s, kept %s for verification\n", kid, publicPath)
+}
+
+func writeNew(path string, data []byte, perm os.FileMode) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		panic(err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(data); err != nil {
+		panic(err)
+	}
+}
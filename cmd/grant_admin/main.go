@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/paulozy/costurai/configs"
+	"github.com/paulozy/costurai/internal/entity"
+	"github.com/paulozy/costurai/internal/infra/database/factory"
+)
+
+const (
+	cliActor = "cli"
+
+	auditActionGrantAdmin  = "grant_admin"
+	auditActionRevokeAdmin = "revoke_admin"
+)
+
+// Flags a user as admin, or removes the flag, recording the change in the
+// audit log. Admins log in as users and get the admin role.
+//
+//	go run ./cmd/grant_admin -email ops@costurai.com -reason "first admin"
+//	go run ./cmd/grant_admin -email ops@costurai.com -revoke -reason "left the team"
+func main() {
+	email := flag.String("email", "", "email of the user")
+	revoke := flag.Bool("revoke", false, "remove the admin flag instead of granting it")
+	reason := flag.String("reason", "", "why the change is made, kept in the audit log")
+	flag.Parse()
+
+	if *email == "" || *reason == "" {
+		flag.Usage()
+		return
+	}
+
+	configs, err := configs.LoadConfig("../")
+	if err != nil {
+		panic(err)
+	}
+
+	ctx := context.Background()
+	repos := factory.NewRepositories(configs)
+
+	user, err := repos.User.FindByEmail(ctx, *email)
+	if err != nil {
+		panic(err)
+	}
+
+	if user == nil {
+		panic(fmt.Sprintf("no user found with email: %s", *email))
+	}
+
+	user.Admin = !*revoke
+	if err := repos.User.Update(ctx, user); err != nil {
+		panic(err)
+	}
+
+	action := auditActionGrantAdmin
+	if *revoke {
+		action = auditActionRevokeAdmin
+		// sessions carry the admin role until they end
+		if err := repos.RefreshToken.RevokeSubject(ctx, user.ID); err != nil {
+			panic(err)
+		}
+	}
+
+	err = repos.AuditLog.Create(ctx, entity.NewAuditEntry(
+		cliActor, action, entity.AuditTargetUser, user.ID, *reason,
+		map[string]any{"admin": user.Admin},
+	))
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("User %s admin: %t\n", user.Email, user.Admin)
+}
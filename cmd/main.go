@@ -1,17 +1,20 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"log"
+	"time"
 
 	"github.com/paulozy/costurai/configs"
-	"github.com/paulozy/costurai/internal/infra/database/firestore"
+	"github.com/paulozy/costurai/internal/infra/database/factory"
+	"github.com/paulozy/costurai/internal/infra/scheduler"
 	"github.com/paulozy/costurai/internal/infra/server"
+	notificationServices "github.com/paulozy/costurai/internal/infra/services/notification"
+	subUseCases "github.com/paulozy/costurai/internal/usecase/subscription"
 )
 
-const (
-	Firestore string = "firestore"
-	Postgres  string = "postgres"
-)
+const defaultSweepInterval = time.Hour
 
 func main() {
 	fmt.Println("Starting the Costurai API server...")
@@ -21,10 +24,45 @@ func main() {
 		panic(err)
 	}
 
-	database := firestore.NewFirestoreClient(configs.FirebaseProjectId)
-
 	server := server.NewServer(configs.WebHost, configs.WebPort, configs.Env)
-	server.FirestoreDB = database
+	server.Config = configs
+	server.Repositories = factory.NewRepositories(configs)
 	server.AddHandlers()
+
+	startJobs(server)
 	server.Start()
 }
+
+// startJobs runs the background jobs. Every replica starts them, and the
+// scheduler's locks keep them from running twice.
+func startJobs(server *server.Server) {
+	repos := server.Repositories
+
+	sweepInterval := defaultSweepInterval
+	if server.Config.SubscriptionSweepInterval > 0 {
+		sweepInterval = time.Duration(server.Config.SubscriptionSweepInterval) * time.Minute
+	}
+
+	sweepSubscriptionsUseCase := subUseCases.NewSweepSubscriptionsUseCase(
+		repos.Subscription,
+		repos.Dressmaker,
+		notificationServices.NewNotificationService(server.Config),
+		server.Config,
+	)
+
+	jobs := scheduler.NewScheduler(repos.Lock)
+	jobs.Add(scheduler.Job{
+		Name:     "sweep-subscriptions",
+		Interval: sweepInterval,
+		Run: func(ctx context.Context) error {
+			output, ucErr := sweepSubscriptionsUseCase.Execute(ctx)
+			if ucErr.Message != "" {
+				return fmt.Errorf("%s: %s", ucErr.Message, ucErr.Error)
+			}
+
+			log.Printf("subscriptions swept: %d lapsed, %d notified, %d failed", output.Lapsed, output.Notified, output.Failed)
+			return nil
+		},
+	})
+	jobs.Start(context.Background())
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+
+	oidc "github.com/paulozy/costurai/internal/infra/services/oidc"
+)
+
+// Runs a local OpenID Connect provider to try the social login without a
+// real one. Point OIDC_ISSUER at it and use the same client id:
+//
+//	go run ./cmd/mockoidc -addr :9000 -client-id costurai
+func main() {
+	addr := flag.String("addr", ":9000", "address to listen on")
+	issuerURL := flag.String("issuer", "http://localhost:9000", "issuer URL the provider is reached at")
+	clientID := flag.String("client-id", "costurai", "client id accepted by the provider")
+	flag.Parse()
+
+	issuer, err := oidc.NewMockIssuer(*issuerURL, *clientID)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("Mock OpenID Connect provider %s listening on %s\n", issuer.IssuerURL, *addr)
+
+	if err := http.ListenAndServe(*addr, issuer); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/paulozy/costurai/configs"
+	"github.com/paulozy/costurai/internal/entity"
+	"github.com/paulozy/costurai/internal/infra/database"
+	"github.com/paulozy/costurai/internal/infra/database/factory"
+	paymentServices "github.com/paulozy/costurai/internal/infra/services/payment"
+	subUseCases "github.com/paulozy/costurai/internal/usecase/subscription"
+)
+
+// Lists the payment webhook events received, newest first, and replays them
+// against the webhook handler to recover from incidents.
+//
+//	go run ./cmd/webhook_events -status failed
+//	go run ./cmd/webhook_events -replay evt_1Nv0FGQ9
+//	go run ./cmd/webhook_events -replay evt_1Nv0FGQ9 -force
+//	go run ./cmd/webhook_events -replay-failed
+func main() {
+	status := flag.String("status", "", "only list events with the status: received, processed, ignored or failed")
+	eventType := flag.String("type", "", "only list events of the gateway event type, e.g. invoice.paid")
+	limit := flag.Int64("limit", 20, "number of events to list")
+	replay := flag.String("replay", "", "ID of the event to replay")
+	replayFailed := flag.Bool("replay-failed", false, "replay every failed event, oldest first")
+	force := flag.Bool("force", false, "replay events that were already processed or ignored")
+	flag.Parse()
+
+	configs, err := configs.LoadConfig("../")
+	if err != nil {
+		panic(err)
+	}
+
+	ctx := context.Background()
+	repos := factory.NewRepositories(configs)
+
+	replayPaymentEventUseCase := subUseCases.NewReplayPaymentEventUseCase(
+		repos.WebhookEvent,
+		paymentServices.NewStripeService(),
+		subUseCases.NewHandlePaymentEventUseCase(repos.Subscription, repos.Dressmaker, configs),
+		configs,
+	)
+
+	switch {
+	case *replay != "":
+		replayEvent(ctx, replayPaymentEventUseCase, *replay, *force)
+	case *replayFailed:
+		events, _, err := repos.WebhookEvent.Search(ctx, database.WebhookEventSearchParams{
+			Status: entity.WebhookEventFailed,
+		})
+		if err != nil {
+			panic(err)
+		}
+
+		for i := len(events) - 1; i >= 0; i-- {
+			replayEvent(ctx, replayPaymentEventUseCase, events[i].ID, false)
+		}
+	default:
+		events, total, err := repos.WebhookEvent.Search(ctx, database.WebhookEventSearchParams{
+			Type:   *eventType,
+			Status: entity.WebhookEventStatus(*status),
+			Limit:  *limit,
+			Page:   1,
+		})
+		if err != nil {
+			panic(err)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tTYPE\tSTATUS\tATTEMPTS\tOCCURRED AT\tERROR")
+		for _, event := range events {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\t%s\n",
+				event.ID, event.Type, event.Status, event.Attempts, event.OccurredAt.Format(time.RFC3339), event.Error)
+		}
+		w.Flush()
+
+		fmt.Printf("%d of %d events\n", len(events), total)
+	}
+}
+
+func replayEvent(ctx context.Context, uc *subUseCases.ReplayPaymentEventUseCase, id string, force bool) {
+	event, ucErr := uc.Execute(ctx, id, force)
+	if event == nil {
+		fmt.Printf("%s: %s\n", id, ucErr.Message)
+		return
+	}
+
+	fmt.Printf("%s (%s): %s %s\n", event.ID, event.Type, event.Status, event.Error)
+}
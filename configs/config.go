@@ -3,28 +3,68 @@ package configs
 import "github.com/spf13/viper"
 
 type Config struct {
-	DBDriver                  string `mapstructure:"DB_DRIVER"`
-	DBHost                    string `mapstructure:"DB_HOST"`
-	DBPort                    string `mapstructure:"DB_PORT"`
-	DBUser                    string `mapstructure:"DB_USER"`
-	DBPassword                string `mapstructure:"DB_PASSWORD"`
-	DBName                    string `mapstructure:"DB_NAME"`
-	WebPort                   string `mapstructure:"WEB_PORT"`
-	WebHost                   string `mapstructure:"WEB_HOST"`
-	JWTSecret                 string `mapstructure:"JWT_SECRET"`
-	JWTExpiresIn              int64  `mapstructure:"JWT_EXPIRES_IN"`
-	FirebaseProjectId         string `mapstructure:"FIREBASE_PROJECT_ID"`
-	TwilioSID                 string `mapstructure:"TWILIO_ACCOUNT_SID"`
-	TwilioAuthToken           string `mapstructure:"TWILIO_AUTH_TOKEN"`
-	TwilioSMSServiceSID       string `mapstructure:"TWILIO_SMS_SERVICE_SID"`
-	SMSTimeout                int64  `mapstructure:"SMS_TIMEOUT"`
-	TwilioChannel             string `mapstructure:"TWILIO_CHANNEL"`
-	DBType                    string `mapstructure:"DB_TYPE"`
-	PaymentSuccessRedirectURL string `mapstructure:"PAYMENT_SUCCESS_REDIRECT_URL"`
-	PaymentCancelRedirectURL  string `mapstructure:"PAYMENT_CANCEL_REDIRECT_URL"`
-	StripeSecretKey           string `mapstructure:"STRIPE_SECRET_KEY"`
-	StripeWebhookSecret       string `mapstructure:"STRIPE_WEBHOOK_SECRET"`
-	Env                       string `mapstructure:"ENV"`
+	DBDriver                     string `mapstructure:"DB_DRIVER"`
+	DBHost                       string `mapstructure:"DB_HOST"`
+	DBPort                       string `mapstructure:"DB_PORT"`
+	DBUser                       string `mapstructure:"DB_USER"`
+	DBPassword                   string `mapstructure:"DB_PASSWORD"`
+	DBName                       string `mapstructure:"DB_NAME"`
+	WebPort                      string `mapstructure:"WEB_PORT"`
+	WebHost                      string `mapstructure:"WEB_HOST"`
+	RequestTimeout               int64  `mapstructure:"REQUEST_TIMEOUT"`
+	TrustedProxies               string `mapstructure:"TRUSTED_PROXIES"`
+	JWTKeysDir                   string `mapstructure:"JWT_KEYS_DIR"`
+	JWTActiveKeyID               string `mapstructure:"JWT_ACTIVE_KEY_ID"`
+	JWTExpiresIn                 int64  `mapstructure:"JWT_EXPIRES_IN"`
+	JWTAccessExpiresIn           int64  `mapstructure:"JWT_ACCESS_EXPIRES_IN"`
+	RefreshTokenExpiresIn        int64  `mapstructure:"REFRESH_TOKEN_EXPIRES_IN"`
+	FirebaseProjectId            string `mapstructure:"FIREBASE_PROJECT_ID"`
+	TwilioSID                    string `mapstructure:"TWILIO_ACCOUNT_SID"`
+	TwilioAuthToken              string `mapstructure:"TWILIO_AUTH_TOKEN"`
+	TwilioSMSServiceSID          string `mapstructure:"TWILIO_SMS_SERVICE_SID"`
+	SMSTimeout                   int64  `mapstructure:"SMS_TIMEOUT"`
+	TwilioChannel                string `mapstructure:"TWILIO_CHANNEL"`
+	TwilioFromNumber             string `mapstructure:"TWILIO_FROM_NUMBER"`
+	OTPProvider                  string `mapstructure:"OTP_PROVIDER"`
+	OTPLength                    int    `mapstructure:"OTP_LENGTH"`
+	OTPExpiresIn                 int64  `mapstructure:"OTP_EXPIRES_IN"`
+	OTPMaxAttempts               int    `mapstructure:"OTP_MAX_ATTEMPTS"`
+	OTPSendInterval              int64  `mapstructure:"OTP_SEND_INTERVAL"`
+	OTPSendMaxAttempts           int    `mapstructure:"OTP_SEND_MAX_ATTEMPTS"`
+	LoginMaxAttempts             int    `mapstructure:"LOGIN_MAX_ATTEMPTS"`
+	LoginIPMaxAttempts           int    `mapstructure:"LOGIN_IP_MAX_ATTEMPTS"`
+	LoginBackoff                 int64  `mapstructure:"LOGIN_BACKOFF"`
+	LoginLockoutDuration         int64  `mapstructure:"LOGIN_LOCKOUT_DURATION"`
+	SMSSender                    string `mapstructure:"SMS_SENDER"`
+	SMSFilePath                  string `mapstructure:"SMS_FILE_PATH"`
+	DBType                       string `mapstructure:"DB_TYPE"`
+	PaymentSuccessRedirectURL    string `mapstructure:"PAYMENT_SUCCESS_REDIRECT_URL"`
+	PaymentCancelRedirectURL     string `mapstructure:"PAYMENT_CANCEL_REDIRECT_URL"`
+	StripeSecretKey              string `mapstructure:"STRIPE_SECRET_KEY"`
+	StripeWebhookSecret          string `mapstructure:"STRIPE_WEBHOOK_SECRET"`
+	SubscriptionGracePeriodDays  int    `mapstructure:"SUBSCRIPTION_GRACE_PERIOD_DAYS"`
+	SubscriptionExpiryNoticeDays int    `mapstructure:"SUBSCRIPTION_EXPIRY_NOTICE_DAYS"`
+	SubscriptionSweepInterval    int64  `mapstructure:"SUBSCRIPTION_SWEEP_INTERVAL"`
+	ReviewBlockedWords           string `mapstructure:"REVIEW_BLOCKED_WORDS"`
+	ReviewBlockedPatterns        string `mapstructure:"REVIEW_BLOCKED_PATTERNS"`
+	ReviewReportThreshold        int    `mapstructure:"REVIEW_REPORT_THRESHOLD"`
+	NotificationProvider         string `mapstructure:"NOTIFICATION_PROVIDER"`
+	SMTPHost                     string `mapstructure:"SMTP_HOST"`
+	SMTPPort                     string `mapstructure:"SMTP_PORT"`
+	SMTPUsername                 string `mapstructure:"SMTP_USERNAME"`
+	SMTPPassword                 string `mapstructure:"SMTP_PASSWORD"`
+	SMTPFrom                     string `mapstructure:"SMTP_FROM"`
+	PasswordResetURL             string `mapstructure:"PASSWORD_RESET_URL"`
+	PasswordResetExpiresIn       int64  `mapstructure:"PASSWORD_RESET_EXPIRES_IN"`
+	EmailVerificationURL         string `mapstructure:"EMAIL_VERIFICATION_URL"`
+	EmailChangeURL               string `mapstructure:"EMAIL_CHANGE_URL"`
+	EmailTokenExpiresIn          int64  `mapstructure:"EMAIL_TOKEN_EXPIRES_IN"`
+	OIDCIssuer                   string `mapstructure:"OIDC_ISSUER"`
+	OIDCClientID                 string `mapstructure:"OIDC_CLIENT_ID"`
+	OIDCClientSecret             string `mapstructure:"OIDC_CLIENT_SECRET"`
+	OIDCRedirectURL              string `mapstructure:"OIDC_REDIRECT_URL"`
+	OIDCScopes                   string `mapstructure:"OIDC_SCOPES"`
+	Env                          string `mapstructure:"ENV"`
 }
 
 func LoadConfig(path string) (*Config, error) {
@@ -0,0 +1,41 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	AuditTargetUser         = "user"
+	AuditTargetDressmaker   = "dressmaker"
+	AuditTargetSubscription = "subscription"
+	AuditTargetReview       = "review"
+)
+
+// AuditEntry records an action taken by an admin on behalf of the
+// marketplace, who took it and why.
+type AuditEntry struct {
+	ID         string         `json:"id"`
+	ActorID    string         `json:"actorId"`
+	Action     string         `json:"action"`
+	TargetType string         `json:"targetType"`
+	TargetID   string         `json:"targetId"`
+	Reason     string         `json:"reason"`
+	Changes    map[string]any `json:"changes,omitempty"` // new values of the fields the action changed
+
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func NewAuditEntry(actorID, action, targetType, targetID, reason string, changes map[string]any) *AuditEntry {
+	return &AuditEntry{
+		ID:         uuid.New().String(),
+		ActorID:    actorID,
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Reason:     reason,
+		Changes:    changes,
+		CreatedAt:  time.Now(),
+	}
+}
@@ -8,6 +8,14 @@ import (
 	"github.com/paulozy/costurai/pkg"
 )
 
+// The ranking score is the average grade pulled towards RatingPriorMean as if
+// the dressmaker had RatingPriorWeight extra reviews of that grade, so a
+// handful of reviews can't outrank a long and consistent track record.
+const (
+	RatingPriorMean   = 3.5
+	RatingPriorWeight = 5.0
+)
+
 type Address struct {
 	City         string   `json:"city"`
 	State        string   `json:"state"`
@@ -24,14 +32,23 @@ type Dressmaker struct {
 
 	Name           string   `json:"name"`
 	Contact        string   `json:"contact"`
-	Enabled        bool     `json:"enabled"`
+	Phone          string   `json:"phone,omitempty"` // last verified contact
+	Enabled        bool     `json:"enabled"`         // listed in search, see Listed
+	Suspended      bool     `json:"suspended"`       // disabled by an admin
 	Grade          float64  `json:"grade"`
+	ReviewCount    int64    `json:"reviewCount"`
+	GradeSum       float64  `json:"-"`
+	Score          float64  `json:"score"`
 	Services       []string `json:"services"`
 	SubscriptionId *string  `json:"subscriptionId"`
 	Address        Address  `json:"address"`
+	Geohash        string   `json:"-"`
 
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	EmailVerified   bool       `json:"emailVerified"`
+	EmailVerifiedAt *time.Time `json:"emailVerifiedAt,omitempty"`
+	PhoneVerifiedAt *time.Time `json:"phoneVerifiedAt,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
 }
 
 type CreateDressmakerInput struct {
@@ -70,36 +87,111 @@ func NewDressmaker(params CreateDressmakerInput) (*Dressmaker, error) {
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
+	dressmaker.UpdateGeohash()
 
 	return dressmaker, nil
 }
 
-func (dressmaker *Dressmaker) Enable() {
-	dressmaker.Enabled = true
+// Listed reports whether customers can find the dressmaker: the contact shown
+// is a verified phone, an admin hasn't suspended them and their current
+// subscription grants access. Sub is nil when they have no subscription.
+func (dressmaker *Dressmaker) Listed(sub *Subscription) bool {
+	if dressmaker.PhoneVerifiedAt == nil || dressmaker.Suspended {
+		return false
+	}
+
+	if sub == nil || dressmaker.SubscriptionId == nil || *dressmaker.SubscriptionId != sub.ID {
+		return false
+	}
+
+	return sub.HasAccess()
+}
+
+// RefreshListing recomputes Enabled, which search filters on, so it always
+// follows Listed.
+func (dressmaker *Dressmaker) RefreshListing(sub *Subscription) {
+	dressmaker.Enabled = dressmaker.Listed(sub)
 }
 
-func (dressmaker *Dressmaker) Disable() {
+// Suspend hides the dressmaker until an admin reinstates them. Unlike a
+// pending phone verification, the owner can't lift a suspension.
+func (dressmaker *Dressmaker) Suspend() {
+	dressmaker.Suspended = true
 	dressmaker.Enabled = false
 }
 
+// Reinstate lifts a suspension, listing the dressmaker again if the rest of
+// Listed holds.
+func (dressmaker *Dressmaker) Reinstate(sub *Subscription) {
+	dressmaker.Suspended = false
+	dressmaker.RefreshListing(sub)
+}
+
+// VerifyPhone records that the owner proved the phone is theirs. The contact
+// shown to customers is always the verified phone.
+func (dressmaker *Dressmaker) VerifyPhone(phone string) {
+	now := time.Now()
+	dressmaker.Contact = phone
+	dressmaker.Phone = phone
+	dressmaker.PhoneVerifiedAt = &now
+}
+
+func (dressmaker *Dressmaker) VerifyEmail() {
+	now := time.Now()
+	dressmaker.EmailVerified = true
+	dressmaker.EmailVerifiedAt = &now
+}
+
+// ChangeEmail swaps the email for a new address the owner already confirmed.
+func (dressmaker *Dressmaker) ChangeEmail(email string) {
+	dressmaker.Email = email
+	dressmaker.VerifyEmail()
+	dressmaker.UpdatedAt = time.Now()
+}
+
 func (dressmaker *Dressmaker) AddSubscription(sub *Subscription) {
 	dressmaker.SubscriptionId = &sub.ID
 }
 
-func (dressmaker *Dressmaker) UpdateGrade(grade float64) {
-	dressmaker.Grade = grade
+func (dressmaker *Dressmaker) ChangePassword(password string) error {
+	passHash, err := pkg.Encrypt(password)
+	if err != nil {
+		return err
+	}
+
+	dressmaker.Password = string(passHash)
+	dressmaker.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// UpdateGeohash recomputes the geohash of the dressmaker's address location,
+// used to narrow down proximity queries.
+func (dressmaker *Dressmaker) UpdateGeohash() {
+	dressmaker.Geohash = pkg.EncodeGeohash(
+		dressmaker.Address.Location.Latitude,
+		dressmaker.Address.Location.Longitude,
+		pkg.GeohashPrecision,
+	)
 }
 
 func (dressmaker *Dressmaker) Update(params UpdateDressmakerInput) {
 	if params.Name != "" {
 		dressmaker.Name = params.Name
 	}
-	if params.Contact != "" {
+	if params.Contact != "" && params.Contact != dressmaker.Contact {
 		dressmaker.Contact = params.Contact
+
+		// a new contact has to be verified again
+		if dressmaker.Contact != dressmaker.Phone {
+			dressmaker.Phone = ""
+			dressmaker.PhoneVerifiedAt = nil
+		}
 	}
 	// Check if Address is not the zero value
 	if (params.Address != Address{}) {
 		dressmaker.Address = params.Address
+		dressmaker.UpdateGeohash()
 	}
 	if params.Services != nil && len(params.Services) > 0 {
 		dressmaker.Services = params.Services
@@ -107,15 +199,21 @@ func (dressmaker *Dressmaker) Update(params UpdateDressmakerInput) {
 	dressmaker.UpdatedAt = time.Now()
 }
 
-func (dressmaker *Dressmaker) CalculateGrade(reviews []Review) float64 {
-	if len(reviews) == 0 {
-		return 0
-	}
-
-	totalGrade := 0.0
-	for _, review := range reviews {
-		totalGrade += review.Grade
+// ApplyReviewDelta adjusts the review aggregates by the given amounts and
+// recomputes the two-decimal average grade and the Bayesian ranking score.
+func (dressmaker *Dressmaker) ApplyReviewDelta(countDelta int64, gradeDelta float64) {
+	dressmaker.ReviewCount += countDelta
+	dressmaker.GradeSum += gradeDelta
+
+	if dressmaker.ReviewCount <= 0 {
+		dressmaker.ReviewCount = 0
+		dressmaker.GradeSum = 0
+		dressmaker.Grade = 0
+		dressmaker.Score = 0
+		return
 	}
 
-	return math.Round(totalGrade / float64(len(reviews)))
+	count := float64(dressmaker.ReviewCount)
+	dressmaker.Grade = math.Round(dressmaker.GradeSum/count*100) / 100
+	dressmaker.Score = math.Round((RatingPriorMean*RatingPriorWeight+dressmaker.GradeSum)/(RatingPriorWeight+count)*10000) / 10000
 }
@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Identity links an account at an external OpenID Connect provider to a
+// user or dressmaker, so later logins don't depend on the email staying
+// the same.
+type Identity struct {
+	ID          string `json:"id"`
+	Issuer      string `json:"issuer"`
+	Subject     string `json:"subject"`
+	AccountType string `json:"accountType"` // "user" or "dressmaker"
+	AccountID   string `json:"accountId"`
+	Email       string `json:"email"`
+
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func NewIdentity(issuer, subject, accountType, accountID, email string) *Identity {
+	return &Identity{
+		ID:          uuid.New().String(),
+		Issuer:      issuer,
+		Subject:     subject,
+		AccountType: accountType,
+		AccountID:   accountID,
+		Email:       email,
+		CreatedAt:   time.Now(),
+	}
+}
@@ -0,0 +1,26 @@
+package entity
+
+import "time"
+
+// Lock is a lease on a named resource, such as a scheduled job, so only one
+// replica works on it at a time. It is held until ExpiresAt unless released
+// earlier.
+type Lock struct {
+	Name      string    `json:"name"`
+	Holder    string    `json:"holder"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+func NewLock(name, holder string, ttl time.Duration) *Lock {
+	return &Lock{
+		Name:      name,
+		Holder:    holder,
+		ExpiresAt: time.Now().Add(ttl),
+	}
+}
+
+// CanBeAcquiredBy reports whether the holder may take the lock, which it
+// can when it already holds it or the lease ran out.
+func (l *Lock) CanBeAcquiredBy(holder string, now time.Time) bool {
+	return l.Holder == holder || !now.Before(l.ExpiresAt)
+}
@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/paulozy/costurai/pkg"
+)
+
+// OIDCLoginState is kept between sending someone to the identity provider
+// and their return. The state handed to the provider is stored hashed; the
+// PKCE verifier and nonce never leave the server.
+type OIDCLoginState struct {
+	StateHash    string `json:"-"`
+	AccountType  string `json:"accountType"` // "user" or "dressmaker"
+	CodeVerifier string `json:"-"`
+	Nonce        string `json:"-"`
+
+	ExpiresAt time.Time `json:"expiresAt"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// NewOIDCLoginState returns the login state together with the plain state
+// value to send to the provider.
+func NewOIDCLoginState(accountType string, ttl time.Duration) (*OIDCLoginState, string, error) {
+	state, err := pkg.GenerateOpaqueToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	verifier, err := pkg.GenerateOpaqueToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	nonce, err := pkg.GenerateOpaqueToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	now := time.Now()
+
+	return &OIDCLoginState{
+		StateHash:    pkg.HashToken(state),
+		AccountType:  accountType,
+		CodeVerifier: verifier,
+		Nonce:        nonce,
+		ExpiresAt:    now.Add(ttl),
+		CreatedAt:    now,
+	}, state, nil
+}
+
+func (s *OIDCLoginState) HasExpired() bool {
+	return time.Now().After(s.ExpiresAt)
+}
@@ -0,0 +1,64 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/paulozy/costurai/pkg"
+)
+
+type OneTimeTokenPurpose string
+
+const (
+	PurposePasswordReset     OneTimeTokenPurpose = "password_reset"
+	PurposeEmailVerification OneTimeTokenPurpose = "email_verification"
+	PurposeEmailChange       OneTimeTokenPurpose = "email_change"
+)
+
+// OneTimeToken is a single-use, expiring secret mailed to an account owner to
+// prove they control the address, e.g. to reset a forgotten password.
+type OneTimeToken struct {
+	ID          string              `json:"id"`
+	Purpose     OneTimeTokenPurpose `json:"purpose"`
+	Subject     string              `json:"subject"`     // account ID
+	AccountType string              `json:"accountType"` // "user" or "dressmaker"
+	Email       string              `json:"email"`       // address the token was sent to
+	TokenHash   string              `json:"-"`
+
+	ExpiresAt time.Time  `json:"expiresAt"`
+	UsedAt    *time.Time `json:"usedAt,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+// NewOneTimeToken returns the token together with the plain secret to send
+// to the account owner; only its hash is kept.
+func NewOneTimeToken(purpose OneTimeTokenPurpose, subject, accountType, email string, ttl time.Duration) (*OneTimeToken, string, error) {
+	plain, err := pkg.GenerateOpaqueToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	now := time.Now()
+
+	token := &OneTimeToken{
+		ID:          uuid.New().String(),
+		Purpose:     purpose,
+		Subject:     subject,
+		AccountType: accountType,
+		Email:       email,
+		TokenHash:   pkg.HashToken(plain),
+		ExpiresAt:   now.Add(ttl),
+		CreatedAt:   now,
+	}
+
+	return token, plain, nil
+}
+
+func (t *OneTimeToken) IsUsable() bool {
+	return t.UsedAt == nil && time.Now().Before(t.ExpiresAt)
+}
+
+func (t *OneTimeToken) MarkUsed() {
+	now := time.Now()
+	t.UsedAt = &now
+}
@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/paulozy/costurai/pkg"
+)
+
+// OTPCode is the verification code sent to a phone by the built-in OTP
+// provider. A phone has at most one pending code; sending a new one
+// replaces it.
+type OTPCode struct {
+	Phone    string `json:"phone"`
+	CodeHash string `json:"-"`
+	Attempts int    `json:"attempts"`
+
+	ExpiresAt time.Time `json:"expiresAt"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func NewOTPCode(phone, code string, ttl time.Duration) (*OTPCode, error) {
+	codeHash, err := pkg.Encrypt(code)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	return &OTPCode{
+		Phone:     phone,
+		CodeHash:  codeHash,
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	}, nil
+}
+
+func (c *OTPCode) HasExpired() bool {
+	return time.Now().After(c.ExpiresAt)
+}
+
+func (c *OTPCode) Matches(code string) bool {
+	return pkg.CompareHashAndPassword(c.CodeHash, code)
+}
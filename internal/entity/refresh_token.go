@@ -0,0 +1,66 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/paulozy/costurai/pkg"
+)
+
+// RefreshToken is one link of a login session. Every refresh rotates the
+// token, keeping the FamilyID, so reusing an already rotated token reveals a
+// stolen token and revokes the whole family.
+type RefreshToken struct {
+	ID        string   `json:"id"`
+	FamilyID  string   `json:"familyId"`
+	Subject   string   `json:"subject"`
+	Issuer    string   `json:"issuer"`
+	Role      pkg.Role `json:"role"`
+	TokenHash string   `json:"-"`
+
+	ExpiresAt time.Time  `json:"expiresAt"`
+	UsedAt    *time.Time `json:"usedAt,omitempty"`
+	RevokedAt *time.Time `json:"revokedAt,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+// NewRefreshToken starts a new family when familyID is empty. It returns the
+// plain token to hand to the client; only its hash is kept.
+func NewRefreshToken(familyID, subject, issuer string, role pkg.Role, ttl time.Duration) (*RefreshToken, string, error) {
+	plain, err := pkg.GenerateOpaqueToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	if familyID == "" {
+		familyID = uuid.New().String()
+	}
+
+	now := time.Now()
+
+	token := &RefreshToken{
+		ID:        uuid.New().String(),
+		FamilyID:  familyID,
+		Subject:   subject,
+		Issuer:    issuer,
+		Role:      role,
+		TokenHash: pkg.HashToken(plain),
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	}
+
+	return token, plain, nil
+}
+
+func (t *RefreshToken) HasExpired() bool {
+	return time.Now().After(t.ExpiresAt)
+}
+
+func (t *RefreshToken) IsUsable() bool {
+	return t.UsedAt == nil && t.RevokedAt == nil && !t.HasExpired()
+}
+
+func (t *RefreshToken) MarkUsed() {
+	now := time.Now()
+	t.UsedAt = &now
+}
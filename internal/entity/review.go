@@ -6,24 +6,118 @@ import (
 	"github.com/google/uuid"
 )
 
+type ReviewStatus string
+
+const (
+	ReviewStatusPublished ReviewStatus = "published"
+	ReviewStatusPending   ReviewStatus = "pending"
+	ReviewStatusHidden    ReviewStatus = "hidden"
+)
+
+type ReviewReport struct {
+	ReporterID string `json:"reporterId"`
+	Reason     string `json:"reason"`
+	CreatedAt  string `json:"created_at"`
+}
+
+type ReviewReply struct {
+	Comment   string `json:"comment"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
 type Review struct {
-	ID						string  `json:"id"`
-	DressmakerID 	string  `json:"dressmakerId"`
-	UserID      	string  `json:"userId"`
-	Grade       	float64 `json:"grade"`
-	Comment 			string  `json:"comment"`
-	CreatedAt   	string  `json:"created_at"`
-	UpdatedAt   	string  `json:"updated_at"`
+	ID           string       `json:"id"`
+	DressmakerID string       `json:"dressmakerId"`
+	UserID       string       `json:"userId"`
+	Grade        float64      `json:"grade"`
+	Comment      string       `json:"comment"`
+	Reply        *ReviewReply `json:"reply,omitempty"`
+	Status       ReviewStatus `json:"status"`
+
+	Reports          []ReviewReport `json:"-"`
+	ReportCount      int            `json:"-"`
+	ModerationReason string         `json:"-"`
+
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
 }
 
 func NewReview(dressmakerID, userID, comment string, grade float64) *Review {
 	return &Review{
-		ID:          		uuid.New().String(),
-		DressmakerID: 	dressmakerID,
-		UserID:      		userID,
-		Grade:       		grade,
-		Comment: 				comment,
-		CreatedAt:   		time.Now().Format(time.RFC3339),
-		UpdatedAt:   		time.Now().Format(time.RFC3339),
+		ID:           uuid.New().String(),
+		DressmakerID: dressmakerID,
+		UserID:       userID,
+		Grade:        grade,
+		Comment:      comment,
+		Status:       ReviewStatusPublished,
+		CreatedAt:    time.Now().Format(time.RFC3339),
+		UpdatedAt:    time.Now().Format(time.RFC3339),
+	}
+}
+
+func (review *Review) Update(comment string, grade float64) {
+	review.Comment = comment
+	review.Grade = grade
+	review.UpdatedAt = time.Now().Format(time.RFC3339)
+}
+
+// SetReply adds the dressmaker's public reply to the review, or edits it if
+// one was already written.
+func (review *Review) SetReply(comment string) {
+	now := time.Now().Format(time.RFC3339)
+
+	if review.Reply == nil {
+		review.Reply = &ReviewReply{CreatedAt: now}
+	}
+
+	review.Reply.Comment = comment
+	review.Reply.UpdatedAt = now
+}
+
+// IsPublished reports whether the review is publicly listed and counted in
+// the dressmaker's grade. Reviews written before moderation have no status.
+func (review *Review) IsPublished() bool {
+	return review.Status == "" || review.Status == ReviewStatusPublished
+}
+
+// Hold sends the review to the moderation queue until an admin decides.
+func (review *Review) Hold(reason string) {
+	review.Status = ReviewStatusPending
+	review.ModerationReason = reason
+}
+
+func (review *Review) Approve() {
+	review.Status = ReviewStatusPublished
+	review.ModerationReason = ""
+	review.Reports = nil
+	review.ReportCount = 0
+}
+
+func (review *Review) Hide(reason string) {
+	review.Status = ReviewStatusHidden
+	review.ModerationReason = reason
+}
+
+// Report records a report against the review, returning false when the
+// reporter had already reported it.
+func (review *Review) Report(reporterID, reason string) bool {
+	for _, report := range review.Reports {
+		if report.ReporterID == reporterID {
+			return false
+		}
+	}
+
+	if review.Status == "" {
+		review.Status = ReviewStatusPublished
 	}
-}
\ No newline at end of file
+
+	review.Reports = append(review.Reports, ReviewReport{
+		ReporterID: reporterID,
+		Reason:     reason,
+		CreatedAt:  time.Now().Format(time.RFC3339),
+	})
+	review.ReportCount = len(review.Reports)
+
+	return true
+}
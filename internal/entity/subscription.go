@@ -2,6 +2,7 @@ package entity
 
 import (
 	"fmt"
+	"math"
 	"time"
 
 	"github.com/google/uuid"
@@ -13,6 +14,7 @@ const (
 	StatusActive   Status = "active"
 	StatusPending  Status = "pending"
 	StatusCanceled Status = "canceled"
+	StatusExpired  Status = "expired" // access ended without a renewal
 )
 
 type Subscription struct {
@@ -29,6 +31,12 @@ type Subscription struct {
 	GraceUntil *time.Time `json:"graceUntil,omitempty"` // até quando mantém acesso
 	GatewayId  *string    `json:"gatewayId,omitempty"`
 	PaymentURL *string    `json:"paymentURL,omitempty"`
+	// LastEventAt is when the newest gateway event applied to the
+	// subscription occurred.
+	LastEventAt *time.Time `json:"lastEventAt,omitempty"`
+	// ExpiryNoticeAt is when the dressmaker was last warned that access was
+	// about to end.
+	ExpiryNoticeAt *time.Time `json:"expiryNoticeAt,omitempty"`
 
 	CreatedAt time.Time `json:"createdAt"`
 	UpdatedAt time.Time `json:"updatedAt"`
@@ -51,6 +59,8 @@ func NewSubscription(dressmakerID string, plan Plan) (*Subscription, error) {
 		ID:           uuid.New().String(),
 		DressmakerID: dressmakerID,
 		Plan:         plan,
+		Price:        plan.Price,
+		Periodicity:  plan.Periodicity,
 		StartedAt:    &now,
 		ExpiresAt:    &expires,
 		Status:       StatusPending,
@@ -92,19 +102,121 @@ func (s *Subscription) Cancel(gracePeriodDays int) {
 	}
 }
 
-func (s *Subscription) Renew() error {
-	if s.Periodicity.PeriodicityType == "" {
-		return fmt.Errorf("periodicity type undefined")
+// DaysLeft is the number of days, rounded up, until the paid period ends.
+func (s *Subscription) DaysLeft() int {
+	if s.ExpiresAt == nil {
+		return 0
 	}
 
-	var duration time.Duration
-	switch s.Periodicity.PeriodicityType {
-	case MonthlyPeriodicity:
-		duration = 30 * 24 * time.Hour
-	case YearlyPeriodicity:
-		duration = 365 * 24 * time.Hour
-	default:
-		return fmt.Errorf("unsupported periodicity")
+	left := time.Until(*s.ExpiresAt)
+	if left <= 0 {
+		return 0
+	}
+
+	return int(math.Ceil(left.Hours() / 24))
+}
+
+// CancelAtPeriodEnd cancels the subscription but keeps access until the end
+// of the period already paid for.
+func (s *Subscription) CancelAtPeriodEnd() {
+	s.Cancel(s.DaysLeft())
+}
+
+// Reactivate undoes a cancellation while the grace period lasts.
+func (s *Subscription) Reactivate() error {
+	if s.Status != StatusCanceled {
+		return fmt.Errorf("subscription is not canceled")
+	}
+
+	if !s.IsInGracePeriod() {
+		return fmt.Errorf("the grace period is over")
+	}
+
+	s.Status = StatusActive
+	s.CanceledAt = nil
+	s.GraceUntil = nil
+
+	return nil
+}
+
+// ChangePlan swaps the plan of an active subscription. Changing the
+// periodicity starts a new period right away.
+func (s *Subscription) ChangePlan(plan Plan) error {
+	if plan.Periodicity.PeriodicityType != s.periodicity() {
+		duration, err := durationForPeriodicity(plan.Periodicity.PeriodicityType)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		expires := now.Add(duration)
+		s.StartedAt = &now
+		s.ExpiresAt = &expires
+	}
+
+	s.Plan = plan
+	s.Price = plan.Price
+	s.Periodicity = plan.Periodicity
+
+	return nil
+}
+
+// SubscriptionAdjustment is a manual change made to a subscription from the
+// back-office. Nil fields are left as they are.
+type SubscriptionAdjustment struct {
+	Status     *Status
+	ExpiresAt  *time.Time
+	GraceUntil *time.Time
+}
+
+func (s *Subscription) Adjust(adjustment SubscriptionAdjustment) error {
+	if adjustment.Status != nil {
+		switch *adjustment.Status {
+		case StatusActive, StatusPending:
+			s.CanceledAt = nil
+		case StatusCanceled:
+			if s.CanceledAt == nil {
+				now := time.Now()
+				s.CanceledAt = &now
+			}
+		case StatusExpired:
+			// keeps CanceledAt, as canceled subscriptions expire too
+		default:
+			return fmt.Errorf("unsupported status: %s", *adjustment.Status)
+		}
+
+		s.Status = *adjustment.Status
+	}
+
+	if adjustment.ExpiresAt != nil {
+		expires := *adjustment.ExpiresAt
+		s.ExpiresAt = &expires
+	}
+
+	if adjustment.GraceUntil != nil {
+		grace := *adjustment.GraceUntil
+		s.GraceUntil = &grace
+	}
+
+	return nil
+}
+
+// Activate marks the subscription as paid for when the gateway confirms the
+// checkout.
+func (s *Subscription) Activate(gatewayID string) {
+	s.Status = StatusActive
+	s.CanceledAt = nil
+	s.GraceUntil = nil
+
+	if gatewayID != "" {
+		s.GatewayId = &gatewayID
+	}
+}
+
+func (s *Subscription) Renew() error {
+	duration, err := durationForPeriodicity(s.periodicity())
+	if err != nil {
+		return err
 	}
 
 	now := time.Now()
@@ -117,6 +229,114 @@ func (s *Subscription) Renew() error {
 	return nil
 }
 
+// StartGracePeriod keeps access for the given days after a failed payment
+// while the gateway retries it. Later failures don't extend it.
+func (s *Subscription) StartGracePeriod(days int) {
+	if s.IsInGracePeriod() {
+		return
+	}
+
+	now := time.Now()
+	grace := now.AddDate(0, 0, days)
+	if s.ExpiresAt != nil && s.ExpiresAt.After(now) {
+		grace = s.ExpiresAt.AddDate(0, 0, days)
+	}
+
+	s.GraceUntil = &grace
+}
+
+// IsStaleEvent reports whether a gateway event that occurred at the given
+// time is older than one already applied, as gateways don't guarantee the
+// delivery order.
+func (s *Subscription) IsStaleEvent(occurredAt time.Time) bool {
+	return s.LastEventAt != nil && occurredAt.Before(*s.LastEventAt)
+}
+
+func (s *Subscription) RecordEvent(occurredAt time.Time) {
+	s.LastEventAt = &occurredAt
+}
+
+// HasAccess reports whether the dressmaker gets what the subscription pays
+// for, which lasts through the grace period.
+func (s *Subscription) HasAccess() bool {
+	return s.IsActive() || s.IsInGracePeriod()
+}
+
+// AccessEndsAt is when the dressmaker loses access unless the subscription
+// is renewed. It is nil when the subscription grants no access or never ends.
+func (s *Subscription) AccessEndsAt() *time.Time {
+	if !s.HasAccess() {
+		return nil
+	}
+
+	if s.GraceUntil != nil && (s.Status != StatusActive || s.ExpiresAt == nil || s.GraceUntil.After(*s.ExpiresAt)) {
+		return s.GraceUntil
+	}
+
+	return s.ExpiresAt
+}
+
+// NeedsExpiryNotice reports whether the dressmaker should be warned that
+// access ends within the given duration. Subscriptions the gateway renews
+// on its own aren't about to end, and the warning is sent once per period.
+func (s *Subscription) NeedsExpiryNotice(within time.Duration) bool {
+	endsAt := s.AccessEndsAt()
+	if endsAt == nil || time.Until(*endsAt) > within {
+		return false
+	}
+
+	if s.Status == StatusActive && s.GatewayId != nil && s.GraceUntil == nil {
+		return false
+	}
+
+	return s.ExpiryNoticeAt == nil || (s.StartedAt != nil && s.ExpiryNoticeAt.Before(*s.StartedAt))
+}
+
+func (s *Subscription) RecordExpiryNotice() {
+	now := time.Now()
+	s.ExpiryNoticeAt = &now
+}
+
+// Lapse handles a subscription whose period ended without a renewal: an
+// active one gets the grace period counted from the end of the period, and
+// it expires once access is over. It reports whether the subscription
+// changed.
+func (s *Subscription) Lapse(gracePeriodDays int) bool {
+	switch s.Status {
+	case StatusActive:
+		if !s.HasExpired() || s.IsInGracePeriod() {
+			return false
+		}
+
+		if s.GraceUntil == nil && gracePeriodDays > 0 {
+			grace := s.ExpiresAt.AddDate(0, 0, gracePeriodDays)
+			if grace.After(time.Now()) {
+				s.GraceUntil = &grace
+				return true
+			}
+		}
+	case StatusCanceled:
+		if s.IsInGracePeriod() {
+			return false
+		}
+	default:
+		return false
+	}
+
+	s.Status = StatusExpired
+	return true
+}
+
+// periodicity falls back to the plan's for subscriptions created before the
+// periodicity was copied onto them.
+func (s *Subscription) periodicity() PeriodicityType {
+	if s.Periodicity.PeriodicityType != "" {
+		return s.Periodicity.PeriodicityType
+	}
+
+	return s.Plan.Periodicity.PeriodicityType
+}
+
 func durationForPeriodicity(p PeriodicityType) (time.Duration, error) {
 	switch p {
 	case MonthlyPeriodicity:
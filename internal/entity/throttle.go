@@ -0,0 +1,82 @@
+package entity
+
+import "time"
+
+// maxLockout caps progressive lockouts so a key is never blocked for more
+// than a day.
+const maxLockout = 24 * time.Hour
+
+// ThrottlePolicy describes how attempts against a key are limited.
+type ThrottlePolicy struct {
+	// MaxAttempts within Window before the key is locked out.
+	MaxAttempts int
+	// Window after which earlier attempts and lockouts are forgotten.
+	Window time.Duration
+	// Backoff is the delay imposed after the first attempt, doubled for
+	// every following one. Zero disables backoff.
+	Backoff time.Duration
+	// Lockout is the duration of the first lockout, doubled for every
+	// following one.
+	Lockout time.Duration
+}
+
+// Throttle tracks attempts made against a key, such as an account's email,
+// a client IP or a phone number.
+type Throttle struct {
+	Key           string    `json:"key"`
+	Attempts      int       `json:"attempts"`
+	Lockouts      int       `json:"lockouts"`
+	LastAttemptAt time.Time `json:"lastAttemptAt"`
+	BlockedUntil  time.Time `json:"blockedUntil"`
+}
+
+func NewThrottle(key string) *Throttle {
+	return &Throttle{Key: key}
+}
+
+// RetryAfter returns how long the key is still blocked for.
+func (t *Throttle) RetryAfter(now time.Time) time.Duration {
+	if now.Before(t.BlockedUntil) {
+		return t.BlockedUntil.Sub(now)
+	}
+
+	return 0
+}
+
+// Register records an attempt, blocking the key with exponential backoff
+// and locking it out once policy.MaxAttempts is reached.
+func (t *Throttle) Register(policy ThrottlePolicy, now time.Time) {
+	if policy.Window > 0 && now.Sub(t.LastAttemptAt) > policy.Window {
+		t.Attempts = 0
+		t.Lockouts = 0
+	}
+
+	t.Attempts++
+	t.LastAttemptAt = now
+
+	if policy.MaxAttempts > 0 && t.Attempts >= policy.MaxAttempts {
+		t.Lockouts++
+		t.Attempts = 0
+		t.BlockedUntil = now.Add(doubled(policy.Lockout, t.Lockouts, maxLockout))
+		return
+	}
+
+	if policy.Backoff > 0 {
+		t.BlockedUntil = now.Add(doubled(policy.Backoff, t.Attempts, policy.Lockout))
+	}
+}
+
+// doubled returns base doubled n-1 times, never exceeding limit when one
+// is set.
+func doubled(base time.Duration, n int, limit time.Duration) time.Duration {
+	delay := base
+	for i := 1; i < n && (limit <= 0 || delay < limit); i++ {
+		delay *= 2
+	}
+
+	if limit > 0 && delay > limit {
+		return limit
+	}
+
+	return delay
+}
@@ -8,15 +8,21 @@ import (
 )
 
 type User struct {
-	ID       string   `json:"id"`
-	Email    string   `json:"email"`
-	Password string   `json:"-"`
-	Name     string   `json:"name"`
-	Enabled  bool     `json:"enabled"`
-	Location Location `json:"location"`
+	ID        string   `json:"id"`
+	Email     string   `json:"email"`
+	Password  string   `json:"-"`
+	Name      string   `json:"name"`
+	Enabled   bool     `json:"enabled"`
+	Admin     bool     `json:"admin"`
+	Suspended bool     `json:"suspended"` // disabled by an admin
+	Phone     string   `json:"phone,omitempty"`
+	Location  Location `json:"location"`
 
-	CreatedAt string `json:"created_at"`
-	UpdatedAt string `json:"updated_at"`
+	EmailVerified   bool       `json:"emailVerified"`
+	EmailVerifiedAt *time.Time `json:"emailVerifiedAt,omitempty"`
+	PhoneVerifiedAt *time.Time `json:"phoneVerifiedAt,omitempty"`
+	CreatedAt       string     `json:"created_at"`
+	UpdatedAt       string     `json:"updated_at"`
 }
 
 func NewUser(email, password, name string, location Location) (*User, error) {
@@ -46,6 +52,47 @@ func (user *User) Disable() {
 	user.Enabled = false
 }
 
+// Suspend disables the user until an admin reinstates them. Unlike a pending
+// phone verification, the owner can't lift a suspension.
+func (user *User) Suspend() {
+	user.Disable()
+	user.Suspended = true
+}
+
+func (user *User) Reinstate() {
+	user.Suspended = false
+	user.Enable()
+}
+
+// SetPhone changes the user's phone, which then needs to be verified again.
+func (user *User) SetPhone(phone string) {
+	if phone == user.Phone {
+		return
+	}
+
+	user.Phone = phone
+	user.PhoneVerifiedAt = nil
+}
+
+func (user *User) VerifyPhone(phone string) {
+	now := time.Now()
+	user.Phone = phone
+	user.PhoneVerifiedAt = &now
+}
+
+func (user *User) VerifyEmail() {
+	now := time.Now()
+	user.EmailVerified = true
+	user.EmailVerifiedAt = &now
+}
+
+// ChangeEmail swaps the email for a new address the owner already confirmed.
+func (user *User) ChangeEmail(email string) {
+	user.Email = email
+	user.VerifyEmail()
+	user.UpdatedAt = time.Now().Format(time.RFC3339)
+}
+
 func (user *User) UpdateLocation(location Location) {
 	user.Location = location
 }
@@ -55,3 +102,15 @@ func (user *User) UpdateUser(name string, location Location) {
 	user.Location = location
 	user.UpdatedAt = time.Now().Format(time.RFC3339)
 }
+
+func (user *User) ChangePassword(password string) error {
+	passHash, err := pkg.Encrypt(password)
+	if err != nil {
+		return err
+	}
+
+	user.Password = string(passHash)
+	user.UpdatedAt = time.Now().Format(time.RFC3339)
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package entity
+
+import "time"
+
+type WebhookEventStatus string
+
+const (
+	WebhookEventReceived  WebhookEventStatus = "received" // being processed
+	WebhookEventProcessed WebhookEventStatus = "processed"
+	WebhookEventIgnored   WebhookEventStatus = "ignored" // irrelevant or older than what was already applied
+	WebhookEventFailed    WebhookEventStatus = "failed"
+)
+
+// WebhookEvent is a payment gateway notification as it was delivered, kept
+// so retried deliveries are only applied once and events can be replayed.
+type WebhookEvent struct {
+	ID      string             `json:"id"` // the gateway's event ID
+	Gateway string             `json:"gateway"`
+	Type    string             `json:"type"` // the event type as the gateway names it
+	Payload []byte             `json:"-"`
+	Status  WebhookEventStatus `json:"status"`
+	Error   string             `json:"error,omitempty"`
+	// Attempts counts how many times the event was processed, replays
+	// included.
+	Attempts int `json:"attempts"`
+
+	OccurredAt  time.Time  `json:"occurredAt"` // when the gateway created it
+	ReceivedAt  time.Time  `json:"receivedAt"`
+	ProcessedAt *time.Time `json:"processedAt,omitempty"`
+}
+
+func NewWebhookEvent(id, gateway, eventType string, payload []byte, occurredAt time.Time) *WebhookEvent {
+	return &WebhookEvent{
+		ID:         id,
+		Gateway:    gateway,
+		Type:       eventType,
+		Payload:    payload,
+		Status:     WebhookEventReceived,
+		OccurredAt: occurredAt,
+		ReceivedAt: time.Now(),
+	}
+}
+
+// IsSettled reports whether the event was already applied or deliberately
+// skipped, so delivering it again must have no effect.
+func (e *WebhookEvent) IsSettled() bool {
+	return e.Status == WebhookEventProcessed || e.Status == WebhookEventIgnored
+}
+
+// CanBeClaimed reports whether a delivery may process the event again, which
+// it can when the last attempt failed, or when the one in progress was
+// received before staleBefore and must have died without recording its
+// outcome. Settled events can only be claimed when settled is set, to apply
+// them again on purpose.
+func (e *WebhookEvent) CanBeClaimed(staleBefore time.Time, settled bool) bool {
+	if e.IsSettled() {
+		return settled
+	}
+
+	return e.Status == WebhookEventFailed || (e.Status == WebhookEventReceived && e.ReceivedAt.Before(staleBefore))
+}
+
+// Claim marks the event as being processed again by the current delivery.
+func (e *WebhookEvent) Claim() {
+	e.Status = WebhookEventReceived
+	e.ReceivedAt = time.Now()
+}
+
+// Finish records the outcome of processing the event.
+func (e *WebhookEvent) Finish(status WebhookEventStatus, reason string) {
+	now := time.Now()
+	e.Status = status
+	e.Error = reason
+	e.Attempts++
+	e.ProcessedAt = &now
+}
@@ -0,0 +1,39 @@
+package factory
+
+import (
+	"fmt"
+
+	"github.com/paulozy/costurai/configs"
+	"github.com/paulozy/costurai/internal/infra/database"
+	"github.com/paulozy/costurai/internal/infra/database/firestore"
+	"github.com/paulozy/costurai/internal/infra/database/memory"
+	"github.com/paulozy/costurai/internal/infra/database/postgres"
+)
+
+const (
+	Firestore string = "firestore"
+	Postgres  string = "postgres"
+	Memory    string = "memory"
+)
+
+// NewRepositories builds the repositories of the backend selected by
+// DB_TYPE, running pending migrations first on Postgres.
+func NewRepositories(cfg *configs.Config) *database.Repositories {
+	switch cfg.DBType {
+	case Postgres:
+		db := postgres.NewPostgresClient(cfg)
+		if err := postgres.RunMigrations(db); err != nil {
+			panic(err)
+		}
+
+		return postgres.NewPostgresRepositories(db)
+	case Memory:
+		return memory.NewMemoryRepositories()
+	case Firestore, "":
+		client := firestore.NewFirestoreClient(cfg.FirebaseProjectId)
+
+		return firestore.NewFirestoreRepositories(client)
+	default:
+		panic(fmt.Sprintf("unsupported DB_TYPE: %s", cfg.DBType))
+	}
+}
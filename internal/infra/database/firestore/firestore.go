@@ -5,6 +5,8 @@ import (
 	"log"
 
 	"cloud.google.com/go/firestore"
+	"github.com/paulozy/costurai/internal/infra/database"
+	"github.com/paulozy/costurai/internal/infra/database/firestore/repositories"
 )
 
 func NewFirestoreClient(projectId string) *firestore.Client {
@@ -17,3 +19,21 @@ func NewFirestoreClient(projectId string) *firestore.Client {
 
 	return client
 }
+
+func NewFirestoreRepositories(client *firestore.Client) *database.Repositories {
+	return &database.Repositories{
+		Dressmaker:        repositories.NewFirestoreDressmakerRepository(client),
+		User:              repositories.NewFirestoreUserRepository(client),
+		Subscription:      repositories.NewFirestoreSubscriptionRepository(client),
+		DressmakerReviews: repositories.NewFirestoreReviewsRepository(client),
+		RefreshToken:      repositories.NewFirestoreRefreshTokenRepository(client),
+		OneTimeToken:      repositories.NewFirestoreOneTimeTokenRepository(client),
+		OTPCode:           repositories.NewFirestoreOTPCodeRepository(client),
+		Throttle:          repositories.NewFirestoreThrottleRepository(client),
+		OIDCLoginState:    repositories.NewFirestoreOIDCLoginStateRepository(client),
+		Identity:          repositories.NewFirestoreIdentityRepository(client),
+		AuditLog:          repositories.NewFirestoreAuditLogRepository(client),
+		WebhookEvent:      repositories.NewFirestoreWebhookEventRepository(client),
+		Lock:              repositories.NewFirestoreLockRepository(client),
+	}
+}
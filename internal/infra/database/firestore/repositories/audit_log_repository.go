@@ -0,0 +1,41 @@
+package repositories
+
+import (
+	"context"
+
+	"cloud.google.com/go/firestore"
+	"github.com/paulozy/costurai/internal/entity"
+	"github.com/paulozy/costurai/internal/infra/database"
+)
+
+type FirestoreAuditLogRepository struct {
+	Entries *firestore.CollectionRef
+}
+
+func NewFirestoreAuditLogRepository(db *firestore.Client) *FirestoreAuditLogRepository {
+	return &FirestoreAuditLogRepository{
+		Entries: db.Collection("audit_log"),
+	}
+}
+
+func (r *FirestoreAuditLogRepository) Create(ctx context.Context, entry *entity.AuditEntry) error {
+	_, err := r.Entries.Doc(entry.ID).Create(ctx, entry)
+	return err
+}
+
+func (r *FirestoreAuditLogRepository) Search(ctx context.Context, params database.AuditLogSearchParams) ([]entity.AuditEntry, int64, error) {
+	query := r.Entries.Query
+	if params.ActorID != "" {
+		query = query.Where("ActorID", "==", params.ActorID)
+	}
+
+	if params.TargetType != "" {
+		query = query.Where("TargetType", "==", params.TargetType)
+	}
+
+	if params.TargetID != "" {
+		query = query.Where("TargetID", "==", params.TargetID)
+	}
+
+	return searchPage[entity.AuditEntry](ctx, query.OrderBy("CreatedAt", firestore.Desc), params.Limit, params.Page)
+}
@@ -6,26 +6,25 @@ import (
 
 	"cloud.google.com/go/firestore"
 	"github.com/paulozy/costurai/internal/entity"
+	"github.com/paulozy/costurai/internal/infra/database"
 	"github.com/paulozy/costurai/pkg"
 	"google.golang.org/api/iterator"
 )
 
 type FirestoreDressmakerRepository struct {
+	Client      *firestore.Client
 	Dressmakers *firestore.CollectionRef
-	Ctx         *context.Context
 }
 
 func NewFirestoreDressmakerRepository(db *firestore.Client) *FirestoreDressmakerRepository {
-	ctx := context.Background()
-
 	return &FirestoreDressmakerRepository{
+		Client:      db,
 		Dressmakers: db.Collection("dressmakers"),
-		Ctx:         &ctx,
 	}
 }
 
-func (r *FirestoreDressmakerRepository) Create(dressmaker *entity.Dressmaker) error {
-	_, err := r.Dressmakers.NewDoc().Create(*r.Ctx, dressmaker)
+func (r *FirestoreDressmakerRepository) Create(ctx context.Context, dressmaker *entity.Dressmaker) error {
+	_, err := r.Dressmakers.NewDoc().Create(ctx, dressmaker)
 
 	if err != nil {
 		return err
@@ -34,12 +33,12 @@ func (r *FirestoreDressmakerRepository) Create(dressmaker *entity.Dressmaker) er
 	return nil
 }
 
-func (r *FirestoreDressmakerRepository) FindByEmail(email string) (*entity.Dressmaker, error) {
+func (r *FirestoreDressmakerRepository) FindByEmail(ctx context.Context, email string) (*entity.Dressmaker, error) {
 	query := r.Dressmakers.Where(
 		"Email", "==", email,
 	).Limit(1)
 
-	docs, err := query.Documents(*r.Ctx).GetAll()
+	docs, err := query.Documents(ctx).GetAll()
 	if err != nil {
 		return nil, err
 	}
@@ -58,9 +57,9 @@ func (r *FirestoreDressmakerRepository) FindByEmail(email string) (*entity.Dress
 	return dressmaker, nil
 }
 
-func (r *FirestoreDressmakerRepository) Exists(email string) (bool, error) {
+func (r *FirestoreDressmakerRepository) Exists(ctx context.Context, email string) (bool, error) {
 	query := r.Dressmakers.Where("Email", "==", email).Limit(1)
-	docs, err := query.Documents(*r.Ctx).GetAll()
+	docs, err := query.Documents(ctx).GetAll()
 	if err != nil {
 		return false, err
 	}
@@ -68,9 +67,9 @@ func (r *FirestoreDressmakerRepository) Exists(email string) (bool, error) {
 	return len(docs) > 0, nil
 }
 
-func (r *FirestoreDressmakerRepository) FindByID(id string) (*entity.Dressmaker, error) {
+func (r *FirestoreDressmakerRepository) FindByID(ctx context.Context, id string) (*entity.Dressmaker, error) {
 	query := r.Dressmakers.Where("ID", "==", id).Limit(1)
-	docs, err := query.Documents(*r.Ctx).GetAll()
+	docs, err := query.Documents(ctx).GetAll()
 	if err != nil {
 		return nil, err
 	}
@@ -89,46 +88,109 @@ func (r *FirestoreDressmakerRepository) FindByID(id string) (*entity.Dressmaker,
 	return dressmaker, nil
 }
 
-func (r *FirestoreDressmakerRepository) FindByProximity(latitude, longitude float64, maxDistance int) ([]entity.Dressmaker, error) {
-	iter := r.Dressmakers.Documents(*r.Ctx)
-	defer iter.Stop()
+func (r *FirestoreDressmakerRepository) FindByProximity(ctx context.Context, latitude, longitude float64, maxDistance int) ([]entity.Dressmaker, error) {
+	prefixes := pkg.GeohashCoveringPrefixes(latitude, longitude, float64(maxDistance))
+
+	queries := []firestore.Query{r.Dressmakers.Query}
+	if len(prefixes) > 0 {
+		queries = make([]firestore.Query, 0, len(prefixes))
+		for _, prefix := range prefixes {
+			queries = append(queries, r.Dressmakers.
+				Where("Geohash", ">=", prefix).
+				Where("Geohash", "<=", prefix+"~"))
+		}
+	}
 
+	seen := map[string]bool{}
 	var dressmakers []entity.Dressmaker
 
+	for _, query := range queries {
+		iter := query.Documents(ctx)
+
+		for {
+			doc, err := iter.Next()
+			if err == iterator.Done {
+				break
+			}
+			if err != nil {
+				iter.Stop()
+				return nil, err
+			}
+
+			var dressmaker entity.Dressmaker
+			if err := doc.DataTo(&dressmaker); err != nil {
+				iter.Stop()
+				return nil, err
+			}
+
+			if seen[dressmaker.ID] {
+				continue
+			}
+			seen[dressmaker.ID] = true
+
+			// geohash cells are squares, so the exact radius check still applies
+			dist := pkg.HaversineDistance(
+				latitude,
+				longitude,
+				dressmaker.Address.Location.Latitude,
+				dressmaker.Address.Location.Longitude,
+			)
+
+			if dist <= float64(maxDistance) {
+				dressmakers = append(dressmakers, dressmaker)
+			}
+		}
+
+		iter.Stop()
+	}
+
+	return dressmakers, nil
+}
+
+// BackfillGeohashes writes the geohash of every dressmaker document that is
+// missing it or has a stale one, returning how many documents were updated.
+func (r *FirestoreDressmakerRepository) BackfillGeohashes(ctx context.Context) (int, error) {
+	iter := r.Dressmakers.Documents(ctx)
+	defer iter.Stop()
+
+	updated := 0
+
 	for {
 		doc, err := iter.Next()
 		if err == iterator.Done {
 			break
 		}
 		if err != nil {
-			return nil, err
+			return updated, err
 		}
 
 		var dressmaker entity.Dressmaker
 		if err := doc.DataTo(&dressmaker); err != nil {
-			return nil, err
+			return updated, err
 		}
 
-		fmt.Printf("latitude: %.2f, longitude: %.2f", latitude, longitude)
-
-		dist := pkg.HaversineDistance(
-			latitude,
-			longitude,
-			dressmaker.Address.Location.Latitude,
-			dressmaker.Address.Location.Longitude,
-		)
+		current := dressmaker.Geohash
+		dressmaker.UpdateGeohash()
+		if current == dressmaker.Geohash {
+			continue
+		}
 
-		if dist <= float64(maxDistance) {
-			dressmakers = append(dressmakers, dressmaker)
+		_, err = doc.Ref.Set(ctx, map[string]interface{}{
+			"Geohash": dressmaker.Geohash,
+		}, firestore.MergeAll)
+		if err != nil {
+			return updated, err
 		}
+
+		updated++
 	}
 
-	return dressmakers, nil
+	return updated, nil
 }
 
-func (r *FirestoreDressmakerRepository) Update(dressmaker *entity.Dressmaker) error {
+func (r *FirestoreDressmakerRepository) Update(ctx context.Context, dressmaker *entity.Dressmaker) error {
 	query := r.Dressmakers.Where("ID", "==", dressmaker.ID).Limit(1)
-	docs, err := query.Documents(*r.Ctx).GetAll()
+	docs, err := query.Documents(ctx).GetAll()
 	if err != nil {
 		return err
 	}
@@ -139,10 +201,12 @@ func (r *FirestoreDressmakerRepository) Update(dressmaker *entity.Dressmaker) er
 
 	dressmakerRef := docs[0].Ref
 
-	_, err = dressmakerRef.Set(*r.Ctx, map[string]interface{}{
-		"Name":    dressmaker.Name,
-		"Email":   dressmaker.Email,
-		"Contact": dressmaker.Contact,
+	_, err = dressmakerRef.Set(ctx, map[string]interface{}{
+		"Name":     dressmaker.Name,
+		"Email":    dressmaker.Email,
+		"Password": dressmaker.Password,
+		"Contact":  dressmaker.Contact,
+		"Phone":    dressmaker.Phone,
 		"Address": map[string]interface{}{
 			"Street": dressmaker.Address.Street,
 			"Number": dressmaker.Address.Number,
@@ -153,12 +217,116 @@ func (r *FirestoreDressmakerRepository) Update(dressmaker *entity.Dressmaker) er
 				"Longitude": dressmaker.Address.Location.Longitude,
 			},
 		},
-		"Services":  dressmaker.Services,
-		"Grade":     dressmaker.Grade,
-		"Enabled":   dressmaker.Enabled,
-		"CreatedAt": dressmaker.CreatedAt,
-		"UpdatedAt": dressmaker.UpdatedAt,
+		"Geohash":         dressmaker.Geohash,
+		"Services":        dressmaker.Services,
+		"SubscriptionId":  dressmaker.SubscriptionId,
+		"Enabled":         dressmaker.Enabled,
+		"Suspended":       dressmaker.Suspended,
+		"PhoneVerifiedAt": dressmaker.PhoneVerifiedAt,
+		"EmailVerified":   dressmaker.EmailVerified,
+		"EmailVerifiedAt": dressmaker.EmailVerifiedAt,
+		"CreatedAt":       dressmaker.CreatedAt,
+		"UpdatedAt":       dressmaker.UpdatedAt,
 	}, firestore.MergeAll)
 
 	return err
 }
+
+func (r *FirestoreDressmakerRepository) Search(ctx context.Context, params database.AccountSearchParams) ([]entity.Dressmaker, int64, error) {
+	return searchPage[entity.Dressmaker](ctx, accountSearchQuery(r.Dressmakers, params), params.Limit, params.Page)
+}
+
+// ApplyReviewDelta updates the review aggregates inside a transaction, so
+// concurrent reviews of the same dressmaker never overwrite each other.
+func (r *FirestoreDressmakerRepository) ApplyReviewDelta(ctx context.Context, id string, countDelta int64, gradeDelta float64) error {
+	return r.Client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		docs, err := tx.Documents(r.Dressmakers.Where("ID", "==", id).Limit(1)).GetAll()
+		if err != nil {
+			return err
+		}
+
+		if len(docs) == 0 {
+			return fmt.Errorf("no dressmaker found with ID: %s", id)
+		}
+
+		var dressmaker entity.Dressmaker
+		if err := docs[0].DataTo(&dressmaker); err != nil {
+			return err
+		}
+
+		dressmaker.ApplyReviewDelta(countDelta, gradeDelta)
+
+		return tx.Set(docs[0].Ref, map[string]interface{}{
+			"ReviewCount": dressmaker.ReviewCount,
+			"GradeSum":    dressmaker.GradeSum,
+			"Grade":       dressmaker.Grade,
+			"Score":       dressmaker.Score,
+		}, firestore.MergeAll)
+	})
+}
+
+// BackfillReviewAggregates recomputes the review aggregates of every
+// dressmaker from its published reviews, returning how many were changed.
+func (r *FirestoreDressmakerRepository) BackfillReviewAggregates(ctx context.Context, reviews *DressmakerReviewsRepository) (int, error) {
+	iter := r.Dressmakers.Documents(ctx)
+	defer iter.Stop()
+
+	updated := 0
+
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return updated, err
+		}
+
+		var dressmaker entity.Dressmaker
+		if err := doc.DataTo(&dressmaker); err != nil {
+			return updated, err
+		}
+
+		dressmakerReviews, err := reviews.FindByDressmakerID(ctx, dressmaker.ID)
+		if err != nil {
+			return updated, err
+		}
+
+		// held and removed reviews don't count towards the grade
+		count, sum := int64(0), 0.0
+		for _, review := range dressmakerReviews {
+			if !review.IsPublished() {
+				continue
+			}
+
+			count++
+			sum += review.Grade
+		}
+
+		countDelta := count - dressmaker.ReviewCount
+		gradeDelta := sum - dressmaker.GradeSum
+		if countDelta == 0 && gradeDelta == 0 && reviewAggregatesComputed(dressmaker) {
+			continue
+		}
+
+		if err := r.ApplyReviewDelta(ctx, dressmaker.ID, countDelta, gradeDelta); err != nil {
+			return updated, err
+		}
+
+		updated++
+	}
+
+	return updated, nil
+}
+
+// reviewAggregatesComputed reports whether the stored grade and score already
+// follow from the review count and grade sum. Dressmakers stored before the
+// aggregates existed have a count but no score, while those without reviews
+// have every aggregate at zero.
+func reviewAggregatesComputed(dressmaker entity.Dressmaker) bool {
+	if dressmaker.ReviewCount == 0 {
+		return dressmaker.GradeSum == 0 && dressmaker.Grade == 0 && dressmaker.Score == 0
+	}
+
+	return dressmaker.Score != 0
+}
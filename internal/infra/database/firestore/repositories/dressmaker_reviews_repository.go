@@ -2,30 +2,171 @@ package repositories
 
 import (
 	"context"
+	"fmt"
+	"sort"
 
 	"cloud.google.com/go/firestore"
 	"github.com/paulozy/costurai/internal/entity"
+	"github.com/paulozy/costurai/internal/infra/database"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type DressmakerReviewsRepository struct {
 	Reviews *firestore.CollectionRef
-	Ctx     *context.Context
 }
 
 func NewFirestoreReviewsRepository(db *firestore.Client) *DressmakerReviewsRepository {
-	ctx := context.Background()
-
 	return &DressmakerReviewsRepository{
 		Reviews: db.Collection("reviews"),
-		Ctx:     &ctx,
 	}
 }
 
-func (r *DressmakerReviewsRepository) Create(review *entity.Review) error {
-	_, err := r.Reviews.NewDoc().Create(*r.Ctx, review)
+// Create stores the review under an ID made of the dressmaker and the user,
+// so concurrent reviews by the same user can't both be stored.
+func (r *DressmakerReviewsRepository) Create(ctx context.Context, review *entity.Review) error {
+	_, err := r.Reviews.Doc(review.DressmakerID+"_"+review.UserID).Create(ctx, review)
+	if status.Code(err) == codes.AlreadyExists {
+		return database.ErrAlreadyExists
+	}
+
+	return err
+}
+
+func (r *DressmakerReviewsRepository) FindByID(ctx context.Context, id string) (*entity.Review, error) {
+	docs, err := r.Reviews.Where("ID", "==", id).Limit(1).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(docs) == 0 {
+		return nil, nil
+	}
+
+	var review entity.Review
+	if err := docs[0].DataTo(&review); err != nil {
+		return nil, err
+	}
+
+	return &review, nil
+}
+
+func (r *DressmakerReviewsRepository) FindByDressmakerAndUser(ctx context.Context, dressmakerID, userID string) (*entity.Review, error) {
+	query := r.Reviews.
+		Where("DressmakerID", "==", dressmakerID).
+		Where("UserID", "==", userID).
+		Limit(1)
+
+	docs, err := query.Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(docs) == 0 {
+		return nil, nil
+	}
+
+	var review entity.Review
+	if err := docs[0].DataTo(&review); err != nil {
+		return nil, err
+	}
+
+	return &review, nil
+}
+
+func (r *DressmakerReviewsRepository) FindByDressmakerID(ctx context.Context, dressmakerID string) ([]entity.Review, error) {
+	docs, err := r.Reviews.Where("DressmakerID", "==", dressmakerID).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	reviews := make([]entity.Review, 0, len(docs))
+	for _, doc := range docs {
+		var review entity.Review
+		if err := doc.DataTo(&review); err != nil {
+			return nil, err
+		}
+
+		reviews = append(reviews, review)
+	}
+
+	return reviews, nil
+}
+
+// FindForModeration merges the pending reviews with the published ones that
+// have been reported, since Firestore cannot OR across different fields.
+func (r *DressmakerReviewsRepository) FindForModeration(ctx context.Context) ([]entity.Review, error) {
+	queries := []firestore.Query{
+		r.Reviews.Where("Status", "==", entity.ReviewStatusPending),
+		r.Reviews.Where("Status", "==", entity.ReviewStatusPublished).Where("ReportCount", ">", 0),
+	}
+
+	seen := map[string]bool{}
+	reviews := []entity.Review{}
+
+	for _, query := range queries {
+		docs, err := query.Documents(ctx).GetAll()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, doc := range docs {
+			var review entity.Review
+			if err := doc.DataTo(&review); err != nil {
+				return nil, err
+			}
+
+			if seen[review.ID] {
+				continue
+			}
+
+			seen[review.ID] = true
+			reviews = append(reviews, review)
+		}
+	}
+
+	sort.Slice(reviews, func(i, j int) bool {
+		if reviews[i].ReportCount != reviews[j].ReportCount {
+			return reviews[i].ReportCount > reviews[j].ReportCount
+		}
+
+		return reviews[i].CreatedAt < reviews[j].CreatedAt
+	})
+
+	return reviews, nil
+}
+
+func (r *DressmakerReviewsRepository) Update(ctx context.Context, review *entity.Review) error {
+	ref, err := r.findRef(ctx, review.ID)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	_, err = ref.Set(ctx, review)
+
+	return err
+}
+
+func (r *DressmakerReviewsRepository) Delete(ctx context.Context, id string) error {
+	ref, err := r.findRef(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	_, err = ref.Delete(ctx)
+
+	return err
+}
+
+func (r *DressmakerReviewsRepository) findRef(ctx context.Context, id string) (*firestore.DocumentRef, error) {
+	docs, err := r.Reviews.Where("ID", "==", id).Limit(1).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(docs) == 0 {
+		return nil, fmt.Errorf("no review found with ID: %s", id)
+	}
+
+	return docs[0].Ref, nil
 }
@@ -0,0 +1,50 @@
+package repositories
+
+import (
+	"context"
+
+	"cloud.google.com/go/firestore"
+	"github.com/paulozy/costurai/internal/entity"
+	"github.com/paulozy/costurai/pkg"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// FirestoreIdentityRepository keys documents by a hash of the issuer,
+// subject and account type, which keeps each link unique.
+type FirestoreIdentityRepository struct {
+	Identities *firestore.CollectionRef
+}
+
+func NewFirestoreIdentityRepository(db *firestore.Client) *FirestoreIdentityRepository {
+	return &FirestoreIdentityRepository{
+		Identities: db.Collection("identities"),
+	}
+}
+
+func identityDocID(issuer, subject, accountType string) string {
+	return pkg.HashToken(issuer + "\n" + subject + "\n" + accountType)
+}
+
+func (r *FirestoreIdentityRepository) Create(ctx context.Context, identity *entity.Identity) error {
+	_, err := r.Identities.Doc(identityDocID(identity.Issuer, identity.Subject, identity.AccountType)).Create(ctx, identity)
+	return err
+}
+
+func (r *FirestoreIdentityRepository) FindByIssuerAndSubject(ctx context.Context, issuer, subject, accountType string) (*entity.Identity, error) {
+	doc, err := r.Identities.Doc(identityDocID(issuer, subject, accountType)).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var identity entity.Identity
+	if err := doc.DataTo(&identity); err != nil {
+		return nil, err
+	}
+
+	return &identity, nil
+}
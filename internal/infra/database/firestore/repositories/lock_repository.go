@@ -0,0 +1,88 @@
+package repositories
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/paulozy/costurai/internal/entity"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// FirestoreLockRepository stores one document per lock name.
+type FirestoreLockRepository struct {
+	Client *firestore.Client
+	Locks  *firestore.CollectionRef
+}
+
+func NewFirestoreLockRepository(db *firestore.Client) *FirestoreLockRepository {
+	return &FirestoreLockRepository{
+		Client: db,
+		Locks:  db.Collection("locks"),
+	}
+}
+
+func (r *FirestoreLockRepository) doc(name string) *firestore.DocumentRef {
+	return r.Locks.Doc(url.PathEscape(name))
+}
+
+func (r *FirestoreLockRepository) Acquire(ctx context.Context, name, holder string, ttl time.Duration) (bool, error) {
+	ref := r.doc(name)
+	acquired := false
+
+	err := r.Client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		acquired = false
+
+		doc, err := tx.Get(ref)
+		if err != nil && status.Code(err) != codes.NotFound {
+			return err
+		}
+
+		if err == nil {
+			var lock entity.Lock
+			if err := doc.DataTo(&lock); err != nil {
+				return err
+			}
+
+			if !lock.CanBeAcquiredBy(holder, time.Now()) {
+				return nil
+			}
+		}
+
+		acquired = true
+		return tx.Set(ref, entity.NewLock(name, holder, ttl))
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return acquired, nil
+}
+
+func (r *FirestoreLockRepository) Release(ctx context.Context, name, holder string) error {
+	ref := r.doc(name)
+
+	return r.Client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(ref)
+		if status.Code(err) == codes.NotFound {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		var lock entity.Lock
+		if err := doc.DataTo(&lock); err != nil {
+			return err
+		}
+
+		if lock.Holder != holder {
+			return nil
+		}
+
+		return tx.Delete(ref)
+	})
+}
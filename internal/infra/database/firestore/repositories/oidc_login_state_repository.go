@@ -0,0 +1,59 @@
+package repositories
+
+import (
+	"context"
+
+	"cloud.google.com/go/firestore"
+	"github.com/paulozy/costurai/internal/entity"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// FirestoreOIDCLoginStateRepository stores one document per login, keyed by
+// the state hash.
+type FirestoreOIDCLoginStateRepository struct {
+	Client *firestore.Client
+	States *firestore.CollectionRef
+}
+
+func NewFirestoreOIDCLoginStateRepository(db *firestore.Client) *FirestoreOIDCLoginStateRepository {
+	return &FirestoreOIDCLoginStateRepository{
+		Client: db,
+		States: db.Collection("oidc_login_states"),
+	}
+}
+
+func (r *FirestoreOIDCLoginStateRepository) Create(ctx context.Context, state *entity.OIDCLoginState) error {
+	_, err := r.States.Doc(state.StateHash).Create(ctx, state)
+	return err
+}
+
+func (r *FirestoreOIDCLoginStateRepository) Consume(ctx context.Context, stateHash string) (*entity.OIDCLoginState, error) {
+	ref := r.States.Doc(stateHash)
+	var state *entity.OIDCLoginState
+
+	err := r.Client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		state = nil
+
+		doc, err := tx.Get(ref)
+		if status.Code(err) == codes.NotFound {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var found entity.OIDCLoginState
+		if err := doc.DataTo(&found); err != nil {
+			return err
+		}
+
+		state = &found
+		return tx.Delete(ref)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
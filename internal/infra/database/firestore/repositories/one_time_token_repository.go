@@ -0,0 +1,102 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/paulozy/costurai/internal/entity"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type FirestoreOneTimeTokenRepository struct {
+	Client *firestore.Client
+	Tokens *firestore.CollectionRef
+}
+
+func NewFirestoreOneTimeTokenRepository(db *firestore.Client) *FirestoreOneTimeTokenRepository {
+	return &FirestoreOneTimeTokenRepository{
+		Client: db,
+		Tokens: db.Collection("one_time_tokens"),
+	}
+}
+
+func (r *FirestoreOneTimeTokenRepository) Create(ctx context.Context, token *entity.OneTimeToken) error {
+	_, err := r.Tokens.Doc(token.ID).Create(ctx, token)
+	return err
+}
+
+func (r *FirestoreOneTimeTokenRepository) FindByHash(ctx context.Context, purpose entity.OneTimeTokenPurpose, hash string) (*entity.OneTimeToken, error) {
+	docs, err := r.Tokens.
+		Where("Purpose", "==", purpose).
+		Where("TokenHash", "==", hash).
+		Limit(1).
+		Documents(ctx).
+		GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(docs) == 0 {
+		return nil, nil
+	}
+
+	var token entity.OneTimeToken
+	if err := docs[0].DataTo(&token); err != nil {
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+func (r *FirestoreOneTimeTokenRepository) MarkUsed(ctx context.Context, id string) (bool, error) {
+	ref := r.Tokens.Doc(id)
+	marked := false
+
+	err := r.Client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		marked = false
+
+		doc, err := tx.Get(ref)
+		if status.Code(err) == codes.NotFound {
+			return fmt.Errorf("no one-time token found with ID: %s", id)
+		}
+		if err != nil {
+			return err
+		}
+
+		var token entity.OneTimeToken
+		if err := doc.DataTo(&token); err != nil {
+			return err
+		}
+
+		if token.UsedAt != nil {
+			return nil
+		}
+
+		marked = true
+		return tx.Update(ref, []firestore.Update{{Path: "UsedAt", Value: time.Now()}})
+	})
+
+	return marked, err
+}
+
+func (r *FirestoreOneTimeTokenRepository) DeleteBySubject(ctx context.Context, purpose entity.OneTimeTokenPurpose, subject string) error {
+	docs, err := r.Tokens.
+		Where("Purpose", "==", purpose).
+		Where("Subject", "==", subject).
+		Documents(ctx).
+		GetAll()
+	if err != nil {
+		return err
+	}
+
+	for _, doc := range docs {
+		if _, err := doc.Ref.Delete(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
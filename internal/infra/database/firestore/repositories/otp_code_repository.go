@@ -0,0 +1,79 @@
+package repositories
+
+import (
+	"context"
+
+	"cloud.google.com/go/firestore"
+	"github.com/paulozy/costurai/internal/entity"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// FirestoreOTPCodeRepository stores one document per phone number, keyed by
+// the phone itself.
+type FirestoreOTPCodeRepository struct {
+	Client *firestore.Client
+	Codes  *firestore.CollectionRef
+}
+
+func NewFirestoreOTPCodeRepository(db *firestore.Client) *FirestoreOTPCodeRepository {
+	return &FirestoreOTPCodeRepository{
+		Client: db,
+		Codes:  db.Collection("otp_codes"),
+	}
+}
+
+func (r *FirestoreOTPCodeRepository) Save(ctx context.Context, code *entity.OTPCode) error {
+	_, err := r.Codes.Doc(code.Phone).Set(ctx, code)
+	return err
+}
+
+func (r *FirestoreOTPCodeRepository) FindByPhone(ctx context.Context, phone string) (*entity.OTPCode, error) {
+	doc, err := r.Codes.Doc(phone).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var code entity.OTPCode
+	if err := doc.DataTo(&code); err != nil {
+		return nil, err
+	}
+
+	return &code, nil
+}
+
+func (r *FirestoreOTPCodeRepository) IncrementAttempts(ctx context.Context, phone string) (int, error) {
+	ref := r.Codes.Doc(phone)
+	attempts := 0
+
+	err := r.Client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		attempts = 0
+
+		doc, err := tx.Get(ref)
+		if status.Code(err) == codes.NotFound {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var code entity.OTPCode
+		if err := doc.DataTo(&code); err != nil {
+			return err
+		}
+
+		attempts = code.Attempts + 1
+		return tx.Update(ref, []firestore.Update{{Path: "Attempts", Value: attempts}})
+	})
+
+	return attempts, err
+}
+
+func (r *FirestoreOTPCodeRepository) Delete(ctx context.Context, phone string) error {
+	_, err := r.Codes.Doc(phone).Delete(ctx)
+	return err
+}
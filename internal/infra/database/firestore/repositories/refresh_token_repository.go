@@ -0,0 +1,148 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/paulozy/costurai/internal/entity"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// FirestoreRefreshTokenRepository keeps revoked families in their own
+// collection so the per-request revocation check is a single document read.
+type FirestoreRefreshTokenRepository struct {
+	Client          *firestore.Client
+	Tokens          *firestore.CollectionRef
+	RevokedFamilies *firestore.CollectionRef
+}
+
+func NewFirestoreRefreshTokenRepository(db *firestore.Client) *FirestoreRefreshTokenRepository {
+	return &FirestoreRefreshTokenRepository{
+		Client:          db,
+		Tokens:          db.Collection("refresh_tokens"),
+		RevokedFamilies: db.Collection("revoked_token_families"),
+	}
+}
+
+func (r *FirestoreRefreshTokenRepository) Create(ctx context.Context, token *entity.RefreshToken) error {
+	_, err := r.Tokens.Doc(token.ID).Create(ctx, token)
+	return err
+}
+
+func (r *FirestoreRefreshTokenRepository) FindByHash(ctx context.Context, hash string) (*entity.RefreshToken, error) {
+	docs, err := r.Tokens.Where("TokenHash", "==", hash).Limit(1).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(docs) == 0 {
+		return nil, nil
+	}
+
+	var token entity.RefreshToken
+	if err := docs[0].DataTo(&token); err != nil {
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+func (r *FirestoreRefreshTokenRepository) MarkUsed(ctx context.Context, id string) (bool, error) {
+	ref := r.Tokens.Doc(id)
+	marked := false
+
+	err := r.Client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		marked = false
+
+		doc, err := tx.Get(ref)
+		if status.Code(err) == codes.NotFound {
+			return fmt.Errorf("no refresh token found with ID: %s", id)
+		}
+		if err != nil {
+			return err
+		}
+
+		var token entity.RefreshToken
+		if err := doc.DataTo(&token); err != nil {
+			return err
+		}
+
+		if token.UsedAt != nil {
+			return nil
+		}
+
+		marked = true
+		return tx.Update(ref, []firestore.Update{{Path: "UsedAt", Value: time.Now()}})
+	})
+
+	return marked, err
+}
+
+func (r *FirestoreRefreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	now := time.Now()
+
+	_, err := r.RevokedFamilies.Doc(familyID).Set(ctx, map[string]interface{}{
+		"RevokedAt": now,
+	})
+	if err != nil {
+		return err
+	}
+
+	docs, err := r.Tokens.Where("FamilyID", "==", familyID).Documents(ctx).GetAll()
+	if err != nil {
+		return err
+	}
+
+	bulk := r.Client.BulkWriter(ctx)
+	for _, doc := range docs {
+		if _, err := bulk.Update(doc.Ref, []firestore.Update{{Path: "RevokedAt", Value: now}}); err != nil {
+			return err
+		}
+	}
+	bulk.End()
+
+	return nil
+}
+
+func (r *FirestoreRefreshTokenRepository) RevokeSubject(ctx context.Context, subject string) error {
+	docs, err := r.Tokens.Where("Subject", "==", subject).Documents(ctx).GetAll()
+	if err != nil {
+		return err
+	}
+
+	families := map[string]bool{}
+	for _, doc := range docs {
+		var token entity.RefreshToken
+		if err := doc.DataTo(&token); err != nil {
+			return err
+		}
+
+		if token.RevokedAt == nil {
+			families[token.FamilyID] = true
+		}
+	}
+
+	for familyID := range families {
+		if err := r.RevokeFamily(ctx, familyID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *FirestoreRefreshTokenRepository) IsFamilyRevoked(ctx context.Context, familyID string) (bool, error) {
+	_, err := r.RevokedFamilies.Doc(familyID).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
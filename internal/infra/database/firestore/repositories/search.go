@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/firestore"
+	"cloud.google.com/go/firestore/apiv1/firestorepb"
+	"github.com/paulozy/costurai/internal/infra/database"
+)
+
+// accountSearchQuery matches emails by prefix. Firestore comparisons are
+// case sensitive, unlike the other backends.
+func accountSearchQuery(collection *firestore.CollectionRef, params database.AccountSearchParams) firestore.Query {
+	query := collection.Query
+	if params.Query != "" {
+		query = query.Where("Email", ">=", params.Query).Where("Email", "<", params.Query+"\uf8ff")
+	}
+
+	if params.Enabled != nil {
+		query = query.Where("Enabled", "==", *params.Enabled)
+	}
+
+	return query.OrderBy("Email", firestore.Asc)
+}
+
+// searchPage counts the documents matching the query and reads the
+// requested page of them.
+func searchPage[T any](ctx context.Context, query firestore.Query, limit, page int64) ([]T, int64, error) {
+	result, err := query.NewAggregationQuery().WithCount("total").Get(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	count, ok := result["total"].(*firestorepb.Value)
+	if !ok {
+		return nil, 0, fmt.Errorf("unexpected count result: %v", result["total"])
+	}
+
+	if limit > 0 {
+		if page <= 0 {
+			page = 1
+		}
+
+		query = query.Offset(int((page - 1) * limit)).Limit(int(limit))
+	}
+
+	docs, err := query.Documents(ctx).GetAll()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	items := make([]T, 0, len(docs))
+	for _, doc := range docs {
+		var item T
+		if err := doc.DataTo(&item); err != nil {
+			return nil, 0, err
+		}
+
+		items = append(items, item)
+	}
+
+	return items, count.GetIntegerValue(), nil
+}
@@ -2,28 +2,28 @@ package repositories
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"cloud.google.com/go/firestore"
 	"github.com/paulozy/costurai/internal/entity"
+	"github.com/paulozy/costurai/internal/infra/database"
 )
 
 type FirestoreSubscriptionRepository struct {
+	Client        *firestore.Client
 	Subscriptions *firestore.CollectionRef
-	Ctx           *context.Context
 }
 
 func NewFirestoreSubscriptionRepository(db *firestore.Client) *FirestoreSubscriptionRepository {
-	ctx := context.Background()
-
 	return &FirestoreSubscriptionRepository{
+		Client:        db,
 		Subscriptions: db.Collection("subscriptions"),
-		Ctx:           &ctx,
 	}
 }
 
-func (r *FirestoreSubscriptionRepository) Create(subscription *entity.Subscription) error {
-	_, err := r.Subscriptions.NewDoc().Create(*r.Ctx, subscription)
+func (r *FirestoreSubscriptionRepository) Create(ctx context.Context, subscription *entity.Subscription) error {
+	_, err := r.Subscriptions.NewDoc().Create(ctx, subscription)
 
 	if err != nil {
 		return err
@@ -32,20 +32,145 @@ func (r *FirestoreSubscriptionRepository) Create(subscription *entity.Subscripti
 	return nil
 }
 
-func (r *FirestoreSubscriptionRepository) FindByID(id string) (*entity.Subscription, error) {
-	doc, err := r.Subscriptions.Doc(id).Get(*r.Ctx)
-	if err != nil {
+// FindByID looks the document up by the ID field, since Create stores
+// subscriptions under generated document IDs.
+func (r *FirestoreSubscriptionRepository) FindByID(ctx context.Context, id string) (*entity.Subscription, error) {
+	doc, err := r.findDoc(ctx, id)
+	if err != nil || doc == nil {
 		return nil, err
 	}
+
 	var sub entity.Subscription
 	if err := doc.DataTo(&sub); err != nil {
 		return nil, err
 	}
+
 	return &sub, nil
 }
 
-func (r *FirestoreSubscriptionRepository) Update(subscription *entity.Subscription) error {
+func (r *FirestoreSubscriptionRepository) FindByGatewayID(ctx context.Context, gatewayID string) (*entity.Subscription, error) {
+	docs, err := r.Subscriptions.Where("GatewayId", "==", gatewayID).Limit(1).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(docs) == 0 {
+		return nil, nil
+	}
+
+	var sub entity.Subscription
+	if err := docs[0].DataTo(&sub); err != nil {
+		return nil, err
+	}
+
+	return &sub, nil
+}
+
+func (r *FirestoreSubscriptionRepository) Update(ctx context.Context, subscription *entity.Subscription) error {
+	doc, err := r.findDoc(ctx, subscription.ID)
+	if err != nil {
+		return err
+	}
+
+	if doc == nil {
+		return fmt.Errorf("no subscription found with ID: %s", subscription.ID)
+	}
+
 	subscription.UpdatedAt = time.Now()
-	_, err := r.Subscriptions.Doc(subscription.ID).Set(*r.Ctx, subscription)
+	_, err = doc.Ref.Set(ctx, subscription)
+
 	return err
 }
+
+// UpdateIfUnchanged compares the stored UpdatedAt within a transaction, so
+// an update made after the subscription was read isn't overwritten.
+func (r *FirestoreSubscriptionRepository) UpdateIfUnchanged(ctx context.Context, subscription *entity.Subscription, readUpdatedAt time.Time) (bool, error) {
+	doc, err := r.findDoc(ctx, subscription.ID)
+	if err != nil || doc == nil {
+		return false, err
+	}
+
+	saved := false
+	err = r.Client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		saved = false
+
+		current, err := tx.Get(doc.Ref)
+		if err != nil {
+			return err
+		}
+
+		var stored entity.Subscription
+		if err := current.DataTo(&stored); err != nil {
+			return err
+		}
+
+		if !stored.UpdatedAt.Equal(readUpdatedAt) {
+			return nil
+		}
+
+		subscription.UpdatedAt = time.Now()
+		saved = true
+
+		return tx.Set(doc.Ref, subscription)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return saved, nil
+}
+
+func (r *FirestoreSubscriptionRepository) Search(ctx context.Context, params database.SubscriptionSearchParams) ([]entity.Subscription, int64, error) {
+	query := r.Subscriptions.Query
+	if params.DressmakerID != "" {
+		query = query.Where("DressmakerID", "==", params.DressmakerID)
+	}
+
+	if params.Status != "" {
+		query = query.Where("Status", "==", params.Status)
+	}
+
+	return searchPage[entity.Subscription](ctx, query.OrderBy("CreatedAt", firestore.Desc), params.Limit, params.Page)
+}
+
+// FindLapsing runs one query per date field, as they can't be combined in a
+// single range filter.
+func (r *FirestoreSubscriptionRepository) FindLapsing(ctx context.Context, before time.Time) ([]entity.Subscription, error) {
+	statuses := []entity.Status{entity.StatusActive, entity.StatusCanceled}
+	seen := map[string]bool{}
+	lapsing := []entity.Subscription{}
+
+	for _, field := range []string{"ExpiresAt", "GraceUntil"} {
+		docs, err := r.Subscriptions.Where("Status", "in", statuses).Where(field, "<", before).Documents(ctx).GetAll()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, doc := range docs {
+			var sub entity.Subscription
+			if err := doc.DataTo(&sub); err != nil {
+				return nil, err
+			}
+
+			if !seen[sub.ID] {
+				seen[sub.ID] = true
+				lapsing = append(lapsing, sub)
+			}
+		}
+	}
+
+	return lapsing, nil
+}
+
+func (r *FirestoreSubscriptionRepository) findDoc(ctx context.Context, id string) (*firestore.DocumentSnapshot, error) {
+	docs, err := r.Subscriptions.Where("ID", "==", id).Limit(1).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(docs) == 0 {
+		return nil, nil
+	}
+
+	return docs[0], nil
+}
@@ -0,0 +1,81 @@
+package repositories
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/paulozy/costurai/internal/entity"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// FirestoreThrottleRepository stores one document per key. Keys are escaped
+// since document IDs can't contain slashes.
+type FirestoreThrottleRepository struct {
+	Client    *firestore.Client
+	Throttles *firestore.CollectionRef
+}
+
+func NewFirestoreThrottleRepository(db *firestore.Client) *FirestoreThrottleRepository {
+	return &FirestoreThrottleRepository{
+		Client:    db,
+		Throttles: db.Collection("throttles"),
+	}
+}
+
+func (r *FirestoreThrottleRepository) doc(key string) *firestore.DocumentRef {
+	return r.Throttles.Doc(url.PathEscape(key))
+}
+
+func (r *FirestoreThrottleRepository) Find(ctx context.Context, key string) (*entity.Throttle, error) {
+	doc, err := r.doc(key).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var throttle entity.Throttle
+	if err := doc.DataTo(&throttle); err != nil {
+		return nil, err
+	}
+
+	return &throttle, nil
+}
+
+func (r *FirestoreThrottleRepository) RegisterAttempt(ctx context.Context, key string, policy entity.ThrottlePolicy) (*entity.Throttle, error) {
+	ref := r.doc(key)
+	var throttle *entity.Throttle
+
+	err := r.Client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		throttle = entity.NewThrottle(key)
+
+		doc, err := tx.Get(ref)
+		if err != nil && status.Code(err) != codes.NotFound {
+			return err
+		}
+
+		if err == nil {
+			if err := doc.DataTo(throttle); err != nil {
+				return err
+			}
+		}
+
+		throttle.Register(policy, time.Now())
+		return tx.Set(ref, throttle)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return throttle, nil
+}
+
+func (r *FirestoreThrottleRepository) Delete(ctx context.Context, key string) error {
+	_, err := r.doc(key).Delete(ctx)
+	return err
+}
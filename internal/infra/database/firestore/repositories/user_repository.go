@@ -2,28 +2,26 @@ package repositories
 
 import (
 	"context"
+	"fmt"
 
 	"cloud.google.com/go/firestore"
 	"github.com/paulozy/costurai/internal/entity"
+	"github.com/paulozy/costurai/internal/infra/database"
 )
 
 type FirestoreUserRepository struct {
 	Users *firestore.CollectionRef
-	Ctx   *context.Context
 }
 
 func NewFirestoreUserRepository(db *firestore.Client) *FirestoreUserRepository {
-	ctx := context.Background()
-
 	return &FirestoreUserRepository{
 		Users: db.Collection("users"),
-		Ctx:   &ctx,
 	}
 }
 
-func (r *FirestoreUserRepository) Create(user *entity.User) error {
+func (r *FirestoreUserRepository) Create(ctx context.Context, user *entity.User) error {
 	_, err := r.Users.NewDoc().Create(
-		*r.Ctx,
+		ctx,
 		user,
 	)
 	if err != nil {
@@ -33,12 +31,12 @@ func (r *FirestoreUserRepository) Create(user *entity.User) error {
 	return nil
 }
 
-func (r *FirestoreUserRepository) FindByEmail(email string) (*entity.User, error) {
+func (r *FirestoreUserRepository) FindByEmail(ctx context.Context, email string) (*entity.User, error) {
 	query := r.Users.Where(
 		"Email", "==", email,
 	).Limit(1)
 
-	docs, err := query.Documents(*r.Ctx).GetAll()
+	docs, err := query.Documents(ctx).GetAll()
 	if err != nil {
 		return nil, err
 	}
@@ -57,9 +55,9 @@ func (r *FirestoreUserRepository) FindByEmail(email string) (*entity.User, error
 	return user, nil
 }
 
-func (r *FirestoreUserRepository) Exists(email string) (bool, error) {
+func (r *FirestoreUserRepository) Exists(ctx context.Context, email string) (bool, error) {
 	query := r.Users.Where("Email", "==", email).Limit(1)
-	docs, err := query.Documents(*r.Ctx).GetAll()
+	docs, err := query.Documents(ctx).GetAll()
 	if err != nil {
 		return false, err
 	}
@@ -67,9 +65,9 @@ func (r *FirestoreUserRepository) Exists(email string) (bool, error) {
 	return len(docs) > 0, nil
 }
 
-func (r *FirestoreUserRepository) FindByID(id string) (*entity.User, error) {
+func (r *FirestoreUserRepository) FindByID(ctx context.Context, id string) (*entity.User, error) {
 	query := r.Users.Where("ID", "==", id).Limit(1)
-	docs, err := query.Documents(*r.Ctx).GetAll()
+	docs, err := query.Documents(ctx).GetAll()
 	if err != nil {
 		return nil, err
 	}
@@ -88,17 +86,40 @@ func (r *FirestoreUserRepository) FindByID(id string) (*entity.User, error) {
 	return user, nil
 }
 
-func (r *FirestoreUserRepository) Update(user *entity.User) error {
-	userRef := r.Users.Doc(user.ID)
+// Update looks the document up by the ID field, since Create stores users
+// under generated document IDs.
+func (r *FirestoreUserRepository) Update(ctx context.Context, user *entity.User) error {
+	query := r.Users.Where("ID", "==", user.ID).Limit(1)
+	docs, err := query.Documents(ctx).GetAll()
+	if err != nil {
+		return err
+	}
 
-	_, err := userRef.Set(*r.Ctx, map[string]interface{}{
-		"Name": user.Name,
+	if len(docs) == 0 {
+		return fmt.Errorf("no user found with ID: %s", user.ID)
+	}
+
+	_, err = docs[0].Ref.Set(ctx, map[string]interface{}{
+		"Name":            user.Name,
+		"Email":           user.Email,
+		"EmailVerified":   user.EmailVerified,
+		"EmailVerifiedAt": user.EmailVerifiedAt,
+		"Password":        user.Password,
+		"Enabled":         user.Enabled,
+		"Admin":           user.Admin,
+		"Suspended":       user.Suspended,
+		"Phone":           user.Phone,
+		"PhoneVerifiedAt": user.PhoneVerifiedAt,
 		"Location": map[string]float64{
 			"Latitude":  user.Location.Latitude,
 			"Longitude": user.Location.Longitude,
 		},
-		"Updated_at": user.UpdatedAt,
+		"UpdatedAt": user.UpdatedAt,
 	}, firestore.MergeAll)
 
 	return err
 }
+
+func (r *FirestoreUserRepository) Search(ctx context.Context, params database.AccountSearchParams) ([]entity.User, int64, error) {
+	return searchPage[entity.User](ctx, accountSearchQuery(r.Users, params), params.Limit, params.Page)
+}
@@ -0,0 +1,125 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/paulozy/costurai/internal/entity"
+	"github.com/paulozy/costurai/internal/infra/database"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type FirestoreWebhookEventRepository struct {
+	Client *firestore.Client
+	Events *firestore.CollectionRef
+}
+
+func NewFirestoreWebhookEventRepository(db *firestore.Client) *FirestoreWebhookEventRepository {
+	return &FirestoreWebhookEventRepository{
+		Client: db,
+		Events: db.Collection("webhook_events"),
+	}
+}
+
+// Create relies on the document ID being the event ID, so concurrent
+// deliveries of the same event can't both store it.
+func (r *FirestoreWebhookEventRepository) Create(ctx context.Context, event *entity.WebhookEvent) (bool, error) {
+	_, err := r.Events.Doc(event.ID).Create(ctx, event)
+	if status.Code(err) == codes.AlreadyExists {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *FirestoreWebhookEventRepository) FindByID(ctx context.Context, id string) (*entity.WebhookEvent, error) {
+	doc, err := r.Events.Doc(id).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var event entity.WebhookEvent
+	if err := doc.DataTo(&event); err != nil {
+		return nil, err
+	}
+
+	return &event, nil
+}
+
+func (r *FirestoreWebhookEventRepository) Claim(ctx context.Context, id string, staleBefore time.Time, settled bool) (bool, error) {
+	ref := r.Events.Doc(id)
+	claimed := false
+
+	err := r.Client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		claimed = false
+
+		doc, err := tx.Get(ref)
+		if status.Code(err) == codes.NotFound {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		var event entity.WebhookEvent
+		if err := doc.DataTo(&event); err != nil {
+			return err
+		}
+
+		if !event.CanBeClaimed(staleBefore, settled) {
+			return nil
+		}
+
+		event.Claim()
+		claimed = true
+
+		return tx.Update(ref, []firestore.Update{
+			{Path: "Status", Value: event.Status},
+			{Path: "ReceivedAt", Value: event.ReceivedAt},
+		})
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return claimed, nil
+}
+
+func (r *FirestoreWebhookEventRepository) Update(ctx context.Context, event *entity.WebhookEvent) error {
+	_, err := r.Events.Doc(event.ID).Update(ctx, []firestore.Update{
+		{Path: "Status", Value: event.Status},
+		{Path: "Error", Value: event.Error},
+		{Path: "Attempts", Value: event.Attempts},
+		{Path: "ProcessedAt", Value: event.ProcessedAt},
+	})
+	if status.Code(err) == codes.NotFound {
+		return fmt.Errorf("no webhook event found with ID: %s", event.ID)
+	}
+
+	return err
+}
+
+func (r *FirestoreWebhookEventRepository) Search(ctx context.Context, params database.WebhookEventSearchParams) ([]entity.WebhookEvent, int64, error) {
+	query := r.Events.Query
+	if params.Type != "" {
+		query = query.Where("Type", "==", params.Type)
+	}
+
+	if params.Status != "" {
+		query = query.Where("Status", "==", params.Status)
+	}
+
+	return searchPage[entity.WebhookEvent](ctx, query.OrderBy("OccurredAt", firestore.Desc), params.Limit, params.Page)
+}
@@ -1,6 +1,16 @@
 package database
 
-import "github.com/paulozy/costurai/internal/entity"
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/paulozy/costurai/internal/entity"
+)
+
+// ErrAlreadyExists is returned when storing a record would break a
+// uniqueness rule, such as a second review of a dressmaker by the same user.
+var ErrAlreadyExists = errors.New("already exists")
 
 type GetDressmakersParams struct {
 	Latitude  float64
@@ -13,29 +23,185 @@ type GetDressmakersParams struct {
 	Default bool
 }
 
+// AccountSearchParams filters the back-office listing of users and
+// dressmakers. Query matches the start of the email, Enabled is ignored when
+// nil and Page starts at 1.
+type AccountSearchParams struct {
+	Query   string
+	Enabled *bool
+	Limit   int64
+	Page    int64
+}
+
+type SubscriptionSearchParams struct {
+	DressmakerID string
+	Status       entity.Status
+	Limit        int64
+	Page         int64
+}
+
+type AuditLogSearchParams struct {
+	ActorID    string
+	TargetType string
+	TargetID   string
+	Limit      int64
+	Page       int64
+}
+
+type WebhookEventSearchParams struct {
+	Type   string
+	Status entity.WebhookEventStatus
+	Limit  int64
+	Page   int64
+}
+
 type DressmakerRepositoryInterface interface {
-	Create(dressmaker *entity.Dressmaker) error
-	FindByEmail(email string) (*entity.Dressmaker, error)
-	Exists(email string) (bool, error)
-	FindByID(id string) (*entity.Dressmaker, error)
-	FindByProximity(latitude, longitude float64, maxDistance int) ([]entity.Dressmaker, error)
-	Update(dressmaker *entity.Dressmaker) error
+	Create(ctx context.Context, dressmaker *entity.Dressmaker) error
+	FindByEmail(ctx context.Context, email string) (*entity.Dressmaker, error)
+	Exists(ctx context.Context, email string) (bool, error)
+	FindByID(ctx context.Context, id string) (*entity.Dressmaker, error)
+	FindByProximity(ctx context.Context, latitude, longitude float64, maxDistance int) ([]entity.Dressmaker, error)
+	Update(ctx context.Context, dressmaker *entity.Dressmaker) error
+	ApplyReviewDelta(ctx context.Context, id string, countDelta int64, gradeDelta float64) error
+	// Search returns a page of dressmakers ordered by email together with the
+	// total number of matches.
+	Search(ctx context.Context, params AccountSearchParams) ([]entity.Dressmaker, int64, error)
 }
 
 type UserRepositoryInterface interface {
-	Create(user *entity.User) error
-	FindByEmail(email string) (*entity.User, error)
-	Exists(email string) (bool, error)
-	FindByID(id string) (*entity.User, error)
-	Update(user *entity.User) error
+	Create(ctx context.Context, user *entity.User) error
+	FindByEmail(ctx context.Context, email string) (*entity.User, error)
+	Exists(ctx context.Context, email string) (bool, error)
+	FindByID(ctx context.Context, id string) (*entity.User, error)
+	Update(ctx context.Context, user *entity.User) error
+	// Search returns a page of users ordered by email together with the total
+	// number of matches.
+	Search(ctx context.Context, params AccountSearchParams) ([]entity.User, int64, error)
 }
 
 type SubscriptionRepositoryInterface interface {
-	Create(sub *entity.Subscription) error
-	FindByID(id string) (*entity.Subscription, error)
-	Update(sub *entity.Subscription) error
+	Create(ctx context.Context, sub *entity.Subscription) error
+	// FindByID returns nil when there is no subscription with the ID.
+	FindByID(ctx context.Context, id string) (*entity.Subscription, error)
+	// FindByGatewayID returns nil when no subscription was paid for with the
+	// payment gateway subscription.
+	FindByGatewayID(ctx context.Context, gatewayID string) (*entity.Subscription, error)
+	Update(ctx context.Context, sub *entity.Subscription) error
+	// UpdateIfUnchanged saves the subscription unless it was updated since
+	// it was read with the given UpdatedAt, returning false when it was.
+	UpdateIfUnchanged(ctx context.Context, sub *entity.Subscription, readUpdatedAt time.Time) (bool, error)
+	// Search returns a page of subscriptions, newest first, together with the
+	// total number of matches.
+	Search(ctx context.Context, params SubscriptionSearchParams) ([]entity.Subscription, int64, error)
+	// FindLapsing returns the active and canceled subscriptions whose period
+	// or grace period ends before the given time.
+	FindLapsing(ctx context.Context, before time.Time) ([]entity.Subscription, error)
 }
 
 type DressmakerReviewsRepositoryInterface interface {
-	Create(review *entity.Review) error
+	// Create returns ErrAlreadyExists when the user already reviewed the
+	// dressmaker.
+	Create(ctx context.Context, review *entity.Review) error
+	FindByID(ctx context.Context, id string) (*entity.Review, error)
+	FindByDressmakerAndUser(ctx context.Context, dressmakerID, userID string) (*entity.Review, error)
+	FindByDressmakerID(ctx context.Context, dressmakerID string) ([]entity.Review, error)
+	FindForModeration(ctx context.Context) ([]entity.Review, error)
+	Update(ctx context.Context, review *entity.Review) error
+	Delete(ctx context.Context, id string) error
+}
+
+type RefreshTokenRepositoryInterface interface {
+	Create(ctx context.Context, token *entity.RefreshToken) error
+	FindByHash(ctx context.Context, hash string) (*entity.RefreshToken, error)
+	// MarkUsed flags the token as rotated, returning false when it had
+	// already been used so concurrent refreshes can't both succeed.
+	MarkUsed(ctx context.Context, id string) (bool, error)
+	RevokeFamily(ctx context.Context, familyID string) error
+	RevokeSubject(ctx context.Context, subject string) error
+	IsFamilyRevoked(ctx context.Context, familyID string) (bool, error)
+}
+
+type OneTimeTokenRepositoryInterface interface {
+	Create(ctx context.Context, token *entity.OneTimeToken) error
+	FindByHash(ctx context.Context, purpose entity.OneTimeTokenPurpose, hash string) (*entity.OneTimeToken, error)
+	// MarkUsed returns false when the token had already been used.
+	MarkUsed(ctx context.Context, id string) (bool, error)
+	DeleteBySubject(ctx context.Context, purpose entity.OneTimeTokenPurpose, subject string) error
+}
+
+type OTPCodeRepositoryInterface interface {
+	Save(ctx context.Context, code *entity.OTPCode) error
+	FindByPhone(ctx context.Context, phone string) (*entity.OTPCode, error)
+	// IncrementAttempts records a verification attempt and returns the
+	// number of attempts made so far, or 0 when no code is pending.
+	IncrementAttempts(ctx context.Context, phone string) (int, error)
+	Delete(ctx context.Context, phone string) error
+}
+
+type ThrottleRepositoryInterface interface {
+	Find(ctx context.Context, key string) (*entity.Throttle, error)
+	// RegisterAttempt atomically records an attempt against the key, creating
+	// it when needed, and returns its updated state.
+	RegisterAttempt(ctx context.Context, key string, policy entity.ThrottlePolicy) (*entity.Throttle, error)
+	Delete(ctx context.Context, key string) error
+}
+
+type OIDCLoginStateRepositoryInterface interface {
+	Create(ctx context.Context, state *entity.OIDCLoginState) error
+	// Consume deletes and returns the state, so it can only be used once.
+	// It returns nil when there is no state with the hash.
+	Consume(ctx context.Context, stateHash string) (*entity.OIDCLoginState, error)
+}
+
+type IdentityRepositoryInterface interface {
+	Create(ctx context.Context, identity *entity.Identity) error
+	FindByIssuerAndSubject(ctx context.Context, issuer, subject, accountType string) (*entity.Identity, error)
+}
+
+type AuditLogRepositoryInterface interface {
+	Create(ctx context.Context, entry *entity.AuditEntry) error
+	// Search returns a page of entries, newest first, together with the total
+	// number of matches.
+	Search(ctx context.Context, params AuditLogSearchParams) ([]entity.AuditEntry, int64, error)
+}
+
+type WebhookEventRepositoryInterface interface {
+	// Create stores the event unless one with the same ID was already
+	// received, in which case it returns false.
+	Create(ctx context.Context, event *entity.WebhookEvent) (bool, error)
+	// FindByID returns nil when no event with the ID was received.
+	FindByID(ctx context.Context, id string) (*entity.WebhookEvent, error)
+	// Claim marks a stored event as being processed again when
+	// entity.WebhookEvent.CanBeClaimed allows it, returning false when
+	// another delivery got to it first.
+	Claim(ctx context.Context, id string, staleBefore time.Time, settled bool) (bool, error)
+	Update(ctx context.Context, event *entity.WebhookEvent) error
+	// Search returns a page of events, most recently occurred first, together
+	// with the total number of matches.
+	Search(ctx context.Context, params WebhookEventSearchParams) ([]entity.WebhookEvent, int64, error)
+}
+
+type LockRepositoryInterface interface {
+	// Acquire takes the named lock for the holder during ttl, returning false
+	// while someone else holds it. The holder can acquire it again to extend
+	// the lease.
+	Acquire(ctx context.Context, name, holder string, ttl time.Duration) (bool, error)
+	// Release gives the lock up if the holder still has it.
+	Release(ctx context.Context, name, holder string) error
+}
+
+type Repositories struct {
+	Dressmaker        DressmakerRepositoryInterface
+	User              UserRepositoryInterface
+	Subscription      SubscriptionRepositoryInterface
+	DressmakerReviews DressmakerReviewsRepositoryInterface
+	RefreshToken      RefreshTokenRepositoryInterface
+	OneTimeToken      OneTimeTokenRepositoryInterface
+	OTPCode           OTPCodeRepositoryInterface
+	Throttle          ThrottleRepositoryInterface
+	OIDCLoginState    OIDCLoginStateRepositoryInterface
+	Identity          IdentityRepositoryInterface
+	AuditLog          AuditLogRepositoryInterface
+	WebhookEvent      WebhookEventRepositoryInterface
+	Lock              LockRepositoryInterface
 }
@@ -0,0 +1,27 @@
+package memory
+
+import (
+	"github.com/paulozy/costurai/internal/infra/database"
+	"github.com/paulozy/costurai/internal/infra/database/memory/repositories"
+)
+
+// NewMemoryRepositories builds a set of repositories that keep every record
+// in process memory. Data is lost when the process exits, which makes it
+// suitable only for local development and HTTP tests.
+func NewMemoryRepositories() *database.Repositories {
+	return &database.Repositories{
+		Dressmaker:        repositories.NewMemoryDressmakerRepository(),
+		User:              repositories.NewMemoryUserRepository(),
+		Subscription:      repositories.NewMemorySubscriptionRepository(),
+		DressmakerReviews: repositories.NewMemoryReviewsRepository(),
+		RefreshToken:      repositories.NewMemoryRefreshTokenRepository(),
+		OneTimeToken:      repositories.NewMemoryOneTimeTokenRepository(),
+		OTPCode:           repositories.NewMemoryOTPCodeRepository(),
+		Throttle:          repositories.NewMemoryThrottleRepository(),
+		OIDCLoginState:    repositories.NewMemoryOIDCLoginStateRepository(),
+		Identity:          repositories.NewMemoryIdentityRepository(),
+		AuditLog:          repositories.NewMemoryAuditLogRepository(),
+		WebhookEvent:      repositories.NewMemoryWebhookEventRepository(),
+		Lock:              repositories.NewMemoryLockRepository(),
+	}
+}
@@ -0,0 +1,61 @@
+package repositories
+
+import (
+	"context"
+	"maps"
+	"sync"
+
+	"github.com/paulozy/costurai/internal/entity"
+	"github.com/paulozy/costurai/internal/infra/database"
+)
+
+// MemoryAuditLogRepository keeps entries in insertion order, which is also
+// chronological order.
+type MemoryAuditLogRepository struct {
+	mu      sync.RWMutex
+	Entries []entity.AuditEntry
+}
+
+func NewMemoryAuditLogRepository() *MemoryAuditLogRepository {
+	return &MemoryAuditLogRepository{
+		Entries: []entity.AuditEntry{},
+	}
+}
+
+func (r *MemoryAuditLogRepository) Create(ctx context.Context, entry *entity.AuditEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored := *entry
+	stored.Changes = maps.Clone(entry.Changes)
+	r.Entries = append(r.Entries, stored)
+
+	return nil
+}
+
+func (r *MemoryAuditLogRepository) Search(ctx context.Context, params database.AuditLogSearchParams) ([]entity.AuditEntry, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matches := []entity.AuditEntry{}
+	for i := len(r.Entries) - 1; i >= 0; i-- {
+		entry := r.Entries[i]
+
+		if params.ActorID != "" && entry.ActorID != params.ActorID {
+			continue
+		}
+
+		if params.TargetType != "" && entry.TargetType != params.TargetType {
+			continue
+		}
+
+		if params.TargetID != "" && entry.TargetID != params.TargetID {
+			continue
+		}
+
+		entry.Changes = maps.Clone(entry.Changes)
+		matches = append(matches, entry)
+	}
+
+	return page(matches, params.Limit, params.Page), int64(len(matches)), nil
+}
@@ -0,0 +1,181 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/paulozy/costurai/internal/entity"
+	"github.com/paulozy/costurai/internal/infra/database"
+	"github.com/paulozy/costurai/pkg"
+)
+
+type MemoryDressmakerRepository struct {
+	mu          sync.RWMutex
+	Dressmakers map[string]entity.Dressmaker
+}
+
+func NewMemoryDressmakerRepository() *MemoryDressmakerRepository {
+	return &MemoryDressmakerRepository{
+		Dressmakers: map[string]entity.Dressmaker{},
+	}
+}
+
+func (r *MemoryDressmakerRepository) Create(ctx context.Context, dressmaker *entity.Dressmaker) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.Dressmakers[dressmaker.ID]; ok {
+		return fmt.Errorf("dressmaker with ID %s already exists", dressmaker.ID)
+	}
+
+	r.Dressmakers[dressmaker.ID] = copyDressmaker(*dressmaker)
+
+	return nil
+}
+
+func (r *MemoryDressmakerRepository) FindByEmail(ctx context.Context, email string) (*entity.Dressmaker, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, dressmaker := range r.Dressmakers {
+		if dressmaker.Email == email {
+			found := copyDressmaker(dressmaker)
+			return &found, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *MemoryDressmakerRepository) Exists(ctx context.Context, email string) (bool, error) {
+	dressmaker, err := r.FindByEmail(ctx, email)
+	if err != nil {
+		return false, err
+	}
+
+	return dressmaker != nil, nil
+}
+
+func (r *MemoryDressmakerRepository) FindByID(ctx context.Context, id string) (*entity.Dressmaker, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	dressmaker, ok := r.Dressmakers[id]
+	if !ok {
+		return nil, nil
+	}
+
+	found := copyDressmaker(dressmaker)
+
+	return &found, nil
+}
+
+func (r *MemoryDressmakerRepository) FindByProximity(ctx context.Context, latitude, longitude float64, maxDistance int) ([]entity.Dressmaker, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	type match struct {
+		dressmaker entity.Dressmaker
+		distance   float64
+	}
+
+	var matches []match
+
+	for _, dressmaker := range r.Dressmakers {
+		dist := pkg.HaversineDistance(
+			latitude,
+			longitude,
+			dressmaker.Address.Location.Latitude,
+			dressmaker.Address.Location.Longitude,
+		)
+
+		if dist <= float64(maxDistance) {
+			matches = append(matches, match{dressmaker: copyDressmaker(dressmaker), distance: dist})
+		}
+	}
+
+	// map iteration order is random, so sort to keep pages stable between calls
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].distance == matches[j].distance {
+			return matches[i].dressmaker.ID < matches[j].dressmaker.ID
+		}
+		return matches[i].distance < matches[j].distance
+	})
+
+	var dressmakers []entity.Dressmaker
+	for _, m := range matches {
+		dressmakers = append(dressmakers, m.dressmaker)
+	}
+
+	return dressmakers, nil
+}
+
+func (r *MemoryDressmakerRepository) Update(ctx context.Context, dressmaker *entity.Dressmaker) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.Dressmakers[dressmaker.ID]
+	if !ok {
+		return fmt.Errorf("no dressmaker found with ID: %s", dressmaker.ID)
+	}
+
+	// review aggregates are only changed through ApplyReviewDelta
+	updated := copyDressmaker(*dressmaker)
+	updated.Grade = current.Grade
+	updated.ReviewCount = current.ReviewCount
+	updated.GradeSum = current.GradeSum
+	updated.Score = current.Score
+	r.Dressmakers[dressmaker.ID] = updated
+
+	return nil
+}
+
+func (r *MemoryDressmakerRepository) ApplyReviewDelta(ctx context.Context, id string, countDelta int64, gradeDelta float64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	dressmaker, ok := r.Dressmakers[id]
+	if !ok {
+		return fmt.Errorf("no dressmaker found with ID: %s", id)
+	}
+
+	dressmaker.ApplyReviewDelta(countDelta, gradeDelta)
+	r.Dressmakers[id] = dressmaker
+
+	return nil
+}
+
+func (r *MemoryDressmakerRepository) Search(ctx context.Context, params database.AccountSearchParams) ([]entity.Dressmaker, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matches := []entity.Dressmaker{}
+	for _, dressmaker := range r.Dressmakers {
+		if matchesAccount(dressmaker.Email, dressmaker.Enabled, params) {
+			matches = append(matches, copyDressmaker(dressmaker))
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].Email < matches[j].Email
+	})
+
+	return page(matches, params.Limit, params.Page), int64(len(matches)), nil
+}
+
+// copyDressmaker detaches the slices and pointers of a dressmaker so callers
+// can never mutate the stored record without going through Update.
+func copyDressmaker(dressmaker entity.Dressmaker) entity.Dressmaker {
+	if dressmaker.Services != nil {
+		dressmaker.Services = append([]string(nil), dressmaker.Services...)
+	}
+
+	if dressmaker.SubscriptionId != nil {
+		subscriptionId := *dressmaker.SubscriptionId
+		dressmaker.SubscriptionId = &subscriptionId
+	}
+
+	return dressmaker
+}
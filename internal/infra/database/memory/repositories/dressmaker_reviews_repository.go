@@ -0,0 +1,152 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/paulozy/costurai/internal/entity"
+	"github.com/paulozy/costurai/internal/infra/database"
+)
+
+type MemoryReviewsRepository struct {
+	mu      sync.RWMutex
+	Reviews map[string]entity.Review
+}
+
+func NewMemoryReviewsRepository() *MemoryReviewsRepository {
+	return &MemoryReviewsRepository{
+		Reviews: map[string]entity.Review{},
+	}
+}
+
+func (r *MemoryReviewsRepository) Create(ctx context.Context, review *entity.Review) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.Reviews[review.ID]; ok {
+		return fmt.Errorf("review with ID %s already exists", review.ID)
+	}
+
+	for _, stored := range r.Reviews {
+		if stored.DressmakerID == review.DressmakerID && stored.UserID == review.UserID {
+			return database.ErrAlreadyExists
+		}
+	}
+
+	r.Reviews[review.ID] = copyReview(*review)
+
+	return nil
+}
+
+func (r *MemoryReviewsRepository) FindByID(ctx context.Context, id string) (*entity.Review, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	review, ok := r.Reviews[id]
+	if !ok {
+		return nil, nil
+	}
+
+	found := copyReview(review)
+
+	return &found, nil
+}
+
+func (r *MemoryReviewsRepository) FindByDressmakerAndUser(ctx context.Context, dressmakerID, userID string) (*entity.Review, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, review := range r.Reviews {
+		if review.DressmakerID == dressmakerID && review.UserID == userID {
+			found := copyReview(review)
+			return &found, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *MemoryReviewsRepository) FindByDressmakerID(ctx context.Context, dressmakerID string) ([]entity.Review, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	reviews := []entity.Review{}
+	for _, review := range r.Reviews {
+		if review.DressmakerID == dressmakerID {
+			reviews = append(reviews, copyReview(review))
+		}
+	}
+
+	sort.Slice(reviews, func(i, j int) bool {
+		return reviews[i].ID < reviews[j].ID
+	})
+
+	return reviews, nil
+}
+
+func (r *MemoryReviewsRepository) FindForModeration(ctx context.Context) ([]entity.Review, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	reviews := []entity.Review{}
+	for _, review := range r.Reviews {
+		if needsModeration(review) {
+			reviews = append(reviews, copyReview(review))
+		}
+	}
+
+	sort.Slice(reviews, func(i, j int) bool {
+		if reviews[i].ReportCount != reviews[j].ReportCount {
+			return reviews[i].ReportCount > reviews[j].ReportCount
+		}
+
+		return reviews[i].CreatedAt < reviews[j].CreatedAt
+	})
+
+	return reviews, nil
+}
+
+func (r *MemoryReviewsRepository) Update(ctx context.Context, review *entity.Review) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.Reviews[review.ID]; !ok {
+		return fmt.Errorf("no review found with ID: %s", review.ID)
+	}
+
+	r.Reviews[review.ID] = copyReview(*review)
+
+	return nil
+}
+
+func (r *MemoryReviewsRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.Reviews[id]; !ok {
+		return fmt.Errorf("no review found with ID: %s", id)
+	}
+
+	delete(r.Reviews, id)
+
+	return nil
+}
+
+func copyReview(review entity.Review) entity.Review {
+	if review.Reply != nil {
+		reply := *review.Reply
+		review.Reply = &reply
+	}
+
+	if review.Reports != nil {
+		review.Reports = append([]entity.ReviewReport(nil), review.Reports...)
+	}
+
+	return review
+}
+
+func needsModeration(review entity.Review) bool {
+	return review.Status == entity.ReviewStatusPending || (review.IsPublished() && review.ReportCount > 0)
+}
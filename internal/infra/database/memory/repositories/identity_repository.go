@@ -0,0 +1,48 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/paulozy/costurai/internal/entity"
+)
+
+type MemoryIdentityRepository struct {
+	mu         sync.RWMutex
+	Identities map[string]entity.Identity
+}
+
+func NewMemoryIdentityRepository() *MemoryIdentityRepository {
+	return &MemoryIdentityRepository{
+		Identities: map[string]entity.Identity{},
+	}
+}
+
+func (r *MemoryIdentityRepository) Create(ctx context.Context, identity *entity.Identity) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.Identities {
+		if existing.Issuer == identity.Issuer && existing.Subject == identity.Subject && existing.AccountType == identity.AccountType {
+			return fmt.Errorf("identity %s at %s is already linked", identity.Subject, identity.Issuer)
+		}
+	}
+
+	r.Identities[identity.ID] = *identity
+
+	return nil
+}
+
+func (r *MemoryIdentityRepository) FindByIssuerAndSubject(ctx context.Context, issuer, subject, accountType string) (*entity.Identity, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, identity := range r.Identities {
+		if identity.Issuer == issuer && identity.Subject == subject && identity.AccountType == accountType {
+			return &identity, nil
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,44 @@
+package repositories
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/paulozy/costurai/internal/entity"
+)
+
+type MemoryLockRepository struct {
+	mu    sync.Mutex
+	Locks map[string]entity.Lock
+}
+
+func NewMemoryLockRepository() *MemoryLockRepository {
+	return &MemoryLockRepository{
+		Locks: map[string]entity.Lock{},
+	}
+}
+
+func (r *MemoryLockRepository) Acquire(ctx context.Context, name, holder string, ttl time.Duration) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if lock, ok := r.Locks[name]; ok && !lock.CanBeAcquiredBy(holder, time.Now()) {
+		return false, nil
+	}
+
+	r.Locks[name] = *entity.NewLock(name, holder, ttl)
+
+	return true, nil
+}
+
+func (r *MemoryLockRepository) Release(ctx context.Context, name, holder string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if lock, ok := r.Locks[name]; ok && lock.Holder == holder {
+		delete(r.Locks, name)
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/paulozy/costurai/internal/entity"
+)
+
+type MemoryOIDCLoginStateRepository struct {
+	mu     sync.Mutex
+	States map[string]entity.OIDCLoginState
+}
+
+func NewMemoryOIDCLoginStateRepository() *MemoryOIDCLoginStateRepository {
+	return &MemoryOIDCLoginStateRepository{
+		States: map[string]entity.OIDCLoginState{},
+	}
+}
+
+func (r *MemoryOIDCLoginStateRepository) Create(ctx context.Context, state *entity.OIDCLoginState) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.States[state.StateHash]; ok {
+		return fmt.Errorf("oidc login state already exists")
+	}
+
+	r.States[state.StateHash] = *state
+
+	return nil
+}
+
+func (r *MemoryOIDCLoginStateRepository) Consume(ctx context.Context, stateHash string) (*entity.OIDCLoginState, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	state, ok := r.States[stateHash]
+	if !ok {
+		return nil, nil
+	}
+
+	delete(r.States, stateHash)
+
+	return &state, nil
+}
@@ -0,0 +1,79 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/paulozy/costurai/internal/entity"
+)
+
+type MemoryOneTimeTokenRepository struct {
+	mu     sync.RWMutex
+	Tokens map[string]entity.OneTimeToken
+}
+
+func NewMemoryOneTimeTokenRepository() *MemoryOneTimeTokenRepository {
+	return &MemoryOneTimeTokenRepository{
+		Tokens: map[string]entity.OneTimeToken{},
+	}
+}
+
+func (r *MemoryOneTimeTokenRepository) Create(ctx context.Context, token *entity.OneTimeToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.Tokens[token.ID]; ok {
+		return fmt.Errorf("one-time token with ID %s already exists", token.ID)
+	}
+
+	r.Tokens[token.ID] = *token
+
+	return nil
+}
+
+func (r *MemoryOneTimeTokenRepository) FindByHash(ctx context.Context, purpose entity.OneTimeTokenPurpose, hash string) (*entity.OneTimeToken, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, token := range r.Tokens {
+		if token.Purpose == purpose && token.TokenHash == hash {
+			found := token
+			return &found, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *MemoryOneTimeTokenRepository) MarkUsed(ctx context.Context, id string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	token, ok := r.Tokens[id]
+	if !ok {
+		return false, fmt.Errorf("no one-time token found with ID: %s", id)
+	}
+
+	if token.UsedAt != nil {
+		return false, nil
+	}
+
+	token.MarkUsed()
+	r.Tokens[id] = token
+
+	return true, nil
+}
+
+func (r *MemoryOneTimeTokenRepository) DeleteBySubject(ctx context.Context, purpose entity.OneTimeTokenPurpose, subject string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, token := range r.Tokens {
+		if token.Purpose == purpose && token.Subject == subject {
+			delete(r.Tokens, id)
+		}
+	}
+
+	return nil
+}
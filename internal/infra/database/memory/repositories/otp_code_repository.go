@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"context"
+	"sync"
+
+	"github.com/paulozy/costurai/internal/entity"
+)
+
+type MemoryOTPCodeRepository struct {
+	mu    sync.RWMutex
+	Codes map[string]entity.OTPCode
+}
+
+func NewMemoryOTPCodeRepository() *MemoryOTPCodeRepository {
+	return &MemoryOTPCodeRepository{
+		Codes: map[string]entity.OTPCode{},
+	}
+}
+
+func (r *MemoryOTPCodeRepository) Save(ctx context.Context, code *entity.OTPCode) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.Codes[code.Phone] = *code
+
+	return nil
+}
+
+func (r *MemoryOTPCodeRepository) FindByPhone(ctx context.Context, phone string) (*entity.OTPCode, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	code, ok := r.Codes[phone]
+	if !ok {
+		return nil, nil
+	}
+
+	return &code, nil
+}
+
+func (r *MemoryOTPCodeRepository) IncrementAttempts(ctx context.Context, phone string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	code, ok := r.Codes[phone]
+	if !ok {
+		return 0, nil
+	}
+
+	code.Attempts++
+	r.Codes[phone] = code
+
+	return code.Attempts, nil
+}
+
+func (r *MemoryOTPCodeRepository) Delete(ctx context.Context, phone string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.Codes, phone)
+
+	return nil
+}
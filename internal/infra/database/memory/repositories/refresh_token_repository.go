@@ -0,0 +1,114 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/paulozy/costurai/internal/entity"
+)
+
+type MemoryRefreshTokenRepository struct {
+	mu              sync.RWMutex
+	Tokens          map[string]entity.RefreshToken
+	RevokedFamilies map[string]bool
+}
+
+func NewMemoryRefreshTokenRepository() *MemoryRefreshTokenRepository {
+	return &MemoryRefreshTokenRepository{
+		Tokens:          map[string]entity.RefreshToken{},
+		RevokedFamilies: map[string]bool{},
+	}
+}
+
+func (r *MemoryRefreshTokenRepository) Create(ctx context.Context, token *entity.RefreshToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.Tokens[token.ID]; ok {
+		return fmt.Errorf("refresh token with ID %s already exists", token.ID)
+	}
+
+	r.Tokens[token.ID] = *token
+
+	return nil
+}
+
+func (r *MemoryRefreshTokenRepository) FindByHash(ctx context.Context, hash string) (*entity.RefreshToken, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, token := range r.Tokens {
+		if token.TokenHash == hash {
+			found := token
+			return &found, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *MemoryRefreshTokenRepository) MarkUsed(ctx context.Context, id string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	token, ok := r.Tokens[id]
+	if !ok {
+		return false, fmt.Errorf("no refresh token found with ID: %s", id)
+	}
+
+	if token.UsedAt != nil {
+		return false, nil
+	}
+
+	token.MarkUsed()
+	r.Tokens[id] = token
+
+	return true, nil
+}
+
+func (r *MemoryRefreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for id, token := range r.Tokens {
+		if token.FamilyID == familyID && token.RevokedAt == nil {
+			token.RevokedAt = &now
+			r.Tokens[id] = token
+		}
+	}
+
+	r.RevokedFamilies[familyID] = true
+
+	return nil
+}
+
+func (r *MemoryRefreshTokenRepository) RevokeSubject(ctx context.Context, subject string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for id, token := range r.Tokens {
+		if token.Subject != subject {
+			continue
+		}
+
+		if token.RevokedAt == nil {
+			token.RevokedAt = &now
+			r.Tokens[id] = token
+		}
+
+		r.RevokedFamilies[token.FamilyID] = true
+	}
+
+	return nil
+}
+
+func (r *MemoryRefreshTokenRepository) IsFamilyRevoked(ctx context.Context, familyID string) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.RevokedFamilies[familyID], nil
+}
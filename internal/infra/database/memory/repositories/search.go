@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"strings"
+
+	"github.com/paulozy/costurai/internal/infra/database"
+	"github.com/paulozy/costurai/pkg/paginator"
+)
+
+func matchesAccount(email string, enabled bool, params database.AccountSearchParams) bool {
+	if params.Enabled != nil && *params.Enabled != enabled {
+		return false
+	}
+
+	return strings.HasPrefix(strings.ToLower(email), strings.ToLower(params.Query))
+}
+
+// page cuts the requested page out of the sorted matches.
+func page[T any](items []T, limit, page int64) []T {
+	if limit <= 0 {
+		return items
+	}
+
+	if page <= 0 {
+		page = 1
+	}
+
+	offset := paginator.GetOffset(limit, page, items)
+
+	return items[offset.Start:offset.End]
+}
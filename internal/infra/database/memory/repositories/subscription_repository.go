@@ -0,0 +1,133 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/paulozy/costurai/internal/entity"
+	"github.com/paulozy/costurai/internal/infra/database"
+)
+
+type MemorySubscriptionRepository struct {
+	mu            sync.RWMutex
+	Subscriptions map[string]entity.Subscription
+}
+
+func NewMemorySubscriptionRepository() *MemorySubscriptionRepository {
+	return &MemorySubscriptionRepository{
+		Subscriptions: map[string]entity.Subscription{},
+	}
+}
+
+func (r *MemorySubscriptionRepository) Create(ctx context.Context, subscription *entity.Subscription) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.Subscriptions[subscription.ID]; ok {
+		return fmt.Errorf("subscription with ID %s already exists", subscription.ID)
+	}
+
+	r.Subscriptions[subscription.ID] = *subscription
+
+	return nil
+}
+
+func (r *MemorySubscriptionRepository) FindByID(ctx context.Context, id string) (*entity.Subscription, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	subscription, ok := r.Subscriptions[id]
+	if !ok {
+		return nil, nil
+	}
+
+	return &subscription, nil
+}
+
+func (r *MemorySubscriptionRepository) FindByGatewayID(ctx context.Context, gatewayID string) (*entity.Subscription, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, subscription := range r.Subscriptions {
+		if subscription.GatewayId != nil && *subscription.GatewayId == gatewayID {
+			return &subscription, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *MemorySubscriptionRepository) Update(ctx context.Context, subscription *entity.Subscription) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.Subscriptions[subscription.ID]; !ok {
+		return fmt.Errorf("no subscription found with ID: %s", subscription.ID)
+	}
+
+	subscription.UpdatedAt = time.Now()
+	r.Subscriptions[subscription.ID] = *subscription
+
+	return nil
+}
+
+func (r *MemorySubscriptionRepository) UpdateIfUnchanged(ctx context.Context, subscription *entity.Subscription, readUpdatedAt time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.Subscriptions[subscription.ID]
+	if !ok || !stored.UpdatedAt.Equal(readUpdatedAt) {
+		return false, nil
+	}
+
+	subscription.UpdatedAt = time.Now()
+	r.Subscriptions[subscription.ID] = *subscription
+
+	return true, nil
+}
+
+func (r *MemorySubscriptionRepository) Search(ctx context.Context, params database.SubscriptionSearchParams) ([]entity.Subscription, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matches := []entity.Subscription{}
+	for _, subscription := range r.Subscriptions {
+		if params.DressmakerID != "" && subscription.DressmakerID != params.DressmakerID {
+			continue
+		}
+
+		if params.Status != "" && subscription.Status != params.Status {
+			continue
+		}
+
+		matches = append(matches, subscription)
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].CreatedAt.After(matches[j].CreatedAt)
+	})
+
+	return page(matches, params.Limit, params.Page), int64(len(matches)), nil
+}
+
+func (r *MemorySubscriptionRepository) FindLapsing(ctx context.Context, before time.Time) ([]entity.Subscription, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	lapsing := []entity.Subscription{}
+	for _, subscription := range r.Subscriptions {
+		if subscription.Status != entity.StatusActive && subscription.Status != entity.StatusCanceled {
+			continue
+		}
+
+		if (subscription.ExpiresAt != nil && subscription.ExpiresAt.Before(before)) ||
+			(subscription.GraceUntil != nil && subscription.GraceUntil.Before(before)) {
+			lapsing = append(lapsing, subscription)
+		}
+	}
+
+	return lapsing, nil
+}
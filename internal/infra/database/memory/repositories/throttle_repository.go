@@ -0,0 +1,56 @@
+package repositories
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/paulozy/costurai/internal/entity"
+)
+
+type MemoryThrottleRepository struct {
+	mu        sync.Mutex
+	Throttles map[string]entity.Throttle
+}
+
+func NewMemoryThrottleRepository() *MemoryThrottleRepository {
+	return &MemoryThrottleRepository{
+		Throttles: map[string]entity.Throttle{},
+	}
+}
+
+func (r *MemoryThrottleRepository) Find(ctx context.Context, key string) (*entity.Throttle, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	throttle, ok := r.Throttles[key]
+	if !ok {
+		return nil, nil
+	}
+
+	return &throttle, nil
+}
+
+func (r *MemoryThrottleRepository) RegisterAttempt(ctx context.Context, key string, policy entity.ThrottlePolicy) (*entity.Throttle, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	throttle, ok := r.Throttles[key]
+	if !ok {
+		throttle = *entity.NewThrottle(key)
+	}
+
+	throttle.Register(policy, time.Now())
+	r.Throttles[key] = throttle
+
+	return &throttle, nil
+}
+
+func (r *MemoryThrottleRepository) Delete(ctx context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.Throttles, key)
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/paulozy/costurai/internal/entity"
+	"github.com/paulozy/costurai/internal/infra/database"
+)
+
+type MemoryUserRepository struct {
+	mu    sync.RWMutex
+	Users map[string]entity.User
+}
+
+func NewMemoryUserRepository() *MemoryUserRepository {
+	return &MemoryUserRepository{
+		Users: map[string]entity.User{},
+	}
+}
+
+func (r *MemoryUserRepository) Create(ctx context.Context, user *entity.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.Users[user.ID]; ok {
+		return fmt.Errorf("user with ID %s already exists", user.ID)
+	}
+
+	r.Users[user.ID] = *user
+
+	return nil
+}
+
+func (r *MemoryUserRepository) FindByEmail(ctx context.Context, email string) (*entity.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, user := range r.Users {
+		if user.Email == email {
+			found := user
+			return &found, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *MemoryUserRepository) Exists(ctx context.Context, email string) (bool, error) {
+	user, err := r.FindByEmail(ctx, email)
+	if err != nil {
+		return false, err
+	}
+
+	return user != nil, nil
+}
+
+func (r *MemoryUserRepository) FindByID(ctx context.Context, id string) (*entity.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	user, ok := r.Users[id]
+	if !ok {
+		return nil, nil
+	}
+
+	return &user, nil
+}
+
+func (r *MemoryUserRepository) Update(ctx context.Context, user *entity.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.Users[user.ID]; !ok {
+		return fmt.Errorf("no user found with ID: %s", user.ID)
+	}
+
+	r.Users[user.ID] = *user
+
+	return nil
+}
+
+func (r *MemoryUserRepository) Search(ctx context.Context, params database.AccountSearchParams) ([]entity.User, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matches := []entity.User{}
+	for _, user := range r.Users {
+		if matchesAccount(user.Email, user.Enabled, params) {
+			matches = append(matches, user)
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].Email < matches[j].Email
+	})
+
+	return page(matches, params.Limit, params.Page), int64(len(matches)), nil
+}
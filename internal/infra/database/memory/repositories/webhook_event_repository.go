@@ -0,0 +1,108 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/paulozy/costurai/internal/entity"
+	"github.com/paulozy/costurai/internal/infra/database"
+)
+
+type MemoryWebhookEventRepository struct {
+	mu     sync.RWMutex
+	Events map[string]entity.WebhookEvent
+}
+
+func NewMemoryWebhookEventRepository() *MemoryWebhookEventRepository {
+	return &MemoryWebhookEventRepository{
+		Events: map[string]entity.WebhookEvent{},
+	}
+}
+
+func (r *MemoryWebhookEventRepository) Create(ctx context.Context, event *entity.WebhookEvent) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.Events[event.ID]; ok {
+		return false, nil
+	}
+
+	stored := *event
+	stored.Payload = slices.Clone(event.Payload)
+	r.Events[event.ID] = stored
+
+	return true, nil
+}
+
+func (r *MemoryWebhookEventRepository) FindByID(ctx context.Context, id string) (*entity.WebhookEvent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	event, ok := r.Events[id]
+	if !ok {
+		return nil, nil
+	}
+
+	event.Payload = slices.Clone(event.Payload)
+
+	return &event, nil
+}
+
+func (r *MemoryWebhookEventRepository) Claim(ctx context.Context, id string, staleBefore time.Time, settled bool) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	event, ok := r.Events[id]
+	if !ok || !event.CanBeClaimed(staleBefore, settled) {
+		return false, nil
+	}
+
+	event.Claim()
+	r.Events[id] = event
+
+	return true, nil
+}
+
+func (r *MemoryWebhookEventRepository) Update(ctx context.Context, event *entity.WebhookEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.Events[event.ID]; !ok {
+		return fmt.Errorf("no webhook event found with ID: %s", event.ID)
+	}
+
+	stored := *event
+	stored.Payload = slices.Clone(event.Payload)
+	r.Events[event.ID] = stored
+
+	return nil
+}
+
+func (r *MemoryWebhookEventRepository) Search(ctx context.Context, params database.WebhookEventSearchParams) ([]entity.WebhookEvent, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matches := []entity.WebhookEvent{}
+	for _, event := range r.Events {
+		if params.Type != "" && event.Type != params.Type {
+			continue
+		}
+
+		if params.Status != "" && event.Status != params.Status {
+			continue
+		}
+
+		event.Payload = slices.Clone(event.Payload)
+		matches = append(matches, event)
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].OccurredAt.After(matches[j].OccurredAt)
+	})
+
+	return page(matches, params.Limit, params.Page), int64(len(matches)), nil
+}
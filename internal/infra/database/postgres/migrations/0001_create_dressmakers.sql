@@ -0,0 +1,23 @@
+CREATE EXTENSION IF NOT EXISTS postgis;
+
+CREATE TABLE IF NOT EXISTS dressmakers (
+    id              TEXT PRIMARY KEY,
+    email           TEXT NOT NULL UNIQUE,
+    password        TEXT NOT NULL,
+    name            TEXT NOT NULL,
+    contact         TEXT NOT NULL,
+    enabled         BOOLEAN NOT NULL DEFAULT FALSE,
+    grade           DOUBLE PRECISION NOT NULL DEFAULT 0,
+    services        TEXT[] NOT NULL DEFAULT '{}',
+    subscription_id TEXT,
+    street          TEXT NOT NULL DEFAULT '',
+    number          TEXT NOT NULL DEFAULT '',
+    neighborhood    TEXT NOT NULL DEFAULT '',
+    city            TEXT NOT NULL DEFAULT '',
+    state           TEXT NOT NULL DEFAULT '',
+    location        GEOGRAPHY(POINT, 4326) NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL,
+    updated_at      TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS dressmakers_location_idx ON dressmakers USING GIST (location);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS users (
+    id         TEXT PRIMARY KEY,
+    email      TEXT NOT NULL UNIQUE,
+    password   TEXT NOT NULL,
+    name       TEXT NOT NULL,
+    enabled    BOOLEAN NOT NULL DEFAULT FALSE,
+    latitude   DOUBLE PRECISION NOT NULL DEFAULT 0,
+    longitude  DOUBLE PRECISION NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS subscriptions (
+    id            TEXT PRIMARY KEY,
+    dressmaker_id TEXT NOT NULL REFERENCES dressmakers (id),
+    plan          JSONB NOT NULL,
+    price         JSONB NOT NULL,
+    periodicity   TEXT NOT NULL DEFAULT '',
+    status        TEXT NOT NULL,
+    started_at    TIMESTAMPTZ,
+    expires_at    TIMESTAMPTZ,
+    canceled_at   TIMESTAMPTZ,
+    grace_until   TIMESTAMPTZ,
+    gateway_id    TEXT,
+    payment_url   TEXT,
+    created_at    TIMESTAMPTZ NOT NULL,
+    updated_at    TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS subscriptions_dressmaker_id_idx ON subscriptions (dressmaker_id);
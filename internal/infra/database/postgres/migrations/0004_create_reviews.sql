@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS reviews (
+    id            TEXT PRIMARY KEY,
+    dressmaker_id TEXT NOT NULL REFERENCES dressmakers (id),
+    user_id       TEXT NOT NULL,
+    grade         DOUBLE PRECISION NOT NULL,
+    comment       TEXT NOT NULL,
+    created_at    TIMESTAMPTZ NOT NULL,
+    updated_at    TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS reviews_dressmaker_id_idx ON reviews (dressmaker_id);
@@ -0,0 +1 @@
+CREATE UNIQUE INDEX IF NOT EXISTS reviews_dressmaker_user_idx ON reviews (dressmaker_id, user_id);
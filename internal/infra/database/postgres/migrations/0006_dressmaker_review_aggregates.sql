@@ -0,0 +1,17 @@
+ALTER TABLE dressmakers
+    ADD COLUMN IF NOT EXISTS review_count BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS grade_sum DOUBLE PRECISION NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS score DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+-- prior mean 3.5 and prior weight 5 mirror entity.RatingPriorMean/RatingPriorWeight
+UPDATE dressmakers d SET
+    review_count = agg.review_count,
+    grade_sum = agg.grade_sum,
+    grade = ROUND((agg.grade_sum / agg.review_count)::numeric, 2),
+    score = ROUND(((3.5 * 5 + agg.grade_sum) / (5 + agg.review_count))::numeric, 4)
+FROM (
+    SELECT dressmaker_id, COUNT(*) AS review_count, SUM(grade) AS grade_sum
+    FROM reviews
+    GROUP BY dressmaker_id
+) agg
+WHERE agg.dressmaker_id = d.id;
@@ -0,0 +1,4 @@
+ALTER TABLE reviews
+    ADD COLUMN IF NOT EXISTS reply_comment TEXT,
+    ADD COLUMN IF NOT EXISTS reply_created_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS reply_updated_at TIMESTAMPTZ;
@@ -0,0 +1,11 @@
+ALTER TABLE reviews
+    ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'published',
+    ADD COLUMN IF NOT EXISTS reports JSONB NOT NULL DEFAULT '[]',
+    ADD COLUMN IF NOT EXISTS report_count INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS moderation_reason TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS reviews_moderation_idx
+    ON reviews (status, report_count);
+
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS admin BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS one_time_tokens (
+    id           TEXT PRIMARY KEY,
+    purpose      TEXT NOT NULL,
+    subject      TEXT NOT NULL,
+    account_type TEXT NOT NULL,
+    token_hash   TEXT NOT NULL UNIQUE,
+    expires_at   TIMESTAMPTZ NOT NULL,
+    used_at      TIMESTAMPTZ,
+    created_at   TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS one_time_tokens_subject_idx ON one_time_tokens (purpose, subject);
+
+CREATE INDEX IF NOT EXISTS refresh_tokens_subject_idx ON refresh_tokens (subject);
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS throttles (
+    key             TEXT PRIMARY KEY,
+    attempts        INTEGER NOT NULL DEFAULT 0,
+    lockouts        INTEGER NOT NULL DEFAULT 0,
+    last_attempt_at TIMESTAMPTZ NOT NULL DEFAULT 'epoch',
+    blocked_until   TIMESTAMPTZ NOT NULL DEFAULT 'epoch'
+);
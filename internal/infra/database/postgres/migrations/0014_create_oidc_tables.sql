@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS oidc_login_states (
+    state_hash    TEXT PRIMARY KEY,
+    account_type  TEXT NOT NULL,
+    code_verifier TEXT NOT NULL,
+    nonce         TEXT NOT NULL,
+    expires_at    TIMESTAMPTZ NOT NULL,
+    created_at    TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS identities (
+    id           TEXT PRIMARY KEY,
+    issuer       TEXT NOT NULL,
+    subject      TEXT NOT NULL,
+    account_type TEXT NOT NULL,
+    account_id   TEXT NOT NULL,
+    email        TEXT NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL,
+    UNIQUE (issuer, subject, account_type)
+);
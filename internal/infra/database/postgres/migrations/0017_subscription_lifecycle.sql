@@ -0,0 +1,8 @@
+CREATE UNIQUE INDEX IF NOT EXISTS subscriptions_gateway_id_idx ON subscriptions (gateway_id);
+
+-- subscriptions used to only keep the periodicity and price inside the plan
+UPDATE subscriptions SET periodicity = plan->'periodicity'->>'PeriodicityType'
+WHERE periodicity = '' AND plan->'periodicity'->>'PeriodicityType' IS NOT NULL;
+
+UPDATE subscriptions SET price = plan->'price'
+WHERE COALESCE(price->>'currency', '') = '' AND plan->'price' IS NOT NULL;
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS webhook_events (
+    id           TEXT PRIMARY KEY,
+    gateway      TEXT NOT NULL,
+    type         TEXT NOT NULL,
+    payload      BYTEA NOT NULL,
+    status       TEXT NOT NULL,
+    error        TEXT NOT NULL DEFAULT '',
+    attempts     INTEGER NOT NULL DEFAULT 0,
+    occurred_at  TIMESTAMPTZ NOT NULL,
+    received_at  TIMESTAMPTZ NOT NULL,
+    processed_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS webhook_events_occurred_at_idx ON webhook_events (occurred_at DESC);
+CREATE INDEX IF NOT EXISTS webhook_events_status_idx ON webhook_events (status, occurred_at DESC);
+
+ALTER TABLE subscriptions ADD COLUMN IF NOT EXISTS last_event_at TIMESTAMPTZ;
@@ -0,0 +1,10 @@
+ALTER TABLE subscriptions ADD COLUMN IF NOT EXISTS expiry_notice_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS subscriptions_expires_at_idx ON subscriptions (status, expires_at);
+CREATE INDEX IF NOT EXISTS subscriptions_grace_until_idx ON subscriptions (status, grace_until);
+
+CREATE TABLE IF NOT EXISTS locks (
+    name       TEXT PRIMARY KEY,
+    holder     TEXT NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL
+);
@@ -0,0 +1,121 @@
+package postgres
+
+import (
+	"database/sql"
+	"embed"
+	"fmt"
+	"log"
+	"sort"
+
+	_ "github.com/lib/pq"
+	"github.com/paulozy/costurai/configs"
+	"github.com/paulozy/costurai/internal/infra/database"
+	"github.com/paulozy/costurai/internal/infra/database/postgres/repositories"
+)
+
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+func NewPostgresClient(cfg *configs.Config) *sql.DB {
+	driver := cfg.DBDriver
+	if driver == "" {
+		driver = "postgres"
+	}
+
+	dsn := fmt.Sprintf(
+		"host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
+		cfg.DBHost, cfg.DBPort, cfg.DBUser, cfg.DBPassword, cfg.DBName,
+	)
+
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		log.Panic("Error to connect postgres", err)
+	}
+
+	if err := db.Ping(); err != nil {
+		log.Panic("Error to connect postgres", err)
+	}
+
+	return db
+}
+
+// RunMigrations applies every embedded migration that was not applied yet,
+// in file name order, each one inside its own transaction.
+func RunMigrations(db *sql.DB) error {
+	_, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    TEXT PRIMARY KEY,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	)`)
+	if err != nil {
+		return err
+	}
+
+	entries, err := migrations.ReadDir("migrations")
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		var applied bool
+		err := db.QueryRow(`SELECT EXISTS(SELECT 1 FROM schema_migrations WHERE version = $1)`, name).Scan(&applied)
+		if err != nil {
+			return err
+		}
+
+		if applied {
+			continue
+		}
+
+		content, err := migrations.ReadFile("migrations/" + name)
+		if err != nil {
+			return err
+		}
+
+		tx, err := db.Begin()
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec(string(content)); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migration %s: %w", name, err)
+		}
+
+		if _, err := tx.Exec(`INSERT INTO schema_migrations (version) VALUES ($1)`, name); err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+
+		log.Printf("applied migration %s", name)
+	}
+
+	return nil
+}
+
+func NewPostgresRepositories(db *sql.DB) *database.Repositories {
+	return &database.Repositories{
+		Dressmaker:        repositories.NewPostgresDressmakerRepository(db),
+		User:              repositories.NewPostgresUserRepository(db),
+		Subscription:      repositories.NewPostgresSubscriptionRepository(db),
+		DressmakerReviews: repositories.NewPostgresReviewsRepository(db),
+		RefreshToken:      repositories.NewPostgresRefreshTokenRepository(db),
+		OneTimeToken:      repositories.NewPostgresOneTimeTokenRepository(db),
+		OTPCode:           repositories.NewPostgresOTPCodeRepository(db),
+		Throttle:          repositories.NewPostgresThrottleRepository(db),
+		OIDCLoginState:    repositories.NewPostgresOIDCLoginStateRepository(db),
+		Identity:          repositories.NewPostgresIdentityRepository(db),
+		AuditLog:          repositories.NewPostgresAuditLogRepository(db),
+		WebhookEvent:      repositories.NewPostgresWebhookEventRepository(db),
+		Lock:              repositories.NewPostgresLockRepository(db),
+	}
+}
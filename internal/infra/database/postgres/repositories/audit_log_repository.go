@@ -0,0 +1,113 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/paulozy/costurai/internal/entity"
+	"github.com/paulozy/costurai/internal/infra/database"
+)
+
+const auditEntryColumns = `id, actor_id, action, target_type, target_id, reason, changes, created_at`
+
+type PostgresAuditLogRepository struct {
+	DB *sql.DB
+}
+
+func NewPostgresAuditLogRepository(db *sql.DB) *PostgresAuditLogRepository {
+	return &PostgresAuditLogRepository{
+		DB: db,
+	}
+}
+
+func (r *PostgresAuditLogRepository) Create(ctx context.Context, entry *entity.AuditEntry) error {
+	var changes []byte
+	if entry.Changes != nil {
+		var err error
+		changes, err = json.Marshal(entry.Changes)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := r.DB.ExecContext(ctx, `
+		INSERT INTO audit_log (`+auditEntryColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		entry.ID,
+		entry.ActorID,
+		entry.Action,
+		entry.TargetType,
+		entry.TargetID,
+		entry.Reason,
+		changes,
+		entry.CreatedAt,
+	)
+
+	return err
+}
+
+func (r *PostgresAuditLogRepository) Search(ctx context.Context, params database.AuditLogSearchParams) ([]entity.AuditEntry, int64, error) {
+	filter := `WHERE ($1 = '' OR actor_id = $1) AND ($2 = '' OR target_type = $2) AND ($3 = '' OR target_id = $3)`
+
+	var total int64
+	err := r.DB.QueryRowContext(ctx, `SELECT COUNT(*) FROM audit_log `+filter,
+		params.ActorID, params.TargetType, params.TargetID,
+	).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	limit, offset := pageBounds(params.Limit, params.Page)
+
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT `+auditEntryColumns+`
+		FROM audit_log `+filter+`
+		ORDER BY created_at DESC
+		LIMIT $4 OFFSET $5`,
+		params.ActorID, params.TargetType, params.TargetID, limit, offset,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	entries := []entity.AuditEntry{}
+	for rows.Next() {
+		entry, err := scanAuditEntry(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		entries = append(entries, *entry)
+	}
+
+	return entries, total, rows.Err()
+}
+
+func scanAuditEntry(row scanner) (*entity.AuditEntry, error) {
+	var entry entity.AuditEntry
+	var changes []byte
+
+	err := row.Scan(
+		&entry.ID,
+		&entry.ActorID,
+		&entry.Action,
+		&entry.TargetType,
+		&entry.TargetID,
+		&entry.Reason,
+		&changes,
+		&entry.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if changes != nil {
+		if err := json.Unmarshal(changes, &entry.Changes); err != nil {
+			return nil, err
+		}
+	}
+
+	return &entry, nil
+}
@@ -0,0 +1,303 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/paulozy/costurai/internal/entity"
+	"github.com/paulozy/costurai/internal/infra/database"
+)
+
+const dressmakerColumns = `id, email, password, name, contact, phone, phone_verified_at, email_verified_at, enabled, suspended,
+	grade, review_count, grade_sum, score, services, subscription_id,
+	street, number, neighborhood, city, state,
+	ST_Y(location::geometry), ST_X(location::geometry),
+	created_at, updated_at`
+
+type PostgresDressmakerRepository struct {
+	DB *sql.DB
+}
+
+func NewPostgresDressmakerRepository(db *sql.DB) *PostgresDressmakerRepository {
+	return &PostgresDressmakerRepository{
+		DB: db,
+	}
+}
+
+func (r *PostgresDressmakerRepository) Create(ctx context.Context, dressmaker *entity.Dressmaker) error {
+	_, err := r.DB.ExecContext(ctx, `
+		INSERT INTO dressmakers (
+			id, email, password, name, contact, phone, phone_verified_at, email_verified_at, enabled, suspended,
+			grade, review_count, grade_sum, score, services, subscription_id,
+			street, number, neighborhood, city, state, location, created_at, updated_at
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10,
+			$11, $12, $13, $14, $15, $16,
+			$17, $18, $19, $20, $21, ST_SetSRID(ST_MakePoint($22, $23), 4326)::geography, $24, $25
+		)`,
+		dressmaker.ID,
+		dressmaker.Email,
+		dressmaker.Password,
+		dressmaker.Name,
+		dressmaker.Contact,
+		dressmaker.Phone,
+		dressmaker.PhoneVerifiedAt,
+		dressmaker.EmailVerifiedAt,
+		dressmaker.Enabled,
+		dressmaker.Suspended,
+		dressmaker.Grade,
+		dressmaker.ReviewCount,
+		dressmaker.GradeSum,
+		dressmaker.Score,
+		pq.Array(dressmaker.Services),
+		dressmaker.SubscriptionId,
+		dressmaker.Address.Street,
+		dressmaker.Address.Number,
+		dressmaker.Address.Neighborhood,
+		dressmaker.Address.City,
+		dressmaker.Address.State,
+		dressmaker.Address.Location.Longitude,
+		dressmaker.Address.Location.Latitude,
+		dressmaker.CreatedAt,
+		dressmaker.UpdatedAt,
+	)
+
+	return err
+}
+
+func (r *PostgresDressmakerRepository) FindByEmail(ctx context.Context, email string) (*entity.Dressmaker, error) {
+	row := r.DB.QueryRowContext(ctx, `SELECT `+dressmakerColumns+` FROM dressmakers WHERE email = $1`, email)
+
+	dressmaker, err := scanDressmaker(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return dressmaker, err
+}
+
+func (r *PostgresDressmakerRepository) Exists(ctx context.Context, email string) (bool, error) {
+	var exists bool
+	err := r.DB.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM dressmakers WHERE email = $1)`, email).Scan(&exists)
+
+	return exists, err
+}
+
+func (r *PostgresDressmakerRepository) FindByID(ctx context.Context, id string) (*entity.Dressmaker, error) {
+	row := r.DB.QueryRowContext(ctx, `SELECT `+dressmakerColumns+` FROM dressmakers WHERE id = $1`, id)
+
+	dressmaker, err := scanDressmaker(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return dressmaker, err
+}
+
+func (r *PostgresDressmakerRepository) FindByProximity(ctx context.Context, latitude, longitude float64, maxDistance int) ([]entity.Dressmaker, error) {
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT `+dressmakerColumns+`
+		FROM dressmakers
+		WHERE ST_DWithin(location, ST_SetSRID(ST_MakePoint($1, $2), 4326)::geography, $3)
+		ORDER BY ST_Distance(location, ST_SetSRID(ST_MakePoint($1, $2), 4326)::geography)`,
+		longitude, latitude, maxDistance,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var dressmakers []entity.Dressmaker
+
+	for rows.Next() {
+		dressmaker, err := scanDressmaker(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		dressmakers = append(dressmakers, *dressmaker)
+	}
+
+	return dressmakers, rows.Err()
+}
+
+func (r *PostgresDressmakerRepository) Update(ctx context.Context, dressmaker *entity.Dressmaker) error {
+	result, err := r.DB.ExecContext(ctx, `
+		UPDATE dressmakers SET
+			name = $2,
+			email = $3,
+			contact = $4,
+			enabled = $5,
+			services = $6,
+			subscription_id = $7,
+			street = $8,
+			number = $9,
+			neighborhood = $10,
+			city = $11,
+			state = $12,
+			location = ST_SetSRID(ST_MakePoint($13, $14), 4326)::geography,
+			updated_at = $15,
+			password = $16,
+			phone = $17,
+			phone_verified_at = $18,
+			suspended = $19,
+			email_verified_at = $20
+		WHERE id = $1`,
+		dressmaker.ID,
+		dressmaker.Name,
+		dressmaker.Email,
+		dressmaker.Contact,
+		dressmaker.Enabled,
+		pq.Array(dressmaker.Services),
+		dressmaker.SubscriptionId,
+		dressmaker.Address.Street,
+		dressmaker.Address.Number,
+		dressmaker.Address.Neighborhood,
+		dressmaker.Address.City,
+		dressmaker.Address.State,
+		dressmaker.Address.Location.Longitude,
+		dressmaker.Address.Location.Latitude,
+		dressmaker.UpdatedAt,
+		dressmaker.Password,
+		dressmaker.Phone,
+		dressmaker.PhoneVerifiedAt,
+		dressmaker.Suspended,
+		dressmaker.EmailVerifiedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	return expectAffected(result, "dressmaker", dressmaker.ID)
+}
+
+// ApplyReviewDelta locks the dressmaker row while updating the review
+// aggregates, so concurrent reviews never overwrite each other.
+func (r *PostgresDressmakerRepository) ApplyReviewDelta(ctx context.Context, id string, countDelta int64, gradeDelta float64) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var dressmaker entity.Dressmaker
+	dressmaker.ID = id
+
+	err = tx.QueryRowContext(ctx, `
+		SELECT review_count, grade_sum
+		FROM dressmakers
+		WHERE id = $1
+		FOR UPDATE`,
+		id,
+	).Scan(&dressmaker.ReviewCount, &dressmaker.GradeSum)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("no dressmaker found with ID: %s", id)
+	}
+	if err != nil {
+		return err
+	}
+
+	dressmaker.ApplyReviewDelta(countDelta, gradeDelta)
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE dressmakers SET
+			review_count = $2,
+			grade_sum = $3,
+			grade = $4,
+			score = $5
+		WHERE id = $1`,
+		id,
+		dressmaker.ReviewCount,
+		dressmaker.GradeSum,
+		dressmaker.Grade,
+		dressmaker.Score,
+	)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func (r *PostgresDressmakerRepository) Search(ctx context.Context, params database.AccountSearchParams) ([]entity.Dressmaker, int64, error) {
+	filter := `WHERE starts_with(lower(email), lower($1)) AND ($2::boolean IS NULL OR enabled = $2)`
+
+	var total int64
+	err := r.DB.QueryRowContext(ctx, `SELECT COUNT(*) FROM dressmakers `+filter, params.Query, params.Enabled).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	limit, offset := pageBounds(params.Limit, params.Page)
+
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT `+dressmakerColumns+`
+		FROM dressmakers `+filter+`
+		ORDER BY email
+		LIMIT $3 OFFSET $4`,
+		params.Query, params.Enabled, limit, offset,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	dressmakers := []entity.Dressmaker{}
+	for rows.Next() {
+		dressmaker, err := scanDressmaker(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		dressmakers = append(dressmakers, *dressmaker)
+	}
+
+	return dressmakers, total, rows.Err()
+}
+
+func scanDressmaker(row scanner) (*entity.Dressmaker, error) {
+	var dressmaker entity.Dressmaker
+	var services pq.StringArray
+
+	err := row.Scan(
+		&dressmaker.ID,
+		&dressmaker.Email,
+		&dressmaker.Password,
+		&dressmaker.Name,
+		&dressmaker.Contact,
+		&dressmaker.Phone,
+		&dressmaker.PhoneVerifiedAt,
+		&dressmaker.EmailVerifiedAt,
+		&dressmaker.Enabled,
+		&dressmaker.Suspended,
+		&dressmaker.Grade,
+		&dressmaker.ReviewCount,
+		&dressmaker.GradeSum,
+		&dressmaker.Score,
+		&services,
+		&dressmaker.SubscriptionId,
+		&dressmaker.Address.Street,
+		&dressmaker.Address.Number,
+		&dressmaker.Address.Neighborhood,
+		&dressmaker.Address.City,
+		&dressmaker.Address.State,
+		&dressmaker.Address.Location.Latitude,
+		&dressmaker.Address.Location.Longitude,
+		&dressmaker.CreatedAt,
+		&dressmaker.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	dressmaker.Services = services
+	dressmaker.EmailVerified = dressmaker.EmailVerifiedAt != nil
+
+	return &dressmaker, nil
+}
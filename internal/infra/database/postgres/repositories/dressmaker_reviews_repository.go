@@ -0,0 +1,316 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/paulozy/costurai/internal/entity"
+	"github.com/paulozy/costurai/internal/infra/database"
+)
+
+const reviewColumns = `id, dressmaker_id, user_id, grade, comment,
+	reply_comment, reply_created_at, reply_updated_at,
+	status, reports, report_count, moderation_reason, created_at, updated_at`
+
+type PostgresReviewsRepository struct {
+	DB *sql.DB
+}
+
+func NewPostgresReviewsRepository(db *sql.DB) *PostgresReviewsRepository {
+	return &PostgresReviewsRepository{
+		DB: db,
+	}
+}
+
+func (r *PostgresReviewsRepository) Create(ctx context.Context, review *entity.Review) error {
+	createdAt, err := parseTimestamp(review.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	updatedAt, err := parseTimestamp(review.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	replyComment, replyCreatedAt, replyUpdatedAt, err := replyColumns(review.Reply)
+	if err != nil {
+		return err
+	}
+
+	reports, err := json.Marshal(reviewReports(review))
+	if err != nil {
+		return err
+	}
+
+	_, err = r.DB.ExecContext(ctx, `
+		INSERT INTO reviews (`+reviewColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`,
+		review.ID,
+		review.DressmakerID,
+		review.UserID,
+		review.Grade,
+		review.Comment,
+		replyComment,
+		replyCreatedAt,
+		replyUpdatedAt,
+		reviewStatus(review),
+		reports,
+		review.ReportCount,
+		review.ModerationReason,
+		createdAt,
+		updatedAt,
+	)
+	if isUniqueViolation(err) {
+		return database.ErrAlreadyExists
+	}
+
+	return err
+}
+
+func (r *PostgresReviewsRepository) FindByID(ctx context.Context, id string) (*entity.Review, error) {
+	row := r.DB.QueryRowContext(ctx, `SELECT `+reviewColumns+` FROM reviews WHERE id = $1`, id)
+
+	review, err := scanReview(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return review, err
+}
+
+func (r *PostgresReviewsRepository) FindByDressmakerAndUser(ctx context.Context, dressmakerID, userID string) (*entity.Review, error) {
+	row := r.DB.QueryRowContext(ctx, `
+		SELECT `+reviewColumns+`
+		FROM reviews
+		WHERE dressmaker_id = $1 AND user_id = $2`,
+		dressmakerID, userID,
+	)
+
+	review, err := scanReview(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return review, err
+}
+
+func (r *PostgresReviewsRepository) FindByDressmakerID(ctx context.Context, dressmakerID string) ([]entity.Review, error) {
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT `+reviewColumns+`
+		FROM reviews
+		WHERE dressmaker_id = $1
+		ORDER BY created_at DESC`,
+		dressmakerID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reviews := []entity.Review{}
+	for rows.Next() {
+		review, err := scanReview(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		reviews = append(reviews, *review)
+	}
+
+	return reviews, rows.Err()
+}
+
+func (r *PostgresReviewsRepository) FindForModeration(ctx context.Context) ([]entity.Review, error) {
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT `+reviewColumns+`
+		FROM reviews
+		WHERE status = $1 OR (status = $2 AND report_count > 0)
+		ORDER BY report_count DESC, created_at ASC`,
+		entity.ReviewStatusPending, entity.ReviewStatusPublished,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reviews := []entity.Review{}
+	for rows.Next() {
+		review, err := scanReview(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		reviews = append(reviews, *review)
+	}
+
+	return reviews, rows.Err()
+}
+
+func (r *PostgresReviewsRepository) Update(ctx context.Context, review *entity.Review) error {
+	updatedAt, err := parseTimestamp(review.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	replyComment, replyCreatedAt, replyUpdatedAt, err := replyColumns(review.Reply)
+	if err != nil {
+		return err
+	}
+
+	reports, err := json.Marshal(reviewReports(review))
+	if err != nil {
+		return err
+	}
+
+	result, err := r.DB.ExecContext(ctx, `
+		UPDATE reviews SET
+			grade = $2,
+			comment = $3,
+			reply_comment = $4,
+			reply_created_at = $5,
+			reply_updated_at = $6,
+			status = $7,
+			reports = $8,
+			report_count = $9,
+			moderation_reason = $10,
+			updated_at = $11
+		WHERE id = $1`,
+		review.ID,
+		review.Grade,
+		review.Comment,
+		replyComment,
+		replyCreatedAt,
+		replyUpdatedAt,
+		reviewStatus(review),
+		reports,
+		review.ReportCount,
+		review.ModerationReason,
+		updatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	return expectAffected(result, "review", review.ID)
+}
+
+func (r *PostgresReviewsRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.DB.ExecContext(ctx, `DELETE FROM reviews WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	return expectAffected(result, "review", id)
+}
+
+func scanReview(row scanner) (*entity.Review, error) {
+	var review entity.Review
+	var createdAt, updatedAt time.Time
+	var replyComment sql.NullString
+	var replyCreatedAt, replyUpdatedAt sql.NullTime
+	var reports []byte
+
+	err := row.Scan(
+		&review.ID,
+		&review.DressmakerID,
+		&review.UserID,
+		&review.Grade,
+		&review.Comment,
+		&replyComment,
+		&replyCreatedAt,
+		&replyUpdatedAt,
+		&review.Status,
+		&reports,
+		&review.ReportCount,
+		&review.ModerationReason,
+		&createdAt,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if replyComment.Valid {
+		review.Reply = &entity.ReviewReply{
+			Comment:   replyComment.String,
+			CreatedAt: replyCreatedAt.Time.Format(time.RFC3339),
+			UpdatedAt: replyUpdatedAt.Time.Format(time.RFC3339),
+		}
+	}
+
+	if err := json.Unmarshal(reports, &review.Reports); err != nil {
+		return nil, err
+	}
+
+	if len(review.Reports) == 0 {
+		review.Reports = nil
+	}
+
+	review.CreatedAt = createdAt.Format(time.RFC3339)
+	review.UpdatedAt = updatedAt.Format(time.RFC3339)
+
+	return &review, nil
+}
+
+func replyColumns(reply *entity.ReviewReply) (comment *string, createdAt, updatedAt *time.Time, err error) {
+	if reply == nil {
+		return nil, nil, nil, nil
+	}
+
+	created, err := parseTimestamp(reply.CreatedAt)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	updated, err := parseTimestamp(reply.UpdatedAt)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return &reply.Comment, &created, &updated, nil
+}
+
+// reviewStatus defaults reviews built without a status to published, the
+// same way entity.Review.IsPublished reads them.
+func reviewStatus(review *entity.Review) entity.ReviewStatus {
+	if review.Status == "" {
+		return entity.ReviewStatusPublished
+	}
+
+	return review.Status
+}
+
+func reviewReports(review *entity.Review) []entity.ReviewReport {
+	if review.Reports == nil {
+		return []entity.ReviewReport{}
+	}
+
+	return review.Reports
+}
+
+// expectAffected turns an UPDATE or DELETE that matched no rows into the same
+// "not found" error the other backends return.
+// isUniqueViolation reports whether the statement broke a unique index.
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
+
+func expectAffected(result sql.Result, entityName, id string) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return fmt.Errorf("no %s found with ID: %s", entityName, id)
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/paulozy/costurai/internal/entity"
+)
+
+type PostgresIdentityRepository struct {
+	DB *sql.DB
+}
+
+func NewPostgresIdentityRepository(db *sql.DB) *PostgresIdentityRepository {
+	return &PostgresIdentityRepository{
+		DB: db,
+	}
+}
+
+func (r *PostgresIdentityRepository) Create(ctx context.Context, identity *entity.Identity) error {
+	_, err := r.DB.ExecContext(ctx, `
+		INSERT INTO identities (id, issuer, subject, account_type, account_id, email, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		identity.ID,
+		identity.Issuer,
+		identity.Subject,
+		identity.AccountType,
+		identity.AccountID,
+		identity.Email,
+		identity.CreatedAt,
+	)
+
+	return err
+}
+
+func (r *PostgresIdentityRepository) FindByIssuerAndSubject(ctx context.Context, issuer, subject, accountType string) (*entity.Identity, error) {
+	var identity entity.Identity
+
+	err := r.DB.QueryRowContext(ctx, `
+		SELECT id, issuer, subject, account_type, account_id, email, created_at
+		FROM identities
+		WHERE issuer = $1 AND subject = $2 AND account_type = $3`,
+		issuer, subject, accountType,
+	).Scan(
+		&identity.ID,
+		&identity.Issuer,
+		&identity.Subject,
+		&identity.AccountType,
+		&identity.AccountID,
+		&identity.Email,
+		&identity.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &identity, nil
+}
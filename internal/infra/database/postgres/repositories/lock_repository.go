@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+type PostgresLockRepository struct {
+	DB *sql.DB
+}
+
+func NewPostgresLockRepository(db *sql.DB) *PostgresLockRepository {
+	return &PostgresLockRepository{
+		DB: db,
+	}
+}
+
+// Acquire leans on the database clock, so replicas with skewed clocks still
+// agree on when a lease runs out.
+func (r *PostgresLockRepository) Acquire(ctx context.Context, name, holder string, ttl time.Duration) (bool, error) {
+	result, err := r.DB.ExecContext(ctx, `
+		INSERT INTO locks (name, holder, expires_at)
+		VALUES ($1, $2, NOW() + make_interval(secs => $3))
+		ON CONFLICT (name) DO UPDATE SET
+			holder = EXCLUDED.holder,
+			expires_at = EXCLUDED.expires_at
+		WHERE locks.holder = EXCLUDED.holder OR locks.expires_at <= NOW()`,
+		name, holder, ttl.Seconds(),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected == 1, nil
+}
+
+func (r *PostgresLockRepository) Release(ctx context.Context, name, holder string) error {
+	_, err := r.DB.ExecContext(ctx, `DELETE FROM locks WHERE name = $1 AND holder = $2`, name, holder)
+	return err
+}
@@ -0,0 +1,53 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/paulozy/costurai/internal/entity"
+)
+
+type PostgresOIDCLoginStateRepository struct {
+	DB *sql.DB
+}
+
+func NewPostgresOIDCLoginStateRepository(db *sql.DB) *PostgresOIDCLoginStateRepository {
+	return &PostgresOIDCLoginStateRepository{
+		DB: db,
+	}
+}
+
+func (r *PostgresOIDCLoginStateRepository) Create(ctx context.Context, state *entity.OIDCLoginState) error {
+	_, err := r.DB.ExecContext(ctx, `
+		INSERT INTO oidc_login_states (state_hash, account_type, code_verifier, nonce, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		state.StateHash,
+		state.AccountType,
+		state.CodeVerifier,
+		state.Nonce,
+		state.ExpiresAt,
+		state.CreatedAt,
+	)
+
+	return err
+}
+
+func (r *PostgresOIDCLoginStateRepository) Consume(ctx context.Context, stateHash string) (*entity.OIDCLoginState, error) {
+	var state entity.OIDCLoginState
+
+	err := r.DB.QueryRowContext(ctx, `
+		DELETE FROM oidc_login_states
+		WHERE state_hash = $1
+		RETURNING state_hash, account_type, code_verifier, nonce, expires_at, created_at`,
+		stateHash,
+	).Scan(&state.StateHash, &state.AccountType, &state.CodeVerifier, &state.Nonce, &state.ExpiresAt, &state.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &state, nil
+}
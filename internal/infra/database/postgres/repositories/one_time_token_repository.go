@@ -0,0 +1,109 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/paulozy/costurai/internal/entity"
+)
+
+const oneTimeTokenColumns = `id, purpose, subject, account_type, email, token_hash,
+	expires_at, used_at, created_at`
+
+type PostgresOneTimeTokenRepository struct {
+	DB *sql.DB
+}
+
+func NewPostgresOneTimeTokenRepository(db *sql.DB) *PostgresOneTimeTokenRepository {
+	return &PostgresOneTimeTokenRepository{
+		DB: db,
+	}
+}
+
+func (r *PostgresOneTimeTokenRepository) Create(ctx context.Context, token *entity.OneTimeToken) error {
+	_, err := r.DB.ExecContext(ctx, `
+		INSERT INTO one_time_tokens (`+oneTimeTokenColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		token.ID,
+		string(token.Purpose),
+		token.Subject,
+		token.AccountType,
+		token.Email,
+		token.TokenHash,
+		token.ExpiresAt,
+		token.UsedAt,
+		token.CreatedAt,
+	)
+
+	return err
+}
+
+func (r *PostgresOneTimeTokenRepository) FindByHash(ctx context.Context, purpose entity.OneTimeTokenPurpose, hash string) (*entity.OneTimeToken, error) {
+	row := r.DB.QueryRowContext(ctx, `
+		SELECT `+oneTimeTokenColumns+`
+		FROM one_time_tokens
+		WHERE purpose = $1 AND token_hash = $2`,
+		string(purpose), hash,
+	)
+
+	token, err := scanOneTimeToken(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return token, err
+}
+
+func (r *PostgresOneTimeTokenRepository) MarkUsed(ctx context.Context, id string) (bool, error) {
+	result, err := r.DB.ExecContext(ctx, `
+		UPDATE one_time_tokens SET used_at = NOW()
+		WHERE id = $1 AND used_at IS NULL`,
+		id,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected == 1, nil
+}
+
+func (r *PostgresOneTimeTokenRepository) DeleteBySubject(ctx context.Context, purpose entity.OneTimeTokenPurpose, subject string) error {
+	_, err := r.DB.ExecContext(ctx, `
+		DELETE FROM one_time_tokens
+		WHERE purpose = $1 AND subject = $2`,
+		string(purpose), subject,
+	)
+
+	return err
+}
+
+func scanOneTimeToken(row scanner) (*entity.OneTimeToken, error) {
+	var token entity.OneTimeToken
+	var usedAt sql.NullTime
+
+	err := row.Scan(
+		&token.ID,
+		&token.Purpose,
+		&token.Subject,
+		&token.AccountType,
+		&token.Email,
+		&token.TokenHash,
+		&token.ExpiresAt,
+		&usedAt,
+		&token.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if usedAt.Valid {
+		token.UsedAt = &usedAt.Time
+	}
+
+	return &token, nil
+}
@@ -0,0 +1,78 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/paulozy/costurai/internal/entity"
+)
+
+type PostgresOTPCodeRepository struct {
+	DB *sql.DB
+}
+
+func NewPostgresOTPCodeRepository(db *sql.DB) *PostgresOTPCodeRepository {
+	return &PostgresOTPCodeRepository{
+		DB: db,
+	}
+}
+
+func (r *PostgresOTPCodeRepository) Save(ctx context.Context, code *entity.OTPCode) error {
+	_, err := r.DB.ExecContext(ctx, `
+		INSERT INTO otp_codes (phone, code_hash, attempts, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (phone) DO UPDATE SET
+			code_hash = EXCLUDED.code_hash,
+			attempts = EXCLUDED.attempts,
+			expires_at = EXCLUDED.expires_at,
+			created_at = EXCLUDED.created_at`,
+		code.Phone,
+		code.CodeHash,
+		code.Attempts,
+		code.ExpiresAt,
+		code.CreatedAt,
+	)
+
+	return err
+}
+
+func (r *PostgresOTPCodeRepository) FindByPhone(ctx context.Context, phone string) (*entity.OTPCode, error) {
+	var code entity.OTPCode
+
+	err := r.DB.QueryRowContext(ctx, `
+		SELECT phone, code_hash, attempts, expires_at, created_at
+		FROM otp_codes
+		WHERE phone = $1`,
+		phone,
+	).Scan(&code.Phone, &code.CodeHash, &code.Attempts, &code.ExpiresAt, &code.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &code, nil
+}
+
+func (r *PostgresOTPCodeRepository) IncrementAttempts(ctx context.Context, phone string) (int, error) {
+	var attempts int
+
+	err := r.DB.QueryRowContext(ctx, `
+		UPDATE otp_codes SET attempts = attempts + 1
+		WHERE phone = $1
+		RETURNING attempts`,
+		phone,
+	).Scan(&attempts)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+
+	return attempts, err
+}
+
+func (r *PostgresOTPCodeRepository) Delete(ctx context.Context, phone string) error {
+	_, err := r.DB.ExecContext(ctx, `DELETE FROM otp_codes WHERE phone = $1`, phone)
+	return err
+}
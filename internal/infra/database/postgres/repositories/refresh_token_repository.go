@@ -0,0 +1,133 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/paulozy/costurai/internal/entity"
+)
+
+const refreshTokenColumns = `id, family_id, subject, issuer, role, token_hash,
+	expires_at, used_at, revoked_at, created_at`
+
+type PostgresRefreshTokenRepository struct {
+	DB *sql.DB
+}
+
+func NewPostgresRefreshTokenRepository(db *sql.DB) *PostgresRefreshTokenRepository {
+	return &PostgresRefreshTokenRepository{
+		DB: db,
+	}
+}
+
+func (r *PostgresRefreshTokenRepository) Create(ctx context.Context, token *entity.RefreshToken) error {
+	_, err := r.DB.ExecContext(ctx, `
+		INSERT INTO refresh_tokens (`+refreshTokenColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		token.ID,
+		token.FamilyID,
+		token.Subject,
+		token.Issuer,
+		string(token.Role),
+		token.TokenHash,
+		token.ExpiresAt,
+		token.UsedAt,
+		token.RevokedAt,
+		token.CreatedAt,
+	)
+
+	return err
+}
+
+func (r *PostgresRefreshTokenRepository) FindByHash(ctx context.Context, hash string) (*entity.RefreshToken, error) {
+	row := r.DB.QueryRowContext(ctx, `SELECT `+refreshTokenColumns+` FROM refresh_tokens WHERE token_hash = $1`, hash)
+
+	token, err := scanRefreshToken(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return token, err
+}
+
+func (r *PostgresRefreshTokenRepository) MarkUsed(ctx context.Context, id string) (bool, error) {
+	result, err := r.DB.ExecContext(ctx, `
+		UPDATE refresh_tokens SET used_at = NOW()
+		WHERE id = $1 AND used_at IS NULL`,
+		id,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected == 1, nil
+}
+
+func (r *PostgresRefreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	_, err := r.DB.ExecContext(ctx, `
+		UPDATE refresh_tokens SET revoked_at = NOW()
+		WHERE family_id = $1 AND revoked_at IS NULL`,
+		familyID,
+	)
+
+	return err
+}
+
+func (r *PostgresRefreshTokenRepository) RevokeSubject(ctx context.Context, subject string) error {
+	_, err := r.DB.ExecContext(ctx, `
+		UPDATE refresh_tokens SET revoked_at = NOW()
+		WHERE subject = $1 AND revoked_at IS NULL`,
+		subject,
+	)
+
+	return err
+}
+
+func (r *PostgresRefreshTokenRepository) IsFamilyRevoked(ctx context.Context, familyID string) (bool, error) {
+	var revoked bool
+	err := r.DB.QueryRowContext(ctx, `
+		SELECT EXISTS(
+			SELECT 1 FROM refresh_tokens
+			WHERE family_id = $1 AND revoked_at IS NOT NULL
+		)`,
+		familyID,
+	).Scan(&revoked)
+
+	return revoked, err
+}
+
+func scanRefreshToken(row scanner) (*entity.RefreshToken, error) {
+	var token entity.RefreshToken
+	var usedAt, revokedAt sql.NullTime
+
+	err := row.Scan(
+		&token.ID,
+		&token.FamilyID,
+		&token.Subject,
+		&token.Issuer,
+		&token.Role,
+		&token.TokenHash,
+		&token.ExpiresAt,
+		&usedAt,
+		&revokedAt,
+		&token.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if usedAt.Valid {
+		token.UsedAt = &usedAt.Time
+	}
+
+	if revokedAt.Valid {
+		token.RevokedAt = &revokedAt.Time
+	}
+
+	return &token, nil
+}
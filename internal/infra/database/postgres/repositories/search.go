@@ -0,0 +1,15 @@
+package repositories
+
+// pageBounds turns a page into LIMIT and OFFSET arguments, with a nil limit
+// returning every row.
+func pageBounds(limit, page int64) (any, int64) {
+	if limit <= 0 {
+		return nil, 0
+	}
+
+	if page <= 0 {
+		page = 1
+	}
+
+	return limit, (page - 1) * limit
+}
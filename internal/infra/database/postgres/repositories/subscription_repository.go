@@ -0,0 +1,259 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/paulozy/costurai/internal/entity"
+	"github.com/paulozy/costurai/internal/infra/database"
+)
+
+const subscriptionColumns = `id, dressmaker_id, plan, price, periodicity, status,
+	started_at, expires_at, canceled_at, grace_until, gateway_id, payment_url,
+	last_event_at, expiry_notice_at, created_at, updated_at`
+
+type PostgresSubscriptionRepository struct {
+	DB *sql.DB
+}
+
+func NewPostgresSubscriptionRepository(db *sql.DB) *PostgresSubscriptionRepository {
+	return &PostgresSubscriptionRepository{
+		DB: db,
+	}
+}
+
+func (r *PostgresSubscriptionRepository) Create(ctx context.Context, subscription *entity.Subscription) error {
+	plan, err := json.Marshal(subscription.Plan)
+	if err != nil {
+		return err
+	}
+
+	price, err := json.Marshal(subscription.Price)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.DB.ExecContext(ctx, `
+		INSERT INTO subscriptions (`+subscriptionColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)`,
+		subscription.ID,
+		subscription.DressmakerID,
+		plan,
+		price,
+		string(subscription.Periodicity.PeriodicityType),
+		string(subscription.Status),
+		subscription.StartedAt,
+		subscription.ExpiresAt,
+		subscription.CanceledAt,
+		subscription.GraceUntil,
+		subscription.GatewayId,
+		subscription.PaymentURL,
+		subscription.LastEventAt,
+		subscription.ExpiryNoticeAt,
+		subscription.CreatedAt,
+		subscription.UpdatedAt,
+	)
+
+	return err
+}
+
+func (r *PostgresSubscriptionRepository) FindByID(ctx context.Context, id string) (*entity.Subscription, error) {
+	row := r.DB.QueryRowContext(ctx, `SELECT `+subscriptionColumns+` FROM subscriptions WHERE id = $1`, id)
+
+	subscription, err := scanSubscription(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return subscription, err
+}
+
+func (r *PostgresSubscriptionRepository) FindByGatewayID(ctx context.Context, gatewayID string) (*entity.Subscription, error) {
+	row := r.DB.QueryRowContext(ctx, `SELECT `+subscriptionColumns+` FROM subscriptions WHERE gateway_id = $1`, gatewayID)
+
+	subscription, err := scanSubscription(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return subscription, err
+}
+
+func (r *PostgresSubscriptionRepository) Update(ctx context.Context, subscription *entity.Subscription) error {
+	result, err := r.update(ctx, subscription, "")
+	if err != nil {
+		return err
+	}
+
+	return expectAffected(result, "subscription", subscription.ID)
+}
+
+// UpdateIfUnchanged only writes the row while its updated_at is still the one
+// that was read, so a concurrent update isn't overwritten.
+func (r *PostgresSubscriptionRepository) UpdateIfUnchanged(ctx context.Context, subscription *entity.Subscription, readUpdatedAt time.Time) (bool, error) {
+	result, err := r.update(ctx, subscription, "AND updated_at = $15", readUpdatedAt)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected == 1, nil
+}
+
+// update writes every column of the subscription, matching it by ID and the
+// extra condition, whose parameters are numbered from $15.
+func (r *PostgresSubscriptionRepository) update(ctx context.Context, subscription *entity.Subscription, condition string, args ...any) (sql.Result, error) {
+	subscription.UpdatedAt = time.Now()
+
+	plan, err := json.Marshal(subscription.Plan)
+	if err != nil {
+		return nil, err
+	}
+
+	price, err := json.Marshal(subscription.Price)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.DB.ExecContext(ctx, `
+		UPDATE subscriptions SET
+			plan = $2,
+			price = $3,
+			periodicity = $4,
+			status = $5,
+			started_at = $6,
+			expires_at = $7,
+			canceled_at = $8,
+			grace_until = $9,
+			gateway_id = $10,
+			payment_url = $11,
+			last_event_at = $12,
+			expiry_notice_at = $13,
+			updated_at = $14
+		WHERE id = $1 `+condition,
+		append([]any{
+			subscription.ID,
+			plan,
+			price,
+			string(subscription.Periodicity.PeriodicityType),
+			string(subscription.Status),
+			subscription.StartedAt,
+			subscription.ExpiresAt,
+			subscription.CanceledAt,
+			subscription.GraceUntil,
+			subscription.GatewayId,
+			subscription.PaymentURL,
+			subscription.LastEventAt,
+			subscription.ExpiryNoticeAt,
+			subscription.UpdatedAt,
+		}, args...)...,
+	)
+}
+
+func (r *PostgresSubscriptionRepository) Search(ctx context.Context, params database.SubscriptionSearchParams) ([]entity.Subscription, int64, error) {
+	filter := `WHERE ($1 = '' OR dressmaker_id = $1) AND ($2 = '' OR status = $2)`
+
+	var total int64
+	err := r.DB.QueryRowContext(ctx, `SELECT COUNT(*) FROM subscriptions `+filter, params.DressmakerID, string(params.Status)).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	limit, offset := pageBounds(params.Limit, params.Page)
+
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT `+subscriptionColumns+`
+		FROM subscriptions `+filter+`
+		ORDER BY created_at DESC
+		LIMIT $3 OFFSET $4`,
+		params.DressmakerID, string(params.Status), limit, offset,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	subscriptions := []entity.Subscription{}
+	for rows.Next() {
+		subscription, err := scanSubscription(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		subscriptions = append(subscriptions, *subscription)
+	}
+
+	return subscriptions, total, rows.Err()
+}
+
+func (r *PostgresSubscriptionRepository) FindLapsing(ctx context.Context, before time.Time) ([]entity.Subscription, error) {
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT `+subscriptionColumns+`
+		FROM subscriptions
+		WHERE status IN ($1, $2) AND (expires_at < $3 OR grace_until < $3)`,
+		string(entity.StatusActive), string(entity.StatusCanceled), before,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	subscriptions := []entity.Subscription{}
+	for rows.Next() {
+		subscription, err := scanSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		subscriptions = append(subscriptions, *subscription)
+	}
+
+	return subscriptions, rows.Err()
+}
+
+func scanSubscription(row scanner) (*entity.Subscription, error) {
+	var subscription entity.Subscription
+	var plan, price []byte
+	var periodicity, status string
+
+	err := row.Scan(
+		&subscription.ID,
+		&subscription.DressmakerID,
+		&plan,
+		&price,
+		&periodicity,
+		&status,
+		&subscription.StartedAt,
+		&subscription.ExpiresAt,
+		&subscription.CanceledAt,
+		&subscription.GraceUntil,
+		&subscription.GatewayId,
+		&subscription.PaymentURL,
+		&subscription.LastEventAt,
+		&subscription.ExpiryNoticeAt,
+		&subscription.CreatedAt,
+		&subscription.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(plan, &subscription.Plan); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(price, &subscription.Price); err != nil {
+		return nil, err
+	}
+
+	subscription.Periodicity = entity.Periodicity{PeriodicityType: entity.PeriodicityType(periodicity)}
+	subscription.Status = entity.Status(status)
+
+	return &subscription, nil
+}
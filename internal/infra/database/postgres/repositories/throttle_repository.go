@@ -0,0 +1,97 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/paulozy/costurai/internal/entity"
+)
+
+const throttleColumns = `key, attempts, lockouts, last_attempt_at, blocked_until`
+
+type PostgresThrottleRepository struct {
+	DB *sql.DB
+}
+
+func NewPostgresThrottleRepository(db *sql.DB) *PostgresThrottleRepository {
+	return &PostgresThrottleRepository{
+		DB: db,
+	}
+}
+
+func (r *PostgresThrottleRepository) Find(ctx context.Context, key string) (*entity.Throttle, error) {
+	row := r.DB.QueryRowContext(ctx, `SELECT `+throttleColumns+` FROM throttles WHERE key = $1`, key)
+
+	throttle, err := scanThrottle(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return throttle, err
+}
+
+// RegisterAttempt locks the key's row so concurrent attempts are all
+// counted.
+func (r *PostgresThrottleRepository) RegisterAttempt(ctx context.Context, key string, policy entity.ThrottlePolicy) (*entity.Throttle, error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO throttles (key) VALUES ($1) ON CONFLICT (key) DO NOTHING`, key)
+	if err != nil {
+		return nil, err
+	}
+
+	row := tx.QueryRowContext(ctx, `SELECT `+throttleColumns+` FROM throttles WHERE key = $1 FOR UPDATE`, key)
+
+	throttle, err := scanThrottle(row)
+	if err != nil {
+		return nil, err
+	}
+
+	throttle.Register(policy, time.Now())
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE throttles SET
+			attempts = $2,
+			lockouts = $3,
+			last_attempt_at = $4,
+			blocked_until = $5
+		WHERE key = $1`,
+		throttle.Key,
+		throttle.Attempts,
+		throttle.Lockouts,
+		throttle.LastAttemptAt,
+		throttle.BlockedUntil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return throttle, tx.Commit()
+}
+
+func (r *PostgresThrottleRepository) Delete(ctx context.Context, key string) error {
+	_, err := r.DB.ExecContext(ctx, `DELETE FROM throttles WHERE key = $1`, key)
+	return err
+}
+
+func scanThrottle(row scanner) (*entity.Throttle, error) {
+	var throttle entity.Throttle
+
+	err := row.Scan(
+		&throttle.Key,
+		&throttle.Attempts,
+		&throttle.Lockouts,
+		&throttle.LastAttemptAt,
+		&throttle.BlockedUntil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &throttle, nil
+}
@@ -0,0 +1,203 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/paulozy/costurai/internal/entity"
+	"github.com/paulozy/costurai/internal/infra/database"
+)
+
+const userColumns = `id, email, password, name, enabled, admin, suspended, phone, phone_verified_at, email_verified_at, latitude, longitude, created_at, updated_at`
+
+type PostgresUserRepository struct {
+	DB *sql.DB
+}
+
+func NewPostgresUserRepository(db *sql.DB) *PostgresUserRepository {
+	return &PostgresUserRepository{
+		DB: db,
+	}
+}
+
+func (r *PostgresUserRepository) Create(ctx context.Context, user *entity.User) error {
+	createdAt, err := parseTimestamp(user.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	updatedAt, err := parseTimestamp(user.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.DB.ExecContext(ctx, `
+		INSERT INTO users (`+userColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`,
+		user.ID,
+		user.Email,
+		user.Password,
+		user.Name,
+		user.Enabled,
+		user.Admin,
+		user.Suspended,
+		user.Phone,
+		user.PhoneVerifiedAt,
+		user.EmailVerifiedAt,
+		user.Location.Latitude,
+		user.Location.Longitude,
+		createdAt,
+		updatedAt,
+	)
+
+	return err
+}
+
+func (r *PostgresUserRepository) FindByEmail(ctx context.Context, email string) (*entity.User, error) {
+	row := r.DB.QueryRowContext(ctx, `SELECT `+userColumns+` FROM users WHERE email = $1`, email)
+
+	user, err := scanUser(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return user, err
+}
+
+func (r *PostgresUserRepository) Exists(ctx context.Context, email string) (bool, error) {
+	var exists bool
+	err := r.DB.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM users WHERE email = $1)`, email).Scan(&exists)
+
+	return exists, err
+}
+
+func (r *PostgresUserRepository) FindByID(ctx context.Context, id string) (*entity.User, error) {
+	row := r.DB.QueryRowContext(ctx, `SELECT `+userColumns+` FROM users WHERE id = $1`, id)
+
+	user, err := scanUser(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return user, err
+}
+
+func (r *PostgresUserRepository) Update(ctx context.Context, user *entity.User) error {
+	updatedAt, err := parseTimestamp(user.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.DB.ExecContext(ctx, `
+		UPDATE users SET
+			name = $2,
+			enabled = $3,
+			admin = $4,
+			latitude = $5,
+			longitude = $6,
+			updated_at = $7,
+			password = $8,
+			phone = $9,
+			phone_verified_at = $10,
+			suspended = $11,
+			email = $12,
+			email_verified_at = $13
+		WHERE id = $1`,
+		user.ID,
+		user.Name,
+		user.Enabled,
+		user.Admin,
+		user.Location.Latitude,
+		user.Location.Longitude,
+		updatedAt,
+		user.Password,
+		user.Phone,
+		user.PhoneVerifiedAt,
+		user.Suspended,
+		user.Email,
+		user.EmailVerifiedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	return expectAffected(result, "user", user.ID)
+}
+
+func (r *PostgresUserRepository) Search(ctx context.Context, params database.AccountSearchParams) ([]entity.User, int64, error) {
+	filter := `WHERE starts_with(lower(email), lower($1)) AND ($2::boolean IS NULL OR enabled = $2)`
+
+	var total int64
+	err := r.DB.QueryRowContext(ctx, `SELECT COUNT(*) FROM users `+filter, params.Query, params.Enabled).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	limit, offset := pageBounds(params.Limit, params.Page)
+
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT `+userColumns+`
+		FROM users `+filter+`
+		ORDER BY email
+		LIMIT $3 OFFSET $4`,
+		params.Query, params.Enabled, limit, offset,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	users := []entity.User{}
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		users = append(users, *user)
+	}
+
+	return users, total, rows.Err()
+}
+
+func scanUser(row scanner) (*entity.User, error) {
+	var user entity.User
+	var createdAt, updatedAt time.Time
+
+	err := row.Scan(
+		&user.ID,
+		&user.Email,
+		&user.Password,
+		&user.Name,
+		&user.Enabled,
+		&user.Admin,
+		&user.Suspended,
+		&user.Phone,
+		&user.PhoneVerifiedAt,
+		&user.EmailVerifiedAt,
+		&user.Location.Latitude,
+		&user.Location.Longitude,
+		&createdAt,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	user.EmailVerified = user.EmailVerifiedAt != nil
+	user.CreatedAt = createdAt.Format(time.RFC3339)
+	user.UpdatedAt = updatedAt.Format(time.RFC3339)
+
+	return &user, nil
+}
+
+// parseTimestamp converts the RFC3339 strings used by User and Review into
+// a time.Time, falling back to now for records created without one.
+func parseTimestamp(value string) (time.Time, error) {
+	if value == "" {
+		return time.Now(), nil
+	}
+
+	return time.Parse(time.RFC3339, value)
+}
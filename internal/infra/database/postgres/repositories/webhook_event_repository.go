@@ -0,0 +1,175 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/paulozy/costurai/internal/entity"
+	"github.com/paulozy/costurai/internal/infra/database"
+)
+
+const webhookEventColumns = `id, gateway, type, payload, status, error, attempts,
+	occurred_at, received_at, processed_at`
+
+type PostgresWebhookEventRepository struct {
+	DB *sql.DB
+}
+
+func NewPostgresWebhookEventRepository(db *sql.DB) *PostgresWebhookEventRepository {
+	return &PostgresWebhookEventRepository{
+		DB: db,
+	}
+}
+
+func (r *PostgresWebhookEventRepository) Create(ctx context.Context, event *entity.WebhookEvent) (bool, error) {
+	result, err := r.DB.ExecContext(ctx, `
+		INSERT INTO webhook_events (`+webhookEventColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (id) DO NOTHING`,
+		event.ID,
+		event.Gateway,
+		event.Type,
+		event.Payload,
+		string(event.Status),
+		event.Error,
+		event.Attempts,
+		event.OccurredAt,
+		event.ReceivedAt,
+		event.ProcessedAt,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected == 1, nil
+}
+
+func (r *PostgresWebhookEventRepository) FindByID(ctx context.Context, id string) (*entity.WebhookEvent, error) {
+	row := r.DB.QueryRowContext(ctx, `SELECT `+webhookEventColumns+` FROM webhook_events WHERE id = $1`, id)
+
+	event, err := scanWebhookEvent(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return event, err
+}
+
+// Claim checks and marks the event in a single statement, so concurrent
+// deliveries can't both claim it.
+func (r *PostgresWebhookEventRepository) Claim(ctx context.Context, id string, staleBefore time.Time, settled bool) (bool, error) {
+	result, err := r.DB.ExecContext(ctx, `
+		UPDATE webhook_events SET
+			status = $2,
+			received_at = NOW()
+		WHERE id = $1
+			AND (status = $3
+				OR (status = $2 AND received_at < $4)
+				OR ($5 AND status IN ($6, $7)))`,
+		id,
+		string(entity.WebhookEventReceived),
+		string(entity.WebhookEventFailed),
+		staleBefore,
+		settled,
+		string(entity.WebhookEventProcessed),
+		string(entity.WebhookEventIgnored),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected == 1, nil
+}
+
+func (r *PostgresWebhookEventRepository) Update(ctx context.Context, event *entity.WebhookEvent) error {
+	result, err := r.DB.ExecContext(ctx, `
+		UPDATE webhook_events SET
+			status = $2,
+			error = $3,
+			attempts = $4,
+			processed_at = $5
+		WHERE id = $1`,
+		event.ID,
+		string(event.Status),
+		event.Error,
+		event.Attempts,
+		event.ProcessedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	return expectAffected(result, "webhook event", event.ID)
+}
+
+func (r *PostgresWebhookEventRepository) Search(ctx context.Context, params database.WebhookEventSearchParams) ([]entity.WebhookEvent, int64, error) {
+	filter := `WHERE ($1 = '' OR type = $1) AND ($2 = '' OR status = $2)`
+
+	var total int64
+	err := r.DB.QueryRowContext(ctx, `SELECT COUNT(*) FROM webhook_events `+filter, params.Type, string(params.Status)).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	limit, offset := pageBounds(params.Limit, params.Page)
+
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT `+webhookEventColumns+`
+		FROM webhook_events `+filter+`
+		ORDER BY occurred_at DESC
+		LIMIT $3 OFFSET $4`,
+		params.Type, string(params.Status), limit, offset,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	events := []entity.WebhookEvent{}
+	for rows.Next() {
+		event, err := scanWebhookEvent(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		events = append(events, *event)
+	}
+
+	return events, total, rows.Err()
+}
+
+func scanWebhookEvent(row scanner) (*entity.WebhookEvent, error) {
+	var event entity.WebhookEvent
+	var status string
+
+	err := row.Scan(
+		&event.ID,
+		&event.Gateway,
+		&event.Type,
+		&event.Payload,
+		&status,
+		&event.Error,
+		&event.Attempts,
+		&event.OccurredAt,
+		&event.ReceivedAt,
+		&event.ProcessedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	event.Status = entity.WebhookEventStatus(status)
+
+	return &event, nil
+}
@@ -0,0 +1,89 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/paulozy/costurai/internal/infra/database"
+)
+
+// Job is work run periodically in the background.
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// Scheduler runs jobs in process. Before each run it takes the job's lock
+// for the whole interval, so replicas sharing the database run a job at most
+// once per interval. The replica that ran it last keeps running it while it
+// is up.
+type Scheduler struct {
+	Locks  database.LockRepositoryInterface
+	Holder string
+	Jobs   []Job
+}
+
+func NewScheduler(locks database.LockRepositoryInterface) *Scheduler {
+	hostname, _ := os.Hostname()
+
+	return &Scheduler{
+		Locks:  locks,
+		Holder: hostname + "-" + uuid.New().String(),
+	}
+}
+
+func (s *Scheduler) Add(job Job) {
+	s.Jobs = append(s.Jobs, job)
+}
+
+// Start runs every job right away and then on its interval, until the
+// context is canceled.
+func (s *Scheduler) Start(ctx context.Context) {
+	for _, job := range s.Jobs {
+		go s.loop(ctx, job)
+	}
+}
+
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		s.run(ctx, job)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Scheduler) run(ctx context.Context, job Job) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("job %s panicked: %v", job.Name, r)
+		}
+	}()
+
+	acquired, err := s.Locks.Acquire(ctx, "job:"+job.Name, s.Holder, job.Interval)
+	if err != nil {
+		log.Printf("job %s: could not take the lock: %v", job.Name, err)
+		return
+	}
+
+	if !acquired {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, job.Interval)
+	defer cancel()
+
+	if err := job.Run(ctx); err != nil {
+		log.Printf("job %s failed: %v", job.Name, err)
+	}
+}
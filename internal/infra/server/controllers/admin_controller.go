@@ -0,0 +1,216 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	usecases "github.com/paulozy/costurai/internal/usecase/admin"
+)
+
+type AdminController struct {
+	listUsersUseCase           *usecases.ListUsersUseCase
+	listDressmakersUseCase     *usecases.ListDressmakersUseCase
+	setUserStatusUseCase       *usecases.SetUserStatusUseCase
+	setDressmakerStatusUseCase *usecases.SetDressmakerStatusUseCase
+	listSubscriptionsUseCase   *usecases.ListSubscriptionsUseCase
+	showSubscriptionUseCase    *usecases.ShowSubscriptionUseCase
+	adjustSubscriptionUseCase  *usecases.AdjustSubscriptionUseCase
+	listAuditLogUseCase        *usecases.ListAuditLogUseCase
+}
+
+type AdminUseCasesInput struct {
+	ListUsersUseCase           *usecases.ListUsersUseCase
+	ListDressmakersUseCase     *usecases.ListDressmakersUseCase
+	SetUserStatusUseCase       *usecases.SetUserStatusUseCase
+	SetDressmakerStatusUseCase *usecases.SetDressmakerStatusUseCase
+	ListSubscriptionsUseCase   *usecases.ListSubscriptionsUseCase
+	ShowSubscriptionUseCase    *usecases.ShowSubscriptionUseCase
+	AdjustSubscriptionUseCase  *usecases.AdjustSubscriptionUseCase
+	ListAuditLogUseCase        *usecases.ListAuditLogUseCase
+}
+
+func NewAdminController(usecases AdminUseCasesInput) *AdminController {
+	return &AdminController{
+		listUsersUseCase:           usecases.ListUsersUseCase,
+		listDressmakersUseCase:     usecases.ListDressmakersUseCase,
+		setUserStatusUseCase:       usecases.SetUserStatusUseCase,
+		setDressmakerStatusUseCase: usecases.SetDressmakerStatusUseCase,
+		listSubscriptionsUseCase:   usecases.ListSubscriptionsUseCase,
+		showSubscriptionUseCase:    usecases.ShowSubscriptionUseCase,
+		adjustSubscriptionUseCase:  usecases.AdjustSubscriptionUseCase,
+		listAuditLogUseCase:        usecases.ListAuditLogUseCase,
+	}
+}
+
+func (ac *AdminController) GetUsers(c *gin.Context) {
+	var input usecases.ListAccountsInput
+	if err := c.ShouldBindQuery(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	input.Limit, input.Page = pageDefaults(input.Limit, input.Page)
+
+	users, err := ac.listUsersUseCase.Execute(c.Request.Context(), input)
+	if err.Message != "" {
+		c.JSON(err.Status, gin.H{"error": err.Message, "reason": err.Error})
+		return
+	}
+
+	c.JSON(200, gin.H{"items": users.Items, "pagination": users.PaginationInfo})
+}
+
+func (ac *AdminController) GetDressmakers(c *gin.Context) {
+	var input usecases.ListAccountsInput
+	if err := c.ShouldBindQuery(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	input.Limit, input.Page = pageDefaults(input.Limit, input.Page)
+
+	dressmakers, err := ac.listDressmakersUseCase.Execute(c.Request.Context(), input)
+	if err.Message != "" {
+		c.JSON(err.Status, gin.H{"error": err.Message, "reason": err.Error})
+		return
+	}
+
+	c.JSON(200, gin.H{"items": dressmakers.Items, "pagination": dressmakers.PaginationInfo})
+}
+
+func (ac *AdminController) EnableUser(c *gin.Context) {
+	ac.setUserStatus(c, true)
+}
+
+func (ac *AdminController) DisableUser(c *gin.Context) {
+	ac.setUserStatus(c, false)
+}
+
+func (ac *AdminController) setUserStatus(c *gin.Context, enabled bool) {
+	input, ok := bindAccountStatus(c, enabled)
+	if !ok {
+		return
+	}
+
+	user, err := ac.setUserStatusUseCase.Execute(c.Request.Context(), input)
+	if err.Message != "" {
+		c.JSON(err.Status, gin.H{"error": err.Message, "reason": err.Error})
+		return
+	}
+
+	c.JSON(200, gin.H{"data": user})
+}
+
+func (ac *AdminController) EnableDressmaker(c *gin.Context) {
+	ac.setDressmakerStatus(c, true)
+}
+
+func (ac *AdminController) DisableDressmaker(c *gin.Context) {
+	ac.setDressmakerStatus(c, false)
+}
+
+func (ac *AdminController) setDressmakerStatus(c *gin.Context, enabled bool) {
+	input, ok := bindAccountStatus(c, enabled)
+	if !ok {
+		return
+	}
+
+	dressmaker, err := ac.setDressmakerStatusUseCase.Execute(c.Request.Context(), input)
+	if err.Message != "" {
+		c.JSON(err.Status, gin.H{"error": err.Message, "reason": err.Error})
+		return
+	}
+
+	c.JSON(200, gin.H{"data": dressmaker})
+}
+
+func bindAccountStatus(c *gin.Context, enabled bool) (usecases.SetAccountStatusInput, bool) {
+	var input usecases.SetAccountStatusInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return input, false
+	}
+
+	input.ActorID = c.GetString("user")
+	input.ID = c.Param("id")
+	input.Enabled = enabled
+
+	return input, true
+}
+
+func (ac *AdminController) GetSubscriptions(c *gin.Context) {
+	var input usecases.ListSubscriptionsInput
+	if err := c.ShouldBindQuery(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	input.Limit, input.Page = pageDefaults(input.Limit, input.Page)
+
+	subscriptions, err := ac.listSubscriptionsUseCase.Execute(c.Request.Context(), input)
+	if err.Message != "" {
+		c.JSON(err.Status, gin.H{"error": err.Message, "reason": err.Error})
+		return
+	}
+
+	c.JSON(200, gin.H{"items": subscriptions.Items, "pagination": subscriptions.PaginationInfo})
+}
+
+func (ac *AdminController) GetSubscription(c *gin.Context) {
+	details, err := ac.showSubscriptionUseCase.Execute(c.Request.Context(), c.Param("id"))
+	if err.Message != "" {
+		c.JSON(err.Status, gin.H{"error": err.Message, "reason": err.Error})
+		return
+	}
+
+	c.JSON(200, gin.H{"data": details})
+}
+
+func (ac *AdminController) AdjustSubscription(c *gin.Context) {
+	var input usecases.AdjustSubscriptionInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	input.ActorID = c.GetString("user")
+	input.ID = c.Param("id")
+
+	subscription, err := ac.adjustSubscriptionUseCase.Execute(c.Request.Context(), input)
+	if err.Message != "" {
+		c.JSON(err.Status, gin.H{"error": err.Message, "reason": err.Error})
+		return
+	}
+
+	c.JSON(200, gin.H{"data": subscription})
+}
+
+func (ac *AdminController) GetAuditLog(c *gin.Context) {
+	var input usecases.ListAuditLogInput
+	if err := c.ShouldBindQuery(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	input.Limit, input.Page = pageDefaults(input.Limit, input.Page)
+
+	entries, err := ac.listAuditLogUseCase.Execute(c.Request.Context(), input)
+	if err.Message != "" {
+		c.JSON(err.Status, gin.H{"error": err.Message, "reason": err.Error})
+		return
+	}
+
+	c.JSON(200, gin.H{"items": entries.Items, "pagination": entries.PaginationInfo})
+}
+
+func pageDefaults(limit, page int64) (int64, int64) {
+	if limit <= 0 {
+		limit = 10
+	}
+
+	if page <= 0 {
+		page = 1
+	}
+
+	return limit, page
+}
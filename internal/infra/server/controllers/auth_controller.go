@@ -1,15 +1,20 @@
 package controllers
 
 import (
+	"strconv"
+
 	"github.com/gin-gonic/gin"
 	"github.com/paulozy/costurai/internal/infra/database"
 	usecases "github.com/paulozy/costurai/internal/usecase/auth"
 	"github.com/paulozy/costurai/internal/usecase/auth/dtos"
+	"github.com/paulozy/costurai/pkg"
 )
 
 type AuthController struct {
 	authDressmakerUseCase *usecases.AuthDressmakerUseCase
 	authUserUseCase       *usecases.AuthUserUseCase
+	refreshTokenUseCase   *usecases.RefreshTokenUseCase
+	logoutUseCase         *usecases.LogoutUseCase
 	sendOTPUseCase        *usecases.SendOTPUseCase
 	VerifyOTPUseCase      *usecases.VerifyOTPUseCase
 	dressmakerRepository  database.DressmakerRepositoryInterface
@@ -19,6 +24,8 @@ type AuthController struct {
 func NewAuthController(
 	authDressmakerUseCase *usecases.AuthDressmakerUseCase,
 	authUserUseCase *usecases.AuthUserUseCase,
+	refreshTokenUseCase *usecases.RefreshTokenUseCase,
+	logoutUseCase *usecases.LogoutUseCase,
 	sendOTPUseCase *usecases.SendOTPUseCase,
 	verifyOTPUseCase *usecases.VerifyOTPUseCase,
 	dr database.DressmakerRepositoryInterface,
@@ -27,6 +34,8 @@ func NewAuthController(
 	return &AuthController{
 		authDressmakerUseCase: authDressmakerUseCase,
 		authUserUseCase:       authUserUseCase,
+		refreshTokenUseCase:   refreshTokenUseCase,
+		logoutUseCase:         logoutUseCase,
 		sendOTPUseCase:        sendOTPUseCase,
 		VerifyOTPUseCase:      verifyOTPUseCase,
 		dressmakerRepository:  dr,
@@ -41,8 +50,11 @@ func (ac *AuthController) AuthenticateDressmaker(c *gin.Context) {
 		return
 	}
 
-	token, err := ac.authDressmakerUseCase.Execute(input)
+	input.IP = c.ClientIP()
+
+	token, err := ac.authDressmakerUseCase.Execute(c.Request.Context(), input)
 	if err.Message != "" {
+		setRetryAfter(c, err)
 		c.JSON(err.Status, gin.H{"error": err.Message, "reason": err.Error})
 		return
 	}
@@ -57,13 +69,48 @@ func (ac *AuthController) AuthenticateUser(c *gin.Context) {
 		return
 	}
 
-	token, err := ac.authDressmakerUseCase.Execute(input)
+	input.IP = c.ClientIP()
+
+	token, err := ac.authUserUseCase.Execute(c.Request.Context(), input)
 	if err.Message != "" {
-		c.JSON(err.Status, gin.H{"error": err.Message})
+		setRetryAfter(c, err)
+		c.JSON(err.Status, gin.H{"error": err.Message, "reason": err.Error})
 		return
 	}
 
-	c.JSON(200, gin.H{"token": token})
+	c.JSON(200, gin.H{"data": token})
+}
+
+func (ac *AuthController) RefreshToken(c *gin.Context) {
+	var input dtos.RefreshTokenInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	session, err := ac.refreshTokenUseCase.Execute(c.Request.Context(), input)
+	if err.Message != "" {
+		c.JSON(err.Status, gin.H{"error": err.Message, "reason": err.Error})
+		return
+	}
+
+	c.JSON(200, gin.H{"data": session})
+}
+
+func (ac *AuthController) Logout(c *gin.Context) {
+	var input dtos.RefreshTokenInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	err := ac.logoutUseCase.Execute(c.Request.Context(), input)
+	if err.Message != "" {
+		c.JSON(err.Status, gin.H{"error": err.Message, "reason": err.Error})
+		return
+	}
+
+	c.Status(204)
 }
 
 func (ac *AuthController) SendOTP(c *gin.Context) {
@@ -73,8 +120,9 @@ func (ac *AuthController) SendOTP(c *gin.Context) {
 		return
 	}
 
-	err := ac.sendOTPUseCase.Execute(input)
+	err := ac.sendOTPUseCase.Execute(c.Request.Context(), input)
 	if err.Message != "" {
+		setRetryAfter(c, err)
 		c.JSON(err.Status, gin.H{"error": err.Message})
 		return
 	}
@@ -102,7 +150,7 @@ func (ac *AuthController) VerifyOTP(c *gin.Context) {
 		input.Enabling = ""
 	}
 
-	err := ac.VerifyOTPUseCase.Execute(input)
+	err := ac.VerifyOTPUseCase.Execute(c.Request.Context(), input)
 	if err.Message != "" {
 		c.JSON(err.Status, gin.H{"error": err.Message})
 		return
@@ -110,3 +158,10 @@ func (ac *AuthController) VerifyOTP(c *gin.Context) {
 
 	c.JSON(200, gin.H{"data": "OTP verified successfully"})
 }
+
+// setRetryAfter tells throttled clients how many seconds to wait.
+func setRetryAfter(c *gin.Context, err pkg.Error) {
+	if err.RetryAfter > 0 {
+		c.Header("Retry-After", strconv.Itoa(err.RetryAfter))
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/paulozy/costurai/internal/entity"
 	"github.com/paulozy/costurai/internal/infra/database"
+	authUseCases "github.com/paulozy/costurai/internal/usecase/auth"
 	usecases "github.com/paulozy/costurai/internal/usecase/dressmaker"
 )
 
@@ -17,6 +18,13 @@ type DressmakerController struct {
 	updateDressmakerUseCase          *usecases.UpdateDressMakerUseCase
 	getDressmakersByProximityUseCase *usecases.GetDressmakersByProximityUseCase
 	showDressmakerUseCase            *usecases.ShowDressMakerUseCase
+	addDressmakerReviewUseCase       *usecases.AddDressmakerReviewUseCase
+	updateDressmakerReviewUseCase    *usecases.UpdateDressmakerReviewUseCase
+	deleteDressmakerReviewUseCase    *usecases.DeleteDressmakerReviewUseCase
+	listDressmakerReviewsUseCase     *usecases.ListDressmakerReviewsUseCase
+	replyDressmakerReviewUseCase     *usecases.ReplyDressmakerReviewUseCase
+	reportDressmakerReviewUseCase    *usecases.ReportDressmakerReviewUseCase
+	requestEmailVerificationUseCase  *authUseCases.RequestEmailVerificationUseCase
 }
 
 type DressmakerUseCasesInput struct {
@@ -24,6 +32,13 @@ type DressmakerUseCasesInput struct {
 	UpdateDressmakerUseCase          *usecases.UpdateDressMakerUseCase
 	GetDressmakersByProximityUseCase *usecases.GetDressmakersByProximityUseCase
 	ShowDressmakerUseCase            *usecases.ShowDressMakerUseCase
+	AddDressmakerReviewUseCase       *usecases.AddDressmakerReviewUseCase
+	UpdateDressmakerReviewUseCase    *usecases.UpdateDressmakerReviewUseCase
+	DeleteDressmakerReviewUseCase    *usecases.DeleteDressmakerReviewUseCase
+	ListDressmakerReviewsUseCase     *usecases.ListDressmakerReviewsUseCase
+	ReplyDressmakerReviewUseCase     *usecases.ReplyDressmakerReviewUseCase
+	ReportDressmakerReviewUseCase    *usecases.ReportDressmakerReviewUseCase
+	RequestEmailVerificationUseCase  *authUseCases.RequestEmailVerificationUseCase
 }
 
 func NewDressmakerController(dmRepo database.DressmakerRepositoryInterface, dmrRepo database.DressmakerReviewsRepositoryInterface, usecases DressmakerUseCasesInput) *DressmakerController {
@@ -34,6 +49,13 @@ func NewDressmakerController(dmRepo database.DressmakerRepositoryInterface, dmrR
 		updateDressmakerUseCase:          usecases.UpdateDressmakerUseCase,
 		getDressmakersByProximityUseCase: usecases.GetDressmakersByProximityUseCase,
 		showDressmakerUseCase:            usecases.ShowDressmakerUseCase,
+		addDressmakerReviewUseCase:       usecases.AddDressmakerReviewUseCase,
+		updateDressmakerReviewUseCase:    usecases.UpdateDressmakerReviewUseCase,
+		deleteDressmakerReviewUseCase:    usecases.DeleteDressmakerReviewUseCase,
+		listDressmakerReviewsUseCase:     usecases.ListDressmakerReviewsUseCase,
+		replyDressmakerReviewUseCase:     usecases.ReplyDressmakerReviewUseCase,
+		reportDressmakerReviewUseCase:    usecases.ReportDressmakerReviewUseCase,
+		requestEmailVerificationUseCase:  usecases.RequestEmailVerificationUseCase,
 	}
 }
 
@@ -44,12 +66,14 @@ func (dc *DressmakerController) CreateDressmaker(c *gin.Context) {
 		return
 	}
 
-	dressmaker, err := dc.createDressmakerUseCase.Execute(input)
+	dressmaker, err := dc.createDressmakerUseCase.Execute(c.Request.Context(), input)
 	if err.Message != "" {
 		c.JSON(err.Status, gin.H{"error": err.Message, "reason": err.Error})
 		return
 	}
 
+	sendEmailVerification(c, dc.requestEmailVerificationUseCase, authUseCases.AccountTypeDressmaker, dressmaker.ID)
+
 	c.JSON(201, gin.H{"data": dressmaker})
 }
 
@@ -58,7 +82,7 @@ func (dc *DressmakerController) UpdateDressmaker(c *gin.Context) {
 	LoggedUser := c.GetString("user")
 
 	if ID != LoggedUser {
-		c.JSON(401, gin.H{"error": "Unauthorized"})
+		c.JSON(403, gin.H{"error": "Forbidden"})
 		return
 	}
 
@@ -71,7 +95,7 @@ func (dc *DressmakerController) UpdateDressmaker(c *gin.Context) {
 
 	input.ID = ID
 
-	dressmaker, err := dc.updateDressmakerUseCase.Execute(input)
+	dressmaker, err := dc.updateDressmakerUseCase.Execute(c.Request.Context(), input)
 	if err.Message != "" {
 		log.Println(err)
 		c.JSON(err.Status, gin.H{"error": err.Message})
@@ -97,7 +121,7 @@ func (dc *DressmakerController) GetDressmakers(c *gin.Context) {
 		input.Page = 1
 	}
 
-	dressmakers, ucError := dc.getDressmakersByProximityUseCase.Execute(input)
+	dressmakers, ucError := dc.getDressmakersByProximityUseCase.Execute(c.Request.Context(), input)
 	if ucError.Message != "" {
 		c.JSON(ucError.Status, gin.H{"error": ucError.Message, "reason": ucError.Error})
 		return
@@ -112,7 +136,7 @@ func (dc *DressmakerController) GetDressmaker(c *gin.Context) {
 	ID := c.Param("id")
 	input.ID = ID
 
-	dressmaker, ucError := dc.showDressmakerUseCase.Execute(input)
+	dressmaker, ucError := dc.showDressmakerUseCase.Execute(c.Request.Context(), input)
 
 	if ucError.Message != "" {
 		c.JSON(ucError.Status, gin.H{"error": ucError.Message, "reason": ucError.Error})
@@ -120,3 +144,123 @@ func (dc *DressmakerController) GetDressmaker(c *gin.Context) {
 	}
 	c.JSON(200, gin.H{"data": dressmaker})
 }
+
+func (dc *DressmakerController) AddReview(c *gin.Context) {
+	var input usecases.AddDressmakerReviewUseCaseInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	input.DressmakerID = c.Param("id")
+	input.UserID = c.GetString("user")
+
+	review, err := dc.addDressmakerReviewUseCase.Execute(c.Request.Context(), input)
+	if err.Message != "" {
+		c.JSON(err.Status, gin.H{"error": err.Message, "reason": err.Error})
+		return
+	}
+
+	c.JSON(201, gin.H{"data": review})
+}
+
+func (dc *DressmakerController) UpdateReview(c *gin.Context) {
+	var input usecases.UpdateDressmakerReviewUseCaseInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	input.DressmakerID = c.Param("id")
+	input.UserID = c.GetString("user")
+
+	review, err := dc.updateDressmakerReviewUseCase.Execute(c.Request.Context(), input)
+	if err.Message != "" {
+		c.JSON(err.Status, gin.H{"error": err.Message, "reason": err.Error})
+		return
+	}
+
+	c.JSON(200, gin.H{"data": review})
+}
+
+func (dc *DressmakerController) DeleteReview(c *gin.Context) {
+	input := usecases.DeleteDressmakerReviewUseCaseInput{
+		DressmakerID: c.Param("id"),
+		UserID:       c.GetString("user"),
+	}
+
+	err := dc.deleteDressmakerReviewUseCase.Execute(c.Request.Context(), input)
+	if err.Message != "" {
+		c.JSON(err.Status, gin.H{"error": err.Message, "reason": err.Error})
+		return
+	}
+
+	c.Status(204)
+}
+
+func (dc *DressmakerController) GetReviews(c *gin.Context) {
+	var input usecases.ListDressmakerReviewsInput
+
+	if err := c.ShouldBindQuery(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	input.DressmakerID = c.Param("id")
+
+	if input.Limit == 0 {
+		input.Limit = 10
+	}
+
+	if input.Page == 0 {
+		input.Page = 1
+	}
+
+	reviews, ucError := dc.listDressmakerReviewsUseCase.Execute(c.Request.Context(), input)
+	if ucError.Message != "" {
+		c.JSON(ucError.Status, gin.H{"error": ucError.Message, "reason": ucError.Error})
+		return
+	}
+
+	c.JSON(200, gin.H{"items": reviews.Items, "pagination": reviews.PaginationInfo, "summary": reviews.Summary})
+}
+
+func (dc *DressmakerController) ReplyReview(c *gin.Context) {
+	var input usecases.ReplyDressmakerReviewUseCaseInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	input.DressmakerID = c.Param("id")
+	input.ReviewID = c.Param("reviewId")
+	input.LoggedID = c.GetString("user")
+
+	review, err := dc.replyDressmakerReviewUseCase.Execute(c.Request.Context(), input)
+	if err.Message != "" {
+		c.JSON(err.Status, gin.H{"error": err.Message, "reason": err.Error})
+		return
+	}
+
+	c.JSON(200, gin.H{"data": review})
+}
+
+func (dc *DressmakerController) ReportReview(c *gin.Context) {
+	var input usecases.ReportDressmakerReviewUseCaseInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	input.DressmakerID = c.Param("id")
+	input.ReviewID = c.Param("reviewId")
+	input.ReporterID = c.GetString("user")
+
+	err := dc.reportDressmakerReviewUseCase.Execute(c.Request.Context(), input)
+	if err.Message != "" {
+		c.JSON(err.Status, gin.H{"error": err.Message, "reason": err.Error})
+		return
+	}
+
+	c.Status(204)
+}
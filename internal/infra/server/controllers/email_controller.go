@@ -0,0 +1,120 @@
+package controllers
+
+import (
+	"log"
+
+	"github.com/gin-gonic/gin"
+	usecases "github.com/paulozy/costurai/internal/usecase/auth"
+	"github.com/paulozy/costurai/internal/usecase/auth/dtos"
+	"github.com/paulozy/costurai/pkg"
+)
+
+type EmailController struct {
+	requestEmailVerificationUseCase *usecases.RequestEmailVerificationUseCase
+	verifyEmailUseCase              *usecases.VerifyEmailUseCase
+	requestEmailChangeUseCase       *usecases.RequestEmailChangeUseCase
+	confirmEmailChangeUseCase       *usecases.ConfirmEmailChangeUseCase
+}
+
+type EmailUseCasesInput struct {
+	RequestEmailVerificationUseCase *usecases.RequestEmailVerificationUseCase
+	VerifyEmailUseCase              *usecases.VerifyEmailUseCase
+	RequestEmailChangeUseCase       *usecases.RequestEmailChangeUseCase
+	ConfirmEmailChangeUseCase       *usecases.ConfirmEmailChangeUseCase
+}
+
+func NewEmailController(usecases EmailUseCasesInput) *EmailController {
+	return &EmailController{
+		requestEmailVerificationUseCase: usecases.RequestEmailVerificationUseCase,
+		verifyEmailUseCase:              usecases.VerifyEmailUseCase,
+		requestEmailChangeUseCase:       usecases.RequestEmailChangeUseCase,
+		confirmEmailChangeUseCase:       usecases.ConfirmEmailChangeUseCase,
+	}
+}
+
+func (ec *EmailController) RequestVerification(c *gin.Context) {
+	err := ec.requestEmailVerificationUseCase.Execute(c.Request.Context(), emailAccount(c))
+	if err.Message != "" {
+		c.JSON(err.Status, gin.H{"error": err.Message, "reason": err.Error})
+		return
+	}
+
+	c.JSON(202, gin.H{"data": "A verification link was sent to your email"})
+}
+
+func (ec *EmailController) Verify(c *gin.Context) {
+	var input dtos.EmailTokenInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	err := ec.verifyEmailUseCase.Execute(c.Request.Context(), input)
+	if err.Message != "" {
+		c.JSON(err.Status, gin.H{"error": err.Message, "reason": err.Error})
+		return
+	}
+
+	c.JSON(200, gin.H{"data": "Email verified successfully"})
+}
+
+func (ec *EmailController) RequestChange(c *gin.Context) {
+	var input dtos.RequestEmailChangeInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	input.EmailAccountInput = emailAccount(c)
+
+	err := ec.requestEmailChangeUseCase.Execute(c.Request.Context(), input)
+	if err.Message != "" {
+		c.JSON(err.Status, gin.H{"error": err.Message, "reason": err.Error})
+		return
+	}
+
+	c.JSON(202, gin.H{"data": "A confirmation link was sent to the new email"})
+}
+
+func (ec *EmailController) ConfirmChange(c *gin.Context) {
+	var input dtos.EmailTokenInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	err := ec.confirmEmailChangeUseCase.Execute(c.Request.Context(), input)
+	if err.Message != "" {
+		c.JSON(err.Status, gin.H{"error": err.Message, "reason": err.Error})
+		return
+	}
+
+	c.JSON(200, gin.H{"data": "Email changed successfully"})
+}
+
+// emailAccount is the signed in account; admins are users.
+func emailAccount(c *gin.Context) dtos.EmailAccountInput {
+	accountType := usecases.AccountTypeUser
+	if pkg.Role(c.GetString("role")) == pkg.RoleDressmaker {
+		accountType = usecases.AccountTypeDressmaker
+	}
+
+	return dtos.EmailAccountInput{
+		AccountType: accountType,
+		Subject:     c.GetString("user"),
+	}
+}
+
+// sendEmailVerification mails the link to a newly created account. The
+// account is already created, so a failure only gets logged; the owner can
+// ask for another link.
+func sendEmailVerification(c *gin.Context, uc *usecases.RequestEmailVerificationUseCase, accountType, subject string) {
+	if uc == nil {
+		return
+	}
+
+	err := uc.Execute(c.Request.Context(), dtos.EmailAccountInput{AccountType: accountType, Subject: subject})
+	if err.Message != "" {
+		log.Printf("could not send email verification to %s: %s %s", subject, err.Message, err.Error)
+	}
+}
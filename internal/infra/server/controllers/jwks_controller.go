@@ -0,0 +1,23 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/paulozy/costurai/pkg"
+)
+
+type JWKSController struct {
+	keyManager *pkg.KeyManager
+}
+
+func NewJWKSController(keyManager *pkg.KeyManager) *JWKSController {
+	return &JWKSController{
+		keyManager: keyManager,
+	}
+}
+
+// GetJWKS serves the public signing keys as a plain JWK Set, the format
+// token verifiers expect, instead of wrapping it in "data".
+func (jc *JWKSController) GetJWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(200, jc.keyManager.JWKS())
+}
@@ -0,0 +1,63 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	usecases "github.com/paulozy/costurai/internal/usecase/dressmaker"
+)
+
+type ModerationController struct {
+	listReviewsForModerationUseCase *usecases.ListReviewsForModerationUseCase
+	moderateReviewUseCase           *usecases.ModerateDressmakerReviewUseCase
+}
+
+type ModerationUseCasesInput struct {
+	ListReviewsForModerationUseCase *usecases.ListReviewsForModerationUseCase
+	ModerateReviewUseCase           *usecases.ModerateDressmakerReviewUseCase
+}
+
+func NewModerationController(usecases ModerationUseCasesInput) *ModerationController {
+	return &ModerationController{
+		listReviewsForModerationUseCase: usecases.ListReviewsForModerationUseCase,
+		moderateReviewUseCase:           usecases.ModerateReviewUseCase,
+	}
+}
+
+func (mc *ModerationController) GetReviews(c *gin.Context) {
+	reviews, err := mc.listReviewsForModerationUseCase.Execute(c.Request.Context())
+	if err.Message != "" {
+		c.JSON(err.Status, gin.H{"error": err.Message, "reason": err.Error})
+		return
+	}
+
+	c.JSON(200, gin.H{"data": reviews})
+}
+
+func (mc *ModerationController) ApproveReview(c *gin.Context) {
+	mc.moderateReview(c, usecases.ModerationActionApprove)
+}
+
+func (mc *ModerationController) HideReview(c *gin.Context) {
+	mc.moderateReview(c, usecases.ModerationActionHide)
+}
+
+func (mc *ModerationController) moderateReview(c *gin.Context, action string) {
+	var input usecases.ModerateDressmakerReviewUseCaseInput
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(400, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	input.ActorID = c.GetString("user")
+	input.ReviewID = c.Param("reviewId")
+	input.Action = action
+
+	review, err := mc.moderateReviewUseCase.Execute(c.Request.Context(), input)
+	if err.Message != "" {
+		c.JSON(err.Status, gin.H{"error": err.Message, "reason": err.Error})
+		return
+	}
+
+	c.JSON(200, gin.H{"data": review})
+}
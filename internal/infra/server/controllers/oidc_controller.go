@@ -0,0 +1,61 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	usecases "github.com/paulozy/costurai/internal/usecase/auth"
+	"github.com/paulozy/costurai/internal/usecase/auth/dtos"
+)
+
+type OIDCController struct {
+	startOIDCLoginUseCase    *usecases.StartOIDCLoginUseCase
+	completeOIDCLoginUseCase *usecases.CompleteOIDCLoginUseCase
+}
+
+type OIDCUseCasesInput struct {
+	StartOIDCLoginUseCase    *usecases.StartOIDCLoginUseCase
+	CompleteOIDCLoginUseCase *usecases.CompleteOIDCLoginUseCase
+}
+
+func NewOIDCController(usecases OIDCUseCasesInput) *OIDCController {
+	return &OIDCController{
+		startOIDCLoginUseCase:    usecases.StartOIDCLoginUseCase,
+		completeOIDCLoginUseCase: usecases.CompleteOIDCLoginUseCase,
+	}
+}
+
+func (oc *OIDCController) StartLogin(c *gin.Context) {
+	var input dtos.StartOIDCLoginInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	output, err := oc.startOIDCLoginUseCase.Execute(c.Request.Context(), input)
+	if err.Message != "" {
+		c.JSON(err.Status, gin.H{"error": err.Message, "reason": err.Error})
+		return
+	}
+
+	c.JSON(200, gin.H{"data": output})
+}
+
+func (oc *OIDCController) CompleteLogin(c *gin.Context) {
+	var input dtos.CompleteOIDCLoginInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	output, err := oc.completeOIDCLoginUseCase.Execute(c.Request.Context(), input)
+	if err.Message != "" {
+		c.JSON(err.Status, gin.H{"error": err.Message, "reason": err.Error})
+		return
+	}
+
+	status := 200
+	if output.Created {
+		status = 201
+	}
+
+	c.JSON(status, gin.H{"data": output})
+}
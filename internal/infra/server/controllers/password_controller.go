@@ -0,0 +1,56 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	usecases "github.com/paulozy/costurai/internal/usecase/auth"
+	"github.com/paulozy/costurai/internal/usecase/auth/dtos"
+)
+
+type PasswordController struct {
+	requestPasswordResetUseCase *usecases.RequestPasswordResetUseCase
+	resetPasswordUseCase        *usecases.ResetPasswordUseCase
+}
+
+type PasswordUseCasesInput struct {
+	RequestPasswordResetUseCase *usecases.RequestPasswordResetUseCase
+	ResetPasswordUseCase        *usecases.ResetPasswordUseCase
+}
+
+func NewPasswordController(usecases PasswordUseCasesInput) *PasswordController {
+	return &PasswordController{
+		requestPasswordResetUseCase: usecases.RequestPasswordResetUseCase,
+		resetPasswordUseCase:        usecases.ResetPasswordUseCase,
+	}
+}
+
+func (pc *PasswordController) RequestReset(c *gin.Context) {
+	var input dtos.RequestPasswordResetInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	err := pc.requestPasswordResetUseCase.Execute(c.Request.Context(), input)
+	if err.Message != "" {
+		c.JSON(err.Status, gin.H{"error": err.Message, "reason": err.Error})
+		return
+	}
+
+	c.JSON(202, gin.H{"data": "If the email is registered, a reset link or code was sent"})
+}
+
+func (pc *PasswordController) ResetPassword(c *gin.Context) {
+	var input dtos.ResetPasswordInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	err := pc.resetPasswordUseCase.Execute(c.Request.Context(), input)
+	if err.Message != "" {
+		c.JSON(err.Status, gin.H{"error": err.Message, "reason": err.Error})
+		return
+	}
+
+	c.JSON(200, gin.H{"data": "Password changed successfully"})
+}
@@ -1,70 +1,47 @@
 package controllers
 
 import (
-	"encoding/json"
 	"fmt"
-	"io/ioutil"
+	"io"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
-	"github.com/stripe/stripe-go/v82"
-	"github.com/stripe/stripe-go/v82/webhook"
 
-	"github.com/paulozy/costurai/internal/entity"
-	"github.com/paulozy/costurai/internal/infra/database"
 	paymentServices "github.com/paulozy/costurai/internal/infra/services/payment"
+	usecases "github.com/paulozy/costurai/internal/usecase/subscription"
 )
 
 type StripeController struct {
-	subscriptionRepository database.SubscriptionRepositoryInterface
-	dressmakerRepository   database.DressmakerRepositoryInterface
+	paymentWebhook             paymentServices.PaymentWebhookInterface
+	receivePaymentEventUseCase *usecases.ReceivePaymentEventUseCase
 }
 
 func NewStripeController(
-	subRepo database.SubscriptionRepositoryInterface,
-	dmRepo database.DressmakerRepositoryInterface,
+	paymentWebhook paymentServices.PaymentWebhookInterface,
+	receivePaymentEventUseCase *usecases.ReceivePaymentEventUseCase,
 ) *StripeController {
 	return &StripeController{
-		subscriptionRepository: subRepo,
-		dressmakerRepository:   dmRepo,
+		paymentWebhook:             paymentWebhook,
+		receivePaymentEventUseCase: receivePaymentEventUseCase,
 	}
 }
 
 func (sc *StripeController) HandleWebhook(c *gin.Context) {
-	payload, err := ioutil.ReadAll(c.Request.Body)
+	payload, err := io.ReadAll(c.Request.Body)
 	if err != nil {
 		c.String(http.StatusBadRequest, fmt.Sprintf("could not read request body: %v", err))
 		return
 	}
 	sigHeader := c.GetHeader("Stripe-Signature")
-	event, err := webhook.ConstructEvent(payload, sigHeader, paymentServices.WebhookSecret)
+	event, err := sc.paymentWebhook.ParseEvent(payload, sigHeader)
 	if err != nil {
-		c.String(http.StatusBadRequest, fmt.Sprintf("webhook signature verification failed: %v", err))
+		c.String(http.StatusBadRequest, fmt.Sprintf("could not verify webhook: %v", err))
 		return
 	}
-	switch event.Type {
-	case stripe.EventTypeCheckoutSessionCompleted:
-		var sess stripe.CheckoutSession
-		if err := json.Unmarshal(event.Data.Raw, &sess); err != nil {
-			c.String(http.StatusBadRequest, fmt.Sprintf("could not parse webhook JSON: %v", err))
-			return
-		}
-		subID := sess.Metadata["subscription_id"]
-		sub, err := sc.subscriptionRepository.FindByID(subID)
-		if err != nil {
-			c.String(http.StatusNotFound, fmt.Sprintf("subscription not found: %v", err))
-			return
-		}
-		sub.Status = entity.StatusActive
-		if sess.Subscription != nil {
-			gatewayID := sess.Subscription.ID
-			sub.GatewayId = &gatewayID
-		}
-		if err := sc.subscriptionRepository.Update(sub); err != nil {
-			c.String(http.StatusInternalServerError, fmt.Sprintf("could not update subscription: %v", err))
-			return
-		}
-	default:
+	ucErr := sc.receivePaymentEventUseCase.Execute(c.Request.Context(), *event, payload)
+	if ucErr.Message != "" {
+		c.String(ucErr.Status, fmt.Sprintf("%s: %s", ucErr.Message, ucErr.Error))
+		return
 	}
 	c.String(http.StatusOK, "success")
 }
@@ -7,13 +7,21 @@ import (
 )
 
 type SubscriptionController struct {
-	dressmakerRepository      database.DressmakerRepositoryInterface
-	subscriptionRepository    database.SubscriptionRepositoryInterface
-	createSubscriptionUseCase *usecases.CreateSubscriptionUseCase
+	dressmakerRepository          database.DressmakerRepositoryInterface
+	subscriptionRepository        database.SubscriptionRepositoryInterface
+	createSubscriptionUseCase     *usecases.CreateSubscriptionUseCase
+	cancelSubscriptionUseCase     *usecases.CancelSubscriptionUseCase
+	reactivateSubscriptionUseCase *usecases.ReactivateSubscriptionUseCase
+	previewPlanChangeUseCase      *usecases.PreviewPlanChangeUseCase
+	changePlanUseCase             *usecases.ChangePlanUseCase
 }
 
 type SubscriptionUseCasesInput struct {
-	CreateSubscriptionUseCase *usecases.CreateSubscriptionUseCase
+	CreateSubscriptionUseCase     *usecases.CreateSubscriptionUseCase
+	CancelSubscriptionUseCase     *usecases.CancelSubscriptionUseCase
+	ReactivateSubscriptionUseCase *usecases.ReactivateSubscriptionUseCase
+	PreviewPlanChangeUseCase      *usecases.PreviewPlanChangeUseCase
+	ChangePlanUseCase             *usecases.ChangePlanUseCase
 }
 
 func NewSubscriptionController(
@@ -22,9 +30,13 @@ func NewSubscriptionController(
 	usecases SubscriptionUseCasesInput,
 ) *SubscriptionController {
 	return &SubscriptionController{
-		dressmakerRepository:      dmRepo,
-		subscriptionRepository:    subRepo,
-		createSubscriptionUseCase: usecases.CreateSubscriptionUseCase,
+		dressmakerRepository:          dmRepo,
+		subscriptionRepository:        subRepo,
+		createSubscriptionUseCase:     usecases.CreateSubscriptionUseCase,
+		cancelSubscriptionUseCase:     usecases.CancelSubscriptionUseCase,
+		reactivateSubscriptionUseCase: usecases.ReactivateSubscriptionUseCase,
+		previewPlanChangeUseCase:      usecases.PreviewPlanChangeUseCase,
+		changePlanUseCase:             usecases.ChangePlanUseCase,
 	}
 }
 
@@ -35,7 +47,9 @@ func (sc *SubscriptionController) CreateSubscription(c *gin.Context) {
 		return
 	}
 
-	checkoutURL, err := sc.createSubscriptionUseCase.Execute(input)
+	input.DressmakerID = c.GetString("user")
+
+	checkoutURL, err := sc.createSubscriptionUseCase.Execute(c.Request.Context(), input)
 	if err.Message != "" {
 		c.JSON(err.Status, gin.H{"error": err.Message, "reason": err.Error})
 		return
@@ -43,3 +57,71 @@ func (sc *SubscriptionController) CreateSubscription(c *gin.Context) {
 
 	c.JSON(201, gin.H{"data": checkoutURL})
 }
+
+func (sc *SubscriptionController) CancelSubscription(c *gin.Context) {
+	input := usecases.SubscriptionOwnerInput{
+		DressmakerID: c.GetString("user"),
+		ID:           c.Param("id"),
+	}
+
+	subscription, err := sc.cancelSubscriptionUseCase.Execute(c.Request.Context(), input)
+	if err.Message != "" {
+		c.JSON(err.Status, gin.H{"error": err.Message, "reason": err.Error})
+		return
+	}
+
+	c.JSON(200, gin.H{"data": subscription})
+}
+
+func (sc *SubscriptionController) ReactivateSubscription(c *gin.Context) {
+	input := usecases.SubscriptionOwnerInput{
+		DressmakerID: c.GetString("user"),
+		ID:           c.Param("id"),
+	}
+
+	subscription, err := sc.reactivateSubscriptionUseCase.Execute(c.Request.Context(), input)
+	if err.Message != "" {
+		c.JSON(err.Status, gin.H{"error": err.Message, "reason": err.Error})
+		return
+	}
+
+	c.JSON(200, gin.H{"data": subscription})
+}
+
+func (sc *SubscriptionController) PreviewPlanChange(c *gin.Context) {
+	var input usecases.ChangePlanInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	input.DressmakerID = c.GetString("user")
+	input.ID = c.Param("id")
+
+	preview, err := sc.previewPlanChangeUseCase.Execute(c.Request.Context(), input)
+	if err.Message != "" {
+		c.JSON(err.Status, gin.H{"error": err.Message, "reason": err.Error})
+		return
+	}
+
+	c.JSON(200, gin.H{"data": preview})
+}
+
+func (sc *SubscriptionController) ChangePlan(c *gin.Context) {
+	var input usecases.ChangePlanInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	input.DressmakerID = c.GetString("user")
+	input.ID = c.Param("id")
+
+	subscription, err := sc.changePlanUseCase.Execute(c.Request.Context(), input)
+	if err.Message != "" {
+		c.JSON(err.Status, gin.H{"error": err.Message, "reason": err.Error})
+		return
+	}
+
+	c.JSON(200, gin.H{"data": subscription})
+}
@@ -3,22 +3,28 @@ package controllers
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/paulozy/costurai/internal/infra/database"
+	authUseCases "github.com/paulozy/costurai/internal/usecase/auth"
 	usecases "github.com/paulozy/costurai/internal/usecase/user"
 )
 
 type UserController struct {
 	userRepository    database.UserRepositoryInterface
 	createUserUseCase *usecases.CreateUserUseCase
+
+	requestEmailVerificationUseCase *authUseCases.RequestEmailVerificationUseCase
 }
 
 type UserUseCasesInput struct {
-	CreateUserUseCase *usecases.CreateUserUseCase
+	CreateUserUseCase               *usecases.CreateUserUseCase
+	RequestEmailVerificationUseCase *authUseCases.RequestEmailVerificationUseCase
 }
 
 func NewUserController(ur database.UserRepositoryInterface, usecases UserUseCasesInput) *UserController {
 	return &UserController{
 		userRepository:    ur,
 		createUserUseCase: usecases.CreateUserUseCase,
+
+		requestEmailVerificationUseCase: usecases.RequestEmailVerificationUseCase,
 	}
 }
 
@@ -29,11 +35,13 @@ func (uc *UserController) CreateUser(c *gin.Context) {
 		return
 	}
 
-	user, err := uc.createUserUseCase.Execute(input)
+	user, err := uc.createUserUseCase.Execute(c.Request.Context(), input)
 	if err.Message != "" {
 		c.JSON(err.Status, gin.H{"error": err.Message, "reason": err.Error})
 		return
 	}
 
+	sendEmailVerification(c, uc.requestEmailVerificationUseCase, authUseCases.AccountTypeUser, user.ID)
+
 	c.JSON(201, gin.H{"data": user})
 }
@@ -0,0 +1,34 @@
+package server
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/paulozy/costurai/configs"
+	"github.com/paulozy/costurai/pkg"
+)
+
+const defaultTokenTTL = 24 * time.Hour
+
+// newKeyManager loads the JWT signing keys from JWT_KEYS_DIR. Outside
+// production a throwaway key is generated when none is configured, which
+// invalidates every token on restart.
+func newKeyManager(cfg *configs.Config) (*pkg.KeyManager, error) {
+	ttl := defaultTokenTTL
+	if cfg.JWTExpiresIn > 0 {
+		ttl = time.Duration(cfg.JWTExpiresIn) * time.Hour
+	}
+
+	if cfg.JWTKeysDir != "" {
+		return pkg.LoadKeyManager(cfg.JWTKeysDir, cfg.JWTActiveKeyID, ttl)
+	}
+
+	if cfg.Env == "production" {
+		return nil, errors.New("JWT_KEYS_DIR is required in production")
+	}
+
+	log.Println("JWT_KEYS_DIR not set, signing tokens with an ephemeral key")
+
+	return pkg.NewEphemeralKeyManager(ttl)
+}
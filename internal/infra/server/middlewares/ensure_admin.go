@@ -0,0 +1,31 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/paulozy/costurai/internal/infra/database"
+	"github.com/paulozy/costurai/pkg"
+)
+
+// EnsureAdmin only lets through authenticated users flagged as admin. It must
+// run after EnsureAuthenticated, which sets the "user" key.
+func EnsureAdmin(userRepository database.UserRepositoryInterface) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, err := userRepository.FindByID(c.Request.Context(), c.GetString("user"))
+		if err != nil {
+			ucErr := pkg.NewInternalServerError(err)
+			c.JSON(ucErr.Status, gin.H{"error": ucErr.Message, "reason": ucErr.Error})
+			c.Abort()
+			return
+		}
+
+		if user == nil || !user.Admin {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Forbidden"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
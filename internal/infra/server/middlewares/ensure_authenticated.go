@@ -5,10 +5,13 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/paulozy/costurai/internal/infra/database"
 	"github.com/paulozy/costurai/pkg"
 )
 
-func EnsureAuthenticated() gin.HandlerFunc {
+// EnsureAuthenticated validates the bearer token and rejects tokens whose
+// session was revoked by a logout or a refresh token reuse.
+func EnsureAuthenticated(refreshTokenRepository database.RefreshTokenRepositoryInterface) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		token := c.GetHeader("Authorization")
 
@@ -34,7 +37,37 @@ func EnsureAuthenticated() gin.HandlerFunc {
 			return
 		}
 
+		role, err := pkg.GetRole(JWTToken)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+			c.Abort()
+			return
+		}
+
+		sessionID, err := pkg.GetSessionID(JWTToken)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+			c.Abort()
+			return
+		}
+
+		revoked, err := refreshTokenRepository.IsFamilyRevoked(c.Request.Context(), sessionID)
+		if err != nil {
+			ucErr := pkg.NewInternalServerError(err)
+			c.JSON(ucErr.Status, gin.H{"error": ucErr.Message, "reason": ucErr.Error})
+			c.Abort()
+			return
+		}
+
+		if revoked {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+			c.Abort()
+			return
+		}
+
 		c.Set("user", subject)
+		c.Set("session", sessionID)
+		c.Set("role", string(role))
 		c.Next()
 	}
 }
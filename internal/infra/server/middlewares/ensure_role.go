@@ -0,0 +1,25 @@
+package middlewares
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+	"github.com/paulozy/costurai/pkg"
+)
+
+// EnsureRole only lets through tokens carrying one of the given roles. It
+// must run after EnsureAuthenticated, which sets the "role" key.
+func EnsureRole(roles ...pkg.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role := pkg.Role(c.GetString("role"))
+
+		if !slices.Contains(roles, role) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Forbidden"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
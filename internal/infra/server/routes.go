@@ -1,30 +1,43 @@
 package server
 
 import (
-	"cloud.google.com/go/firestore"
+	"log"
+
 	"github.com/paulozy/costurai/configs"
-	"github.com/paulozy/costurai/internal/infra/database/firestore/repositories"
+	"github.com/paulozy/costurai/internal/infra/database"
 	"github.com/paulozy/costurai/internal/infra/server/controllers"
+	notificationServices "github.com/paulozy/costurai/internal/infra/services/notification"
+	oidcServices "github.com/paulozy/costurai/internal/infra/services/oidc"
 	paymentServices "github.com/paulozy/costurai/internal/infra/services/payment"
 	services "github.com/paulozy/costurai/internal/infra/services/sms"
+	adminUseCases "github.com/paulozy/costurai/internal/usecase/admin"
 	authUseCases "github.com/paulozy/costurai/internal/usecase/auth"
 	dressmakerUseCases "github.com/paulozy/costurai/internal/usecase/dressmaker"
 	subUseCases "github.com/paulozy/costurai/internal/usecase/subscription"
 	userUseCases "github.com/paulozy/costurai/internal/usecase/user"
+	"github.com/paulozy/costurai/pkg"
 )
 
 var Routes = []Handler{}
 
-func PopulateRoutes(db *firestore.Client) []Handler {
-	cfg, err := configs.LoadConfig("../../..")
+func PopulateRoutes(repos *database.Repositories, cfg *configs.Config) []Handler {
+	Routes = []Handler{}
+
+	keyManager, err := newKeyManager(cfg)
 	if err != nil {
-		panic(err)
+		log.Panicf("invalid JWT signing keys: %v", err)
 	}
+	pkg.InitKeyManager(keyManager)
+
 	paymentServices.InitStripe(cfg.StripeSecretKey)
 	paymentServices.InitWebhook(cfg.StripeWebhookSecret)
 	stripeController := controllers.NewStripeController(
-		repositories.NewFirestoreSubscriptionRepository(db),
-		repositories.NewFirestoreDressmakerRepository(db),
+		paymentServices.NewStripeService(),
+		subUseCases.NewReceivePaymentEventUseCase(
+			repos.WebhookEvent,
+			subUseCases.NewHandlePaymentEventUseCase(repos.Subscription, repos.Dressmaker, cfg),
+			cfg,
+		),
 	)
 	Routes = append(Routes, Handler{
 		Path:   "/stripe/webhook",
@@ -32,29 +45,122 @@ func PopulateRoutes(db *firestore.Client) []Handler {
 		Func:   stripeController.HandleWebhook,
 		Auth:   false,
 	})
-	addDressmakerRoutes(db)
-	addUserRoutes(db)
-	addSubscriptionRoutes(db, cfg)
-	addAuthRoutes(db)
+	addDressmakerRoutes(repos, cfg)
+	addModerationRoutes(repos)
+	addAdminRoutes(repos)
+	addUserRoutes(repos, cfg)
+	addSubscriptionRoutes(repos, cfg)
+	addAuthRoutes(repos, cfg)
+	addPasswordRoutes(repos, cfg)
+	addEmailRoutes(repos, cfg)
+	addOIDCRoutes(repos, cfg)
+	addWellKnownRoutes(keyManager)
 	return Routes
 }
 
-func addDressmakerRoutes(db *firestore.Client) {
-	dressmakerRepository := repositories.NewFirestoreDressmakerRepository(db)
+// addOIDCRoutes enables login through an OpenID Connect provider when
+// OIDC_ISSUER is set.
+func addOIDCRoutes(repos *database.Repositories, cfg *configs.Config) {
+	if cfg.OIDCIssuer == "" {
+		return
+	}
+
+	provider := oidcServices.NewOIDCProvider(cfg)
+
+	startOIDCLoginUseCase := authUseCases.NewStartOIDCLoginUseCase(authUseCases.NewStartOIDCLoginUseCaseInput{
+		Provider:                 provider,
+		OIDCLoginStateRepository: repos.OIDCLoginState,
+	})
+	completeOIDCLoginUseCase := authUseCases.NewCompleteOIDCLoginUseCase(authUseCases.NewCompleteOIDCLoginUseCaseInput{
+		Provider:                 provider,
+		OIDCLoginStateRepository: repos.OIDCLoginState,
+		IdentityRepository:       repos.Identity,
+		UserRepository:           repos.User,
+		DressmakerRepository:     repos.Dressmaker,
+		RefreshTokenRepository:   repos.RefreshToken,
+		OneTimeTokenRepository:   repos.OneTimeToken,
+		Config:                   cfg,
+	})
+
+	oidcController := controllers.NewOIDCController(controllers.OIDCUseCasesInput{
+		StartOIDCLoginUseCase:    startOIDCLoginUseCase,
+		CompleteOIDCLoginUseCase: completeOIDCLoginUseCase,
+	})
+
+	oidcHandlers := []Handler{
+		{
+			Path:   "/auth/oidc/start",
+			Method: "POST",
+			Func:   oidcController.StartLogin,
+		},
+		{
+			Path:   "/auth/oidc/callback",
+			Method: "POST",
+			Func:   oidcController.CompleteLogin,
+		},
+	}
+
+	Routes = append(Routes, oidcHandlers...)
+}
+
+func addWellKnownRoutes(keyManager *pkg.KeyManager) {
+	jwksController := controllers.NewJWKSController(keyManager)
+
+	Routes = append(Routes, Handler{
+		Path:   "/.well-known/jwks.json",
+		Method: "GET",
+		Func:   jwksController.GetJWKS,
+	})
+}
+
+func addDressmakerRoutes(repos *database.Repositories, cfg *configs.Config) {
+	dressmakerRepository := repos.Dressmaker
+	reviewsRepository := repos.DressmakerReviews
+	userRepository := repos.User
+
+	reviewScreener, err := pkg.NewTextScreener(
+		pkg.SplitList(cfg.ReviewBlockedWords, ","),
+		pkg.SplitList(cfg.ReviewBlockedPatterns, ";;"),
+	)
+	if err != nil {
+		log.Panicf("invalid REVIEW_BLOCKED_PATTERNS: %v", err)
+	}
 
 	createDressmakerUseCase := dressmakerUseCases.NewCreateDressMakerUseCase(dressmakerRepository)
-	updateDressmakerUseCase := dressmakerUseCases.NewUpdateDressMakerUseCase(dressmakerRepository)
+	updateDressmakerUseCase := dressmakerUseCases.NewUpdateDressMakerUseCase(dressmakerRepository, repos.Subscription)
 	getDressmakersByProximityUseCase := dressmakerUseCases.NewGetDressmakersByProximityUseCase(dressmakerRepository)
 	showDressmakerUseCase := dressmakerUseCases.NewShowDressMakerUseCase(dressmakerRepository)
+	addReviewUseCase := dressmakerUseCases.NewAddDressmakerReviewUseCase(dressmakerRepository, reviewsRepository, userRepository, reviewScreener)
+	updateReviewUseCase := dressmakerUseCases.NewUpdateDressmakerReviewUseCase(dressmakerRepository, reviewsRepository, userRepository, reviewScreener)
+	deleteReviewUseCase := dressmakerUseCases.NewDeleteDressmakerReviewUseCase(dressmakerRepository, reviewsRepository)
+	listReviewsUseCase := dressmakerUseCases.NewListDressmakerReviewsUseCase(dressmakerRepository, reviewsRepository)
+	replyReviewUseCase := dressmakerUseCases.NewReplyDressmakerReviewUseCase(
+		dressmakerRepository,
+		reviewsRepository,
+		userRepository,
+		notificationServices.NewNotificationService(cfg),
+	)
+	reportReviewUseCase := dressmakerUseCases.NewReportDressmakerReviewUseCase(
+		dressmakerRepository,
+		reviewsRepository,
+		cfg.ReviewReportThreshold,
+	)
 
 	dressmakerUseCases := controllers.DressmakerUseCasesInput{
 		CreateDressmakerUseCase:          createDressmakerUseCase,
 		UpdateDressmakerUseCase:          updateDressmakerUseCase,
 		GetDressmakersByProximityUseCase: getDressmakersByProximityUseCase,
 		ShowDressmakerUseCase:            showDressmakerUseCase,
+		AddDressmakerReviewUseCase:       addReviewUseCase,
+		UpdateDressmakerReviewUseCase:    updateReviewUseCase,
+		DeleteDressmakerReviewUseCase:    deleteReviewUseCase,
+		ListDressmakerReviewsUseCase:     listReviewsUseCase,
+		ReplyDressmakerReviewUseCase:     replyReviewUseCase,
+		ReportDressmakerReviewUseCase:    reportReviewUseCase,
+		RequestEmailVerificationUseCase:  newRequestEmailVerificationUseCase(repos, cfg),
 	}
 
-	dressmakerController := controllers.NewDressmakerController(dressmakerRepository, nil, dressmakerUseCases)
+	dressmakerController := controllers.NewDressmakerController(dressmakerRepository, reviewsRepository, dressmakerUseCases)
 
 	dressmakerControllerRoutes := []Handler{
 		{
@@ -75,21 +181,170 @@ func addDressmakerRoutes(db *firestore.Client) {
 		{
 			Path:   "/dressmakers/:id",
 			Method: "PUT",
-			Auth:   true,
+			Roles:  []pkg.Role{pkg.RoleDressmaker},
 			Func:   dressmakerController.UpdateDressmaker,
 		},
+		{
+			Path:   "/dressmakers/:id/reviews",
+			Method: "GET",
+			Func:   dressmakerController.GetReviews,
+		},
+		{
+			Path:   "/dressmakers/:id/reviews",
+			Method: "POST",
+			Roles:  []pkg.Role{pkg.RoleUser, pkg.RoleAdmin},
+			Func:   dressmakerController.AddReview,
+		},
+		{
+			Path:   "/dressmakers/:id/reviews",
+			Method: "PUT",
+			Roles:  []pkg.Role{pkg.RoleUser, pkg.RoleAdmin},
+			Func:   dressmakerController.UpdateReview,
+		},
+		{
+			Path:   "/dressmakers/:id/reviews",
+			Method: "DELETE",
+			Roles:  []pkg.Role{pkg.RoleUser, pkg.RoleAdmin},
+			Func:   dressmakerController.DeleteReview,
+		},
+		{
+			Path:   "/dressmakers/:id/reviews/:reviewId/reply",
+			Method: "PUT",
+			Roles:  []pkg.Role{pkg.RoleDressmaker},
+			Func:   dressmakerController.ReplyReview,
+		},
+		{
+			Path:   "/dressmakers/:id/reviews/:reviewId/reports",
+			Method: "POST",
+			Auth:   true,
+			Func:   dressmakerController.ReportReview,
+		},
 	}
 
 	Routes = append(Routes, dressmakerControllerRoutes...)
 }
 
-func addUserRoutes(db *firestore.Client) {
-	userRepository := repositories.NewFirestoreUserRepository(db)
+func addModerationRoutes(repos *database.Repositories) {
+	dressmakerRepository := repos.Dressmaker
+	reviewsRepository := repos.DressmakerReviews
+
+	moderationUseCases := controllers.ModerationUseCasesInput{
+		ListReviewsForModerationUseCase: dressmakerUseCases.NewListReviewsForModerationUseCase(reviewsRepository),
+		ModerateReviewUseCase:           dressmakerUseCases.NewModerateDressmakerReviewUseCase(dressmakerRepository, reviewsRepository, repos.AuditLog),
+	}
+
+	moderationController := controllers.NewModerationController(moderationUseCases)
+
+	moderationRoutes := []Handler{
+		{
+			Path:   "/admin/reviews",
+			Method: "GET",
+			Admin:  true,
+			Func:   moderationController.GetReviews,
+		},
+		{
+			Path:   "/admin/reviews/:reviewId/approve",
+			Method: "POST",
+			Admin:  true,
+			Func:   moderationController.ApproveReview,
+		},
+		{
+			Path:   "/admin/reviews/:reviewId/hide",
+			Method: "POST",
+			Admin:  true,
+			Func:   moderationController.HideReview,
+		},
+	}
+
+	Routes = append(Routes, moderationRoutes...)
+}
+
+func addAdminRoutes(repos *database.Repositories) {
+	adminController := controllers.NewAdminController(controllers.AdminUseCasesInput{
+		ListUsersUseCase:           adminUseCases.NewListUsersUseCase(repos.User),
+		ListDressmakersUseCase:     adminUseCases.NewListDressmakersUseCase(repos.Dressmaker),
+		SetUserStatusUseCase:       adminUseCases.NewSetUserStatusUseCase(repos.User, repos.RefreshToken, repos.AuditLog),
+		SetDressmakerStatusUseCase: adminUseCases.NewSetDressmakerStatusUseCase(repos.Dressmaker, repos.Subscription, repos.RefreshToken, repos.AuditLog),
+		ListSubscriptionsUseCase:   adminUseCases.NewListSubscriptionsUseCase(repos.Subscription),
+		ShowSubscriptionUseCase:    adminUseCases.NewShowSubscriptionUseCase(repos.Subscription, repos.Dressmaker, repos.AuditLog),
+		AdjustSubscriptionUseCase:  adminUseCases.NewAdjustSubscriptionUseCase(repos.Subscription, repos.Dressmaker, repos.AuditLog),
+		ListAuditLogUseCase:        adminUseCases.NewListAuditLogUseCase(repos.AuditLog),
+	})
+
+	adminRoutes := []Handler{
+		{
+			Path:   "/admin/users",
+			Method: "GET",
+			Admin:  true,
+			Func:   adminController.GetUsers,
+		},
+		{
+			Path:   "/admin/users/:id/enable",
+			Method: "POST",
+			Admin:  true,
+			Func:   adminController.EnableUser,
+		},
+		{
+			Path:   "/admin/users/:id/disable",
+			Method: "POST",
+			Admin:  true,
+			Func:   adminController.DisableUser,
+		},
+		{
+			Path:   "/admin/dressmakers",
+			Method: "GET",
+			Admin:  true,
+			Func:   adminController.GetDressmakers,
+		},
+		{
+			Path:   "/admin/dressmakers/:id/enable",
+			Method: "POST",
+			Admin:  true,
+			Func:   adminController.EnableDressmaker,
+		},
+		{
+			Path:   "/admin/dressmakers/:id/disable",
+			Method: "POST",
+			Admin:  true,
+			Func:   adminController.DisableDressmaker,
+		},
+		{
+			Path:   "/admin/subscriptions",
+			Method: "GET",
+			Admin:  true,
+			Func:   adminController.GetSubscriptions,
+		},
+		{
+			Path:   "/admin/subscriptions/:id",
+			Method: "GET",
+			Admin:  true,
+			Func:   adminController.GetSubscription,
+		},
+		{
+			Path:   "/admin/subscriptions/:id/adjust",
+			Method: "POST",
+			Admin:  true,
+			Func:   adminController.AdjustSubscription,
+		},
+		{
+			Path:   "/admin/audit-log",
+			Method: "GET",
+			Admin:  true,
+			Func:   adminController.GetAuditLog,
+		},
+	}
+
+	Routes = append(Routes, adminRoutes...)
+}
+
+func addUserRoutes(repos *database.Repositories, cfg *configs.Config) {
+	userRepository := repos.User
 
 	createUserUseCase := userUseCases.NewCreateUserUseCase(userRepository)
 
 	userUseCases := controllers.UserUseCasesInput{
-		CreateUserUseCase: createUserUseCase,
+		CreateUserUseCase:               createUserUseCase,
+		RequestEmailVerificationUseCase: newRequestEmailVerificationUseCase(repos, cfg),
 	}
 
 	userController := controllers.NewUserController(userRepository, userUseCases)
@@ -105,32 +360,52 @@ func addUserRoutes(db *firestore.Client) {
 	Routes = append(Routes, userControllerRoutes...)
 }
 
-func addAuthRoutes(db *firestore.Client) {
-	dressmakerRepository := repositories.NewFirestoreDressmakerRepository(db)
-	userRepository := repositories.NewFirestoreUserRepository(db)
+func addAuthRoutes(repos *database.Repositories, cfg *configs.Config) {
+	dressmakerRepository := repos.Dressmaker
+	userRepository := repos.User
+	refreshTokenRepository := repos.RefreshToken
+	throttleRepository := repos.Throttle
 
 	authDressmakerUseCase := authUseCases.NewDressmakerAuthenticationUseCase(authUseCases.NewAuthDressmakerUseCaseInput{
-		DressmakerRepository: dressmakerRepository,
+		DressmakerRepository:   dressmakerRepository,
+		RefreshTokenRepository: refreshTokenRepository,
+		ThrottleRepository:     throttleRepository,
+		Config:                 cfg,
 	})
 	authUserUseCase := authUseCases.NewUserAuthUseCase(authUseCases.NewAuthUserUseCaseInput{
-		UserRepository: userRepository,
+		UserRepository:         userRepository,
+		RefreshTokenRepository: refreshTokenRepository,
+		ThrottleRepository:     throttleRepository,
+		Config:                 cfg,
+	})
+	refreshTokenUseCase := authUseCases.NewRefreshTokenUseCase(authUseCases.NewRefreshTokenUseCaseInput{
+		RefreshTokenRepository: refreshTokenRepository,
+		Config:                 cfg,
+	})
+	logoutUseCase := authUseCases.NewLogoutUseCase(authUseCases.NewLogoutUseCaseInput{
+		RefreshTokenRepository: refreshTokenRepository,
 	})
 
-	OTPService := services.NewTwilioService()
+	OTPService := services.NewOTPService(cfg, repos.OTPCode)
 	sendOTPUseCase := authUseCases.NewSentOTPUseCase(
 		authUseCases.NewSendOTPUseCaseInput{
-			OTPService: OTPService,
+			OTPService:         OTPService,
+			ThrottleRepository: throttleRepository,
+			Config:             cfg,
 		},
 	)
 	verifyOTPUseCase := authUseCases.NewVerifyOTPUseCase(authUseCases.NewVerifyOTPUseCaseInput{
-		OTPService:           OTPService,
-		DressmakerRepository: dressmakerRepository,
-		UserRepository:       userRepository,
+		OTPService:             OTPService,
+		DressmakerRepository:   dressmakerRepository,
+		UserRepository:         userRepository,
+		SubscriptionRepository: repos.Subscription,
 	})
 
 	authController := controllers.NewAuthController(
 		authDressmakerUseCase,
 		authUserUseCase,
+		refreshTokenUseCase,
+		logoutUseCase,
 		sendOTPUseCase,
 		verifyOTPUseCase,
 		dressmakerRepository,
@@ -148,6 +423,16 @@ func addAuthRoutes(db *firestore.Client) {
 			Method: "POST",
 			Func:   authController.AuthenticateUser,
 		},
+		{
+			Path:   "/auth/refresh",
+			Method: "POST",
+			Func:   authController.RefreshToken,
+		},
+		{
+			Path:   "/auth/logout",
+			Method: "POST",
+			Func:   authController.Logout,
+		},
 		{
 			Path:   "/otp",
 			Method: "POST",
@@ -157,22 +442,126 @@ func addAuthRoutes(db *firestore.Client) {
 			Path:   "/otp/dressmaker/verify",
 			Method: "POST",
 			Func:   authController.VerifyOTP,
-			Auth:   true,
+			Roles:  []pkg.Role{pkg.RoleDressmaker},
 		},
 		{
 			Path:   "/otp/user/verify",
 			Method: "POST",
 			Func:   authController.VerifyOTP,
-			Auth:   true,
+			Roles:  []pkg.Role{pkg.RoleUser, pkg.RoleAdmin},
 		},
 	}
 
 	Routes = append(Routes, authHandlers...)
 }
 
-func addSubscriptionRoutes(db *firestore.Client, cfg *configs.Config) {
-	dressmakerRepository := repositories.NewFirestoreDressmakerRepository(db)
-	subscriptionRepository := repositories.NewFirestoreSubscriptionRepository(db)
+func addPasswordRoutes(repos *database.Repositories, cfg *configs.Config) {
+	OTPService := services.NewOTPService(cfg, repos.OTPCode)
+	requestPasswordResetUseCase := authUseCases.NewRequestPasswordResetUseCase(authUseCases.NewRequestPasswordResetUseCaseInput{
+		DressmakerRepository:   repos.Dressmaker,
+		UserRepository:         repos.User,
+		OneTimeTokenRepository: repos.OneTimeToken,
+		NotificationService:    notificationServices.NewNotificationService(cfg),
+		OTPService:             OTPService,
+		ThrottleRepository:     repos.Throttle,
+		Config:                 cfg,
+	})
+	resetPasswordUseCase := authUseCases.NewResetPasswordUseCase(authUseCases.NewResetPasswordUseCaseInput{
+		DressmakerRepository:   repos.Dressmaker,
+		UserRepository:         repos.User,
+		OneTimeTokenRepository: repos.OneTimeToken,
+		RefreshTokenRepository: repos.RefreshToken,
+		OTPService:             OTPService,
+	})
+
+	passwordController := controllers.NewPasswordController(controllers.PasswordUseCasesInput{
+		RequestPasswordResetUseCase: requestPasswordResetUseCase,
+		ResetPasswordUseCase:        resetPasswordUseCase,
+	})
+
+	passwordRoutes := []Handler{
+		{
+			Path:   "/auth/password/forgot",
+			Method: "POST",
+			Func:   passwordController.RequestReset,
+		},
+		{
+			Path:   "/auth/password/reset",
+			Method: "POST",
+			Func:   passwordController.ResetPassword,
+		},
+	}
+
+	Routes = append(Routes, passwordRoutes...)
+}
+
+func newRequestEmailVerificationUseCase(repos *database.Repositories, cfg *configs.Config) *authUseCases.RequestEmailVerificationUseCase {
+	return authUseCases.NewRequestEmailVerificationUseCase(authUseCases.NewRequestEmailVerificationUseCaseInput{
+		DressmakerRepository:   repos.Dressmaker,
+		UserRepository:         repos.User,
+		OneTimeTokenRepository: repos.OneTimeToken,
+		NotificationService:    notificationServices.NewNotificationService(cfg),
+		Config:                 cfg,
+	})
+}
+
+func addEmailRoutes(repos *database.Repositories, cfg *configs.Config) {
+	requestEmailChangeUseCase := authUseCases.NewRequestEmailChangeUseCase(authUseCases.NewRequestEmailChangeUseCaseInput{
+		DressmakerRepository:   repos.Dressmaker,
+		UserRepository:         repos.User,
+		OneTimeTokenRepository: repos.OneTimeToken,
+		NotificationService:    notificationServices.NewNotificationService(cfg),
+		Config:                 cfg,
+	})
+	verifyEmailUseCase := authUseCases.NewVerifyEmailUseCase(authUseCases.NewVerifyEmailUseCaseInput{
+		DressmakerRepository:   repos.Dressmaker,
+		UserRepository:         repos.User,
+		OneTimeTokenRepository: repos.OneTimeToken,
+	})
+	confirmEmailChangeUseCase := authUseCases.NewConfirmEmailChangeUseCase(authUseCases.NewConfirmEmailChangeUseCaseInput{
+		DressmakerRepository:   repos.Dressmaker,
+		UserRepository:         repos.User,
+		OneTimeTokenRepository: repos.OneTimeToken,
+	})
+
+	emailController := controllers.NewEmailController(controllers.EmailUseCasesInput{
+		RequestEmailVerificationUseCase: newRequestEmailVerificationUseCase(repos, cfg),
+		VerifyEmailUseCase:              verifyEmailUseCase,
+		RequestEmailChangeUseCase:       requestEmailChangeUseCase,
+		ConfirmEmailChangeUseCase:       confirmEmailChangeUseCase,
+	})
+
+	emailRoutes := []Handler{
+		{
+			Path:   "/auth/email/verification",
+			Method: "POST",
+			Auth:   true,
+			Func:   emailController.RequestVerification,
+		},
+		{
+			Path:   "/auth/email/verify",
+			Method: "POST",
+			Func:   emailController.Verify,
+		},
+		{
+			Path:   "/auth/email/change",
+			Method: "POST",
+			Auth:   true,
+			Func:   emailController.RequestChange,
+		},
+		{
+			Path:   "/auth/email/change/confirm",
+			Method: "POST",
+			Func:   emailController.ConfirmChange,
+		},
+	}
+
+	Routes = append(Routes, emailRoutes...)
+}
+
+func addSubscriptionRoutes(repos *database.Repositories, cfg *configs.Config) {
+	dressmakerRepository := repos.Dressmaker
+	subscriptionRepository := repos.Subscription
 	stripePayment := paymentServices.NewStripeService()
 
 	createSubscriptionUseCase := subUseCases.NewCreateSubscriptionUseCase(
@@ -181,8 +570,24 @@ func addSubscriptionRoutes(db *firestore.Client, cfg *configs.Config) {
 		stripePayment,
 		cfg,
 	)
+	cancelSubscriptionUseCase := subUseCases.NewCancelSubscriptionUseCase(
+		subscriptionRepository,
+		dressmakerRepository,
+		stripePayment,
+	)
+	reactivateSubscriptionUseCase := subUseCases.NewReactivateSubscriptionUseCase(
+		subscriptionRepository,
+		dressmakerRepository,
+		stripePayment,
+	)
+	previewPlanChangeUseCase := subUseCases.NewPreviewPlanChangeUseCase(subscriptionRepository, stripePayment)
+	changePlanUseCase := subUseCases.NewChangePlanUseCase(subscriptionRepository, stripePayment)
 	subsUseCases := controllers.SubscriptionUseCasesInput{
-		CreateSubscriptionUseCase: createSubscriptionUseCase,
+		CreateSubscriptionUseCase:     createSubscriptionUseCase,
+		CancelSubscriptionUseCase:     cancelSubscriptionUseCase,
+		ReactivateSubscriptionUseCase: reactivateSubscriptionUseCase,
+		PreviewPlanChangeUseCase:      previewPlanChangeUseCase,
+		ChangePlanUseCase:             changePlanUseCase,
 	}
 	subscriptionController := controllers.NewSubscriptionController(
 		dressmakerRepository,
@@ -193,9 +598,33 @@ func addSubscriptionRoutes(db *firestore.Client, cfg *configs.Config) {
 		{
 			Path:   "/subscriptions",
 			Method: "POST",
-			Auth:   true,
+			Roles:  []pkg.Role{pkg.RoleDressmaker},
 			Func:   subscriptionController.CreateSubscription,
 		},
+		{
+			Path:   "/subscriptions/:id",
+			Method: "DELETE",
+			Roles:  []pkg.Role{pkg.RoleDressmaker},
+			Func:   subscriptionController.CancelSubscription,
+		},
+		{
+			Path:   "/subscriptions/:id/reactivate",
+			Method: "POST",
+			Roles:  []pkg.Role{pkg.RoleDressmaker},
+			Func:   subscriptionController.ReactivateSubscription,
+		},
+		{
+			Path:   "/subscriptions/:id/plan/preview",
+			Method: "POST",
+			Roles:  []pkg.Role{pkg.RoleDressmaker},
+			Func:   subscriptionController.PreviewPlanChange,
+		},
+		{
+			Path:   "/subscriptions/:id/plan",
+			Method: "POST",
+			Roles:  []pkg.Role{pkg.RoleDressmaker},
+			Func:   subscriptionController.ChangePlan,
+		},
 	}
 	Routes = append(Routes, subsControllerRoutes...)
 }
@@ -1,27 +1,36 @@
 package server
 
 import (
-	"cloud.google.com/go/firestore"
+	"log"
+	"time"
+
 	"github.com/gin-gonic/gin"
+	"github.com/paulozy/costurai/configs"
+	"github.com/paulozy/costurai/internal/infra/database"
 	"github.com/paulozy/costurai/internal/infra/server/middlewares"
+	"github.com/paulozy/costurai/pkg"
 
 	"github.com/gin-contrib/cors"
 )
 
 type Handler struct {
-	Path   string
-	Method string
-	Auth   bool
-	Func   gin.HandlerFunc
+	Path    string
+	Method  string
+	Auth    bool
+	Roles   []pkg.Role    // token roles allowed to call the route, implies Auth
+	Admin   bool          // requires the admin role and a user still flagged as admin, implies Auth
+	Timeout time.Duration // overrides REQUEST_TIMEOUT when set
+	Func    gin.HandlerFunc
 }
 
 type Server struct {
-	Host        string
-	Port        string
-	Env         string
-	Router      *gin.Engine
-	FirestoreDB *firestore.Client
-	Handlers    []Handler
+	Host         string
+	Port         string
+	Env          string
+	Router       *gin.Engine
+	Config       *configs.Config
+	Repositories *database.Repositories
+	Handlers     []Handler
 }
 
 func NewServer(host, port, env string) *Server {
@@ -36,26 +45,60 @@ func NewServer(host, port, env string) *Server {
 }
 
 func (s *Server) AddHandlers() {
-	PopulateRoutes(s.FirestoreDB)
+	PopulateRoutes(s.Repositories, s.Config)
 	s.Handlers = append(s.Handlers, Routes...)
 }
 
-func (s *Server) Start() {
+// Setup registers the middlewares and handlers on the router without
+// listening, so the router can also be served through httptest.
+func (s *Server) Setup() {
 	config := cors.DefaultConfig()
 	config.AllowAllOrigins = true
-	config.AllowMethods = []string{"POST", "GET", "PUT", "OPTIONS"}
+	config.AllowMethods = []string{"POST", "GET", "PUT", "DELETE", "OPTIONS"}
 	config.AllowHeaders = []string{"Origin", "Content-Type", "Authorization", "Accept", "User-Agent", "Cache-Control", "Pragma"}
 	config.ExposeHeaders = []string{"Content-Length"}
 
 	s.Router.Use(cors.New(config))
 
+	defaultTimeout := time.Duration(0)
+	var trustedProxies []string
+	if s.Config != nil {
+		defaultTimeout = time.Duration(s.Config.RequestTimeout) * time.Second
+		trustedProxies = pkg.SplitList(s.Config.TrustedProxies, ",")
+	}
+
+	// client IPs throttle logins, so forwarded headers are only honoured
+	// when they come from a known proxy
+	if err := s.Router.SetTrustedProxies(trustedProxies); err != nil {
+		log.Panicf("invalid TRUSTED_PROXIES: %v", err)
+	}
+
 	for _, h := range s.Handlers {
-		if h.Auth {
-			s.Router.Handle(h.Method, h.Path, middlewares.EnsureAuthenticated(), h.Func)
-		} else {
-			s.Router.Handle(h.Method, h.Path, h.Func)
+		timeout := h.Timeout
+		if timeout == 0 {
+			timeout = defaultTimeout
+		}
+
+		chain := []gin.HandlerFunc{middlewares.RequestTimeout(timeout)}
+		if h.Auth || h.Admin || len(h.Roles) > 0 {
+			chain = append(chain, middlewares.EnsureAuthenticated(s.Repositories.RefreshToken))
 		}
+
+		if len(h.Roles) > 0 {
+			chain = append(chain, middlewares.EnsureRole(h.Roles...))
+		}
+
+		if h.Admin {
+			chain = append(chain, middlewares.EnsureRole(pkg.RoleAdmin), middlewares.EnsureAdmin(s.Repositories.User))
+		}
+
+		chain = append(chain, h.Func)
+		s.Router.Handle(h.Method, h.Path, chain...)
 	}
+}
+
+func (s *Server) Start() {
+	s.Setup()
 
 	var address string
 
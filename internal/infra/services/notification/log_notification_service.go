@@ -0,0 +1,19 @@
+package services
+
+import (
+	"context"
+	"log"
+)
+
+// LogNotificationService writes notifications to the application log instead
+// of delivering them, for local development.
+type LogNotificationService struct{}
+
+func NewLogNotificationService() *LogNotificationService {
+	return &LogNotificationService{}
+}
+
+func (s *LogNotificationService) Notify(ctx context.Context, message Message) error {
+	log.Printf("notification to=%s subject=%q body=%q", message.To, message.Subject, message.Body)
+	return nil
+}
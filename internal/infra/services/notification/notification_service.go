@@ -0,0 +1,32 @@
+package services
+
+import (
+	"log"
+
+	"github.com/paulozy/costurai/configs"
+)
+
+const (
+	LogProvider  = "log"
+	SMTPProvider = "smtp"
+)
+
+// NewNotificationService picks the delivery channel from
+// NOTIFICATION_PROVIDER, logging notifications when it is unset. Logging is
+// refused in production, since notifications carry password reset and email
+// links that would end up in plain text in the logs.
+func NewNotificationService(cfg *configs.Config) NotificationServiceInterface {
+	switch cfg.NotificationProvider {
+	case SMTPProvider:
+		return NewSMTPNotificationService(cfg)
+	case LogProvider, "":
+		if cfg.Env == "production" {
+			log.Panicf("NOTIFICATION_PROVIDER must deliver notifications in production, got %q", cfg.NotificationProvider)
+		}
+
+		return NewLogNotificationService()
+	default:
+		log.Panicf("unknown NOTIFICATION_PROVIDER %q", cfg.NotificationProvider)
+		return nil
+	}
+}
@@ -0,0 +1,13 @@
+package services
+
+import "context"
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+type NotificationServiceInterface interface {
+	Notify(ctx context.Context, message Message) error
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+
+	"github.com/paulozy/costurai/configs"
+)
+
+// SMTPNotificationService delivers notifications as plain text emails.
+type SMTPNotificationService struct {
+	Addr string
+	From string
+	Auth smtp.Auth
+}
+
+func NewSMTPNotificationService(cfg *configs.Config) *SMTPNotificationService {
+	var auth smtp.Auth
+	if cfg.SMTPUsername != "" {
+		auth = smtp.PlainAuth("", cfg.SMTPUsername, cfg.SMTPPassword, cfg.SMTPHost)
+	}
+
+	return &SMTPNotificationService{
+		Addr: net.JoinHostPort(cfg.SMTPHost, cfg.SMTPPort),
+		From: cfg.SMTPFrom,
+		Auth: auth,
+	}
+}
+
+func (s *SMTPNotificationService) Notify(ctx context.Context, message Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if strings.ContainsAny(message.To+message.Subject, "\r\n") {
+		return fmt.Errorf("invalid email header")
+	}
+
+	body := "From: " + s.From + "\r\n" +
+		"To: " + message.To + "\r\n" +
+		"Subject: " + message.Subject + "\r\n" +
+		"Content-Type: text/plain; charset=UTF-8\r\n" +
+		"\r\n" +
+		message.Body
+
+	return smtp.SendMail(s.Addr, s.Auth, s.From, []string{message.To}, []byte(body))
+}
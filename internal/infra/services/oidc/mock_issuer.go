@@ -0,0 +1,191 @@
+package services
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/paulozy/costurai/pkg"
+)
+
+const mockCodeTTL = time.Minute
+
+type mockGrant struct {
+	clientID      string
+	redirectURI   string
+	nonce         string
+	codeChallenge string
+	email         string
+	emailVerified bool
+	expiresAt     time.Time
+}
+
+// MockIssuer is a minimal OpenID Connect provider for local development. It
+// logs in whoever is named by the login_hint parameter without asking for a
+// password, and signs ID tokens with an ephemeral key.
+type MockIssuer struct {
+	IssuerURL string
+	ClientID  string
+
+	keys   *pkg.KeyManager
+	mux    *http.ServeMux
+	mu     sync.Mutex
+	grants map[string]mockGrant
+}
+
+func NewMockIssuer(issuerURL, clientID string) (*MockIssuer, error) {
+	keys, err := pkg.NewEphemeralKeyManager(time.Hour)
+	if err != nil {
+		return nil, err
+	}
+
+	issuer := &MockIssuer{
+		IssuerURL: strings.TrimSuffix(issuerURL, "/"),
+		ClientID:  clientID,
+		keys:      keys,
+		mux:       http.NewServeMux(),
+		grants:    map[string]mockGrant{},
+	}
+
+	issuer.mux.HandleFunc("/.well-known/openid-configuration", issuer.discovery)
+	issuer.mux.HandleFunc("/authorize", issuer.authorize)
+	issuer.mux.HandleFunc("/token", issuer.token)
+	issuer.mux.HandleFunc("/jwks", issuer.jwks)
+
+	return issuer, nil
+}
+
+func (m *MockIssuer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mux.ServeHTTP(w, r)
+}
+
+func (m *MockIssuer) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"issuer":                                m.IssuerURL,
+		"authorization_endpoint":                m.IssuerURL + "/authorize",
+		"token_endpoint":                        m.IssuerURL + "/token",
+		"jwks_uri":                              m.IssuerURL + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"EdDSA"},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+// authorize redirects straight back with a code. Pass login_hint to pick
+// the email and email_verified=false to log in with an unverified one.
+func (m *MockIssuer) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	if query.Get("response_type") != "code" || query.Get("client_id") != m.ClientID {
+		http.Error(w, "unsupported response_type or unknown client_id", http.StatusBadRequest)
+		return
+	}
+
+	if query.Get("code_challenge") == "" || query.Get("code_challenge_method") != "S256" {
+		http.Error(w, "an S256 code_challenge is required", http.StatusBadRequest)
+		return
+	}
+
+	redirectURI, err := url.Parse(query.Get("redirect_uri"))
+	if err != nil || redirectURI.Scheme == "" {
+		http.Error(w, "invalid redirect_uri", http.StatusBadRequest)
+		return
+	}
+
+	email := query.Get("login_hint")
+	if email == "" {
+		email = "ana@example.com"
+	}
+
+	code, err := pkg.GenerateOpaqueToken()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	m.mu.Lock()
+	m.grants[code] = mockGrant{
+		clientID:      m.ClientID,
+		redirectURI:   redirectURI.String(),
+		nonce:         query.Get("nonce"),
+		codeChallenge: query.Get("code_challenge"),
+		email:         email,
+		emailVerified: query.Get("email_verified") != "false",
+		expiresAt:     time.Now().Add(mockCodeTTL),
+	}
+	m.mu.Unlock()
+
+	params := redirectURI.Query()
+	params.Set("code", code)
+	params.Set("state", query.Get("state"))
+	redirectURI.RawQuery = params.Encode()
+
+	http.Redirect(w, r, redirectURI.String(), http.StatusFound)
+}
+
+func (m *MockIssuer) token(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.ParseForm() != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+
+	code := r.PostForm.Get("code")
+
+	m.mu.Lock()
+	grant, ok := m.grants[code]
+	delete(m.grants, code)
+	m.mu.Unlock()
+
+	clientID := r.PostForm.Get("client_id")
+	if basicID, _, hasBasic := r.BasicAuth(); hasBasic {
+		clientID, _ = url.QueryUnescape(basicID)
+	}
+
+	if !ok || time.Now().After(grant.expiresAt) ||
+		r.PostForm.Get("grant_type") != "authorization_code" ||
+		clientID != grant.clientID ||
+		r.PostForm.Get("redirect_uri") != grant.redirectURI ||
+		CodeChallenge(r.PostForm.Get("code_verifier")) != grant.codeChallenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	now := time.Now()
+	idToken, err := m.keys.Sign(jwt.MapClaims{
+		"iss":            m.IssuerURL,
+		"sub":            "mock-" + pkg.HashToken(grant.email)[:16],
+		"aud":            grant.clientID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(time.Hour).Unix(),
+		"nonce":          grant.nonce,
+		"email":          grant.email,
+		"email_verified": grant.emailVerified,
+		"name":           strings.Split(grant.email, "@")[0],
+	})
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": code,
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     idToken,
+	})
+}
+
+func (m *MockIssuer) jwks(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, m.keys.JWKS())
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
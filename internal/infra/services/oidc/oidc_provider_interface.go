@@ -0,0 +1,27 @@
+package services
+
+import "context"
+
+// Identity is what a validated ID token says about the person who logged in.
+type Identity struct {
+	Issuer        string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// AuthorizationRequest holds the values bound to one login attempt.
+type AuthorizationRequest struct {
+	State         string
+	Nonce         string
+	CodeChallenge string // S256 PKCE challenge
+}
+
+type OIDCProviderInterface interface {
+	Issuer() string
+	AuthorizationURL(ctx context.Context, request AuthorizationRequest) (string, error)
+	// Exchange redeems an authorization code and returns the identity from
+	// its ID token, once the token is verified and its nonce checked.
+	Exchange(ctx context.Context, code, codeVerifier, nonce string) (*Identity, error)
+}
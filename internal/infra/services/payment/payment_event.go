@@ -0,0 +1,48 @@
+package services
+
+import "time"
+
+const GatewayStripe = "stripe"
+
+type PaymentEventType string
+
+const (
+	PaymentEventCheckoutCompleted   PaymentEventType = "checkout.completed"
+	PaymentEventInvoicePaid         PaymentEventType = "invoice.paid"
+	PaymentEventInvoicePaymentFail  PaymentEventType = "invoice.payment_failed"
+	PaymentEventSubscriptionDeleted PaymentEventType = "subscription.deleted"
+	PaymentEventSubscriptionUpdated PaymentEventType = "subscription.updated"
+	PaymentEventIgnored             PaymentEventType = "ignored"
+)
+
+// GatewayStatus is the state the gateway reports for a subscription.
+type GatewayStatus string
+
+const (
+	GatewayStatusActive   GatewayStatus = "active"
+	GatewayStatusPastDue  GatewayStatus = "past_due" // a renewal failed and is being retried
+	GatewayStatusCanceled GatewayStatus = "canceled"
+	GatewayStatusPending  GatewayStatus = "pending" // waiting for the first payment
+)
+
+// PaymentEvent is a gateway notification about a subscription, independent
+// of the gateway that sent it.
+type PaymentEvent struct {
+	ID                    string
+	Gateway               string
+	Type                  PaymentEventType
+	GatewayType           string // the event type as the gateway names it
+	SubscriptionID        string // ours, when the gateway echoes it back
+	GatewaySubscriptionID string
+	GatewayStatus         GatewayStatus
+	CancelAtPeriodEnd     bool // the subscription won't renew
+	CreatedAt             time.Time
+}
+
+type PaymentWebhookInterface interface {
+	// ParseEvent verifies the signature of a webhook payload and decodes it.
+	ParseEvent(payload []byte, signature string) (*PaymentEvent, error)
+	// DecodeEvent decodes a payload whose signature was already verified,
+	// such as a stored event being replayed.
+	DecodeEvent(payload []byte) (*PaymentEvent, error)
+}
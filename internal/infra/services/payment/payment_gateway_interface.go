@@ -1,6 +1,10 @@
 package services
 
-import "github.com/paulozy/costurai/internal/entity"
+import (
+	"time"
+
+	"github.com/paulozy/costurai/internal/entity"
+)
 
 type PaymentPayload struct {
 	Subscription *entity.Subscription
@@ -9,6 +13,25 @@ type PaymentPayload struct {
 	CancelURL    string
 }
 
+// PlanChangePreview is what changing plans costs right away. Positive amounts
+// are charged, negative ones are credited towards the next invoices.
+type PlanChangePreview struct {
+	Amount        int64
+	Currency      string
+	ProrationDate time.Time
+}
+
 type PaymentGatewayServiceInterface interface {
 	Pay(params PaymentPayload) (string, error)
+	// CancelAtPeriodEnd stops the gateway subscription from renewing, without
+	// refunding the current period.
+	CancelAtPeriodEnd(gatewayID string) error
+	// Resume undoes CancelAtPeriodEnd before the period ends.
+	Resume(gatewayID string) error
+	// PreviewPlanChange prorates moving the gateway subscription to the plan
+	// as of the proration date, without changing it.
+	PreviewPlanChange(gatewayID string, plan entity.Plan, prorationDate time.Time) (PlanChangePreview, error)
+	// ChangePlan moves the gateway subscription to the plan, charging or
+	// crediting the proration as previewed for the same date.
+	ChangePlan(gatewayID string, plan entity.Plan, prorationDate time.Time) error
 }
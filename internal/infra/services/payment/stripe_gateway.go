@@ -2,12 +2,15 @@ package services
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/paulozy/costurai/internal/entity"
 	"github.com/stripe/stripe-go/v82"
 	"github.com/stripe/stripe-go/v82/checkout/session"
+	"github.com/stripe/stripe-go/v82/invoice"
 	"github.com/stripe/stripe-go/v82/price"
 	"github.com/stripe/stripe-go/v82/product"
+	"github.com/stripe/stripe-go/v82/subscription"
 )
 
 type StripeService struct {
@@ -48,9 +51,10 @@ func (s *StripeService) createCheckoutSessionWithPriceID(req PaymentPayload, pri
 				Quantity: stripe.Int64(1),
 			},
 		},
-		Metadata: map[string]string{
-			"subscription_id": req.Subscription.ID,
-			"dressmaker_id":   req.Dressmaker.ID,
+		Metadata: subscriptionMetadata(req),
+		// copied onto the gateway subscription, so its events can be matched
+		SubscriptionData: &stripe.CheckoutSessionSubscriptionDataParams{
+			Metadata: subscriptionMetadata(req),
 		},
 	}
 
@@ -87,9 +91,10 @@ func (s *StripeService) createInlineCheckoutSession(req PaymentPayload) (string,
 				Quantity: stripe.Int64(1),
 			},
 		},
-		Metadata: map[string]string{
-			"subscription_id": req.Subscription.ID,
-			"dressmaker_id":   req.Dressmaker.ID,
+		Metadata: subscriptionMetadata(req),
+		// copied onto the gateway subscription, so its events can be matched
+		SubscriptionData: &stripe.CheckoutSessionSubscriptionDataParams{
+			Metadata: subscriptionMetadata(req),
 		},
 	}
 
@@ -101,9 +106,106 @@ func (s *StripeService) createInlineCheckoutSession(req PaymentPayload) (string,
 	return session.URL, nil
 }
 
+func (s *StripeService) CancelAtPeriodEnd(gatewayID string) error {
+	_, err := subscription.Update(gatewayID, &stripe.SubscriptionParams{
+		CancelAtPeriodEnd: stripe.Bool(true),
+	})
+
+	return err
+}
+
+func (s *StripeService) Resume(gatewayID string) error {
+	_, err := subscription.Update(gatewayID, &stripe.SubscriptionParams{
+		CancelAtPeriodEnd: stripe.Bool(false),
+	})
+
+	return err
+}
+
+// prorationBehavior charges or credits the difference between plans when the
+// change is made, rather than on the next renewal.
+const prorationBehavior = "always_invoice"
+
+func (s *StripeService) PreviewPlanChange(gatewayID string, plan entity.Plan, prorationDate time.Time) (PlanChangePreview, error) {
+	itemID, priceID, err := s.planChangeItem(gatewayID, plan)
+	if err != nil {
+		return PlanChangePreview{}, err
+	}
+
+	preview, err := invoice.CreatePreview(&stripe.InvoiceCreatePreviewParams{
+		Subscription: stripe.String(gatewayID),
+		SubscriptionDetails: &stripe.InvoiceCreatePreviewSubscriptionDetailsParams{
+			Items: []*stripe.InvoiceCreatePreviewSubscriptionDetailsItemParams{
+				{
+					ID:    stripe.String(itemID),
+					Price: stripe.String(priceID),
+				},
+			},
+			ProrationBehavior: stripe.String(prorationBehavior),
+			ProrationDate:     stripe.Int64(prorationDate.Unix()),
+		},
+	})
+	if err != nil {
+		return PlanChangePreview{}, err
+	}
+
+	return PlanChangePreview{
+		Amount:        preview.Total,
+		Currency:      string(preview.Currency),
+		ProrationDate: prorationDate,
+	}, nil
+}
+
+func (s *StripeService) ChangePlan(gatewayID string, plan entity.Plan, prorationDate time.Time) error {
+	itemID, priceID, err := s.planChangeItem(gatewayID, plan)
+	if err != nil {
+		return err
+	}
+
+	_, err = subscription.Update(gatewayID, &stripe.SubscriptionParams{
+		Items: []*stripe.SubscriptionItemsParams{
+			{
+				ID:    stripe.String(itemID),
+				Price: stripe.String(priceID),
+			},
+		},
+		ProrationBehavior: stripe.String(prorationBehavior),
+		ProrationDate:     stripe.Int64(prorationDate.Unix()),
+	})
+
+	return err
+}
+
+// planChangeItem returns the item of the gateway subscription to swap and
+// the price it is swapped to.
+func (s *StripeService) planChangeItem(gatewayID string, plan entity.Plan) (string, string, error) {
+	sub, err := subscription.Get(gatewayID, nil)
+	if err != nil {
+		return "", "", err
+	}
+
+	if sub.Items == nil || len(sub.Items.Data) == 0 {
+		return "", "", fmt.Errorf("subscription %s has no items", gatewayID)
+	}
+
+	priceID, err := s.getStripePriceID(plan)
+	if err != nil {
+		return "", "", err
+	}
+
+	return sub.Items.Data[0].ID, priceID, nil
+}
+
+func subscriptionMetadata(req PaymentPayload) map[string]string {
+	return map[string]string{
+		"subscription_id": req.Subscription.ID,
+		"dressmaker_id":   req.Dressmaker.ID,
+	}
+}
+
 func (s *StripeService) getStripePriceID(plan entity.Plan) (string, error) {
 	productName := s.getProductName(plan)
-	interval := string(plan.Periodicity.PeriodicityType) // "monthly" or "yearly"
+	interval := s.getInterval(plan.Periodicity)
 
 	productList := &stripe.ProductListParams{
 		Active: stripe.Bool(true),
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/stripe/stripe-go/v82"
+	"github.com/stripe/stripe-go/v82/webhook"
+)
+
+func (s *StripeService) ParseEvent(payload []byte, signature string) (*PaymentEvent, error) {
+	if _, err := webhook.ConstructEvent(payload, signature, WebhookSecret); err != nil {
+		return nil, err
+	}
+
+	return s.DecodeEvent(payload)
+}
+
+func (s *StripeService) DecodeEvent(payload []byte) (*PaymentEvent, error) {
+	var event stripe.Event
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, err
+	}
+
+	paymentEvent := &PaymentEvent{
+		ID:          event.ID,
+		Gateway:     GatewayStripe,
+		Type:        PaymentEventIgnored,
+		GatewayType: string(event.Type),
+		CreatedAt:   time.Unix(event.Created, 0),
+	}
+
+	switch event.Type {
+	case stripe.EventTypeCheckoutSessionCompleted:
+		var sess stripe.CheckoutSession
+		if err := json.Unmarshal(event.Data.Raw, &sess); err != nil {
+			return nil, err
+		}
+
+		paymentEvent.Type = PaymentEventCheckoutCompleted
+		paymentEvent.SubscriptionID = sess.Metadata["subscription_id"]
+		if sess.Subscription != nil {
+			paymentEvent.GatewaySubscriptionID = sess.Subscription.ID
+		}
+	case stripe.EventTypeInvoicePaid, stripe.EventTypeInvoicePaymentFailed:
+		var invoice stripe.Invoice
+		if err := json.Unmarshal(event.Data.Raw, &invoice); err != nil {
+			return nil, err
+		}
+
+		if invoice.Parent == nil || invoice.Parent.SubscriptionDetails == nil {
+			// one-off invoices aren't tied to a subscription
+			return paymentEvent, nil
+		}
+
+		if invoice.BillingReason == stripe.InvoiceBillingReasonSubscriptionUpdate {
+			// prorations of a plan change don't renew the period, and a
+			// failed one shows up as a past due subscription
+			return paymentEvent, nil
+		}
+
+		paymentEvent.Type = PaymentEventInvoicePaid
+		if event.Type == stripe.EventTypeInvoicePaymentFailed {
+			paymentEvent.Type = PaymentEventInvoicePaymentFail
+		}
+
+		details := invoice.Parent.SubscriptionDetails
+		paymentEvent.SubscriptionID = details.Metadata["subscription_id"]
+		if details.Subscription != nil {
+			paymentEvent.GatewaySubscriptionID = details.Subscription.ID
+		}
+	case stripe.EventTypeCustomerSubscriptionDeleted, stripe.EventTypeCustomerSubscriptionUpdated:
+		var sub stripe.Subscription
+		if err := json.Unmarshal(event.Data.Raw, &sub); err != nil {
+			return nil, err
+		}
+
+		paymentEvent.Type = PaymentEventSubscriptionUpdated
+		if event.Type == stripe.EventTypeCustomerSubscriptionDeleted {
+			paymentEvent.Type = PaymentEventSubscriptionDeleted
+		}
+
+		paymentEvent.SubscriptionID = sub.Metadata["subscription_id"]
+		paymentEvent.GatewaySubscriptionID = sub.ID
+		paymentEvent.GatewayStatus = stripeGatewayStatus(sub.Status)
+		paymentEvent.CancelAtPeriodEnd = sub.CancelAtPeriodEnd
+	}
+
+	return paymentEvent, nil
+}
+
+func stripeGatewayStatus(status stripe.SubscriptionStatus) GatewayStatus {
+	switch status {
+	case stripe.SubscriptionStatusActive, stripe.SubscriptionStatusTrialing:
+		return GatewayStatusActive
+	case stripe.SubscriptionStatusPastDue, stripe.SubscriptionStatusUnpaid:
+		return GatewayStatusPastDue
+	case stripe.SubscriptionStatusCanceled, stripe.SubscriptionStatusIncompleteExpired, stripe.SubscriptionStatusPaused:
+		return GatewayStatusCanceled
+	default:
+		return GatewayStatusPending
+	}
+}
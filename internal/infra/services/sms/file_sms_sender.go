@@ -0,0 +1,46 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+)
+
+// FileSMSSender appends every message as a JSON line to a file, so tests and
+// developers can read the codes without a real SMS gateway.
+type FileSMSSender struct {
+	mu   sync.Mutex
+	Path string
+}
+
+func NewFileSMSSender(path string) *FileSMSSender {
+	return &FileSMSSender{
+		Path: path,
+	}
+}
+
+func (s *FileSMSSender) Send(ctx context.Context, to string, body string) error {
+	line, err := json.Marshal(map[string]string{
+		"to":     to,
+		"body":   body,
+		"sentAt": time.Now().Format(time.RFC3339),
+	})
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file, err := os.OpenFile(s.Path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(line, '\n'))
+
+	return err
+}
@@ -0,0 +1,124 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/paulozy/costurai/internal/entity"
+	"github.com/paulozy/costurai/internal/infra/database"
+)
+
+const (
+	defaultOTPLength      = 6
+	defaultOTPTTL         = 10 * time.Minute
+	defaultOTPMaxAttempts = 5
+)
+
+// LocalOTPService generates and checks verification codes itself, keeping
+// only their hashes in the repository and delivering them through an
+// SMSSenderInterface.
+type LocalOTPService struct {
+	Repository  database.OTPCodeRepositoryInterface
+	Sender      SMSSenderInterface
+	Length      int
+	TTL         time.Duration
+	MaxAttempts int
+}
+
+func NewLocalOTPService(repo database.OTPCodeRepositoryInterface, sender SMSSenderInterface, length int, ttl time.Duration, maxAttempts int) *LocalOTPService {
+	if length <= 0 {
+		length = defaultOTPLength
+	}
+
+	if ttl <= 0 {
+		ttl = defaultOTPTTL
+	}
+
+	if maxAttempts <= 0 {
+		maxAttempts = defaultOTPMaxAttempts
+	}
+
+	return &LocalOTPService{
+		Repository:  repo,
+		Sender:      sender,
+		Length:      length,
+		TTL:         ttl,
+		MaxAttempts: maxAttempts,
+	}
+}
+
+func (s *LocalOTPService) Send(ctx context.Context, to string) error {
+	code, err := s.generateCode()
+	if err != nil {
+		return err
+	}
+
+	otp, err := entity.NewOTPCode(to, code, s.TTL)
+	if err != nil {
+		return err
+	}
+
+	if err := s.Repository.Save(ctx, otp); err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf("Seu código de verificação Costurai é %s. Ele expira em %d minutos.", code, int(s.TTL.Minutes()))
+
+	return s.Sender.Send(ctx, to, body)
+}
+
+// Verify reports whether the code matches the last one sent to the phone.
+// Codes are discarded once used, expired or after MaxAttempts guesses.
+func (s *LocalOTPService) Verify(ctx context.Context, phone, code string) (bool, error) {
+	otp, err := s.Repository.FindByPhone(ctx, phone)
+	if err != nil {
+		return false, err
+	}
+
+	if otp == nil {
+		return false, nil
+	}
+
+	if otp.HasExpired() {
+		return false, s.Repository.Delete(ctx, phone)
+	}
+
+	// the attempt is counted before comparing, so concurrent guesses can't
+	// all be checked against the same count
+	attempts, err := s.Repository.IncrementAttempts(ctx, phone)
+	if err != nil {
+		return false, err
+	}
+
+	if attempts == 0 {
+		return false, nil
+	}
+
+	if attempts > s.MaxAttempts {
+		return false, s.Repository.Delete(ctx, phone)
+	}
+
+	if !otp.Matches(code) {
+		if attempts == s.MaxAttempts {
+			return false, s.Repository.Delete(ctx, phone)
+		}
+
+		return false, nil
+	}
+
+	return true, s.Repository.Delete(ctx, phone)
+}
+
+func (s *LocalOTPService) generateCode() (string, error) {
+	max := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(s.Length)), nil)
+
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%0*d", s.Length, n), nil
+}
@@ -0,0 +1,19 @@
+package services
+
+import (
+	"context"
+	"log"
+)
+
+// LogSMSSender writes messages to the application log instead of sending
+// them, for local development.
+type LogSMSSender struct{}
+
+func NewLogSMSSender() *LogSMSSender {
+	return &LogSMSSender{}
+}
+
+func (s *LogSMSSender) Send(ctx context.Context, to string, body string) error {
+	log.Printf("sms to=%s body=%q", to, body)
+	return nil
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"log"
+	"time"
+
+	"github.com/paulozy/costurai/configs"
+	"github.com/paulozy/costurai/internal/infra/database"
+)
+
+const (
+	TwilioProvider = "twilio"
+	LocalProvider  = "local"
+
+	LogSender    = "log"
+	FileSender   = "file"
+	TwilioSender = "twilio"
+)
+
+// NewOTPService picks the OTP provider from OTP_PROVIDER. Twilio Verify stays
+// the default; "local" generates the codes in-process and sends them with
+// the sender chosen by SMS_SENDER.
+func NewOTPService(cfg *configs.Config, repo database.OTPCodeRepositoryInterface) OTPServiceInterface {
+	switch cfg.OTPProvider {
+	case TwilioProvider, "":
+		return NewTwilioService(cfg)
+	case LocalProvider:
+		return NewLocalOTPService(
+			repo,
+			NewSMSSender(cfg),
+			cfg.OTPLength,
+			time.Duration(cfg.OTPExpiresIn)*time.Minute,
+			cfg.OTPMaxAttempts,
+		)
+	default:
+		log.Panicf("unknown OTP_PROVIDER %q", cfg.OTPProvider)
+		return nil
+	}
+}
+
+func NewSMSSender(cfg *configs.Config) SMSSenderInterface {
+	switch cfg.SMSSender {
+	case LogSender, "":
+		return NewLogSMSSender()
+	case FileSender:
+		path := cfg.SMSFilePath
+		if path == "" {
+			path = "sms.log"
+		}
+		return NewFileSMSSender(path)
+	case TwilioSender:
+		return NewTwilioSMSSender(cfg)
+	default:
+		log.Panicf("unknown SMS_SENDER %q", cfg.SMSSender)
+		return nil
+	}
+}
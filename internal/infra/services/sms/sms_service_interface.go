@@ -1,6 +1,14 @@
 package services
 
+import "context"
+
 type OTPServiceInterface interface {
-	Send(to string) error
-	Verify(phone string, code string) (bool, error)
+	Send(ctx context.Context, to string) error
+	Verify(ctx context.Context, phone string, code string) (bool, error)
+}
+
+// SMSSenderInterface delivers a text message, used by the local OTP provider
+// to send the codes it generates.
+type SMSSenderInterface interface {
+	Send(ctx context.Context, to string, body string) error
 }
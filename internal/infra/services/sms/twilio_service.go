@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -15,9 +16,7 @@ type TwilioService struct {
 	Channel    string
 }
 
-func NewTwilioService() *TwilioService {
-	configs, _ := configs.LoadConfig("../../../../")
-
+func NewTwilioService(configs *configs.Config) *TwilioService {
 	params := twilio.ClientParams{
 		Username: configs.TwilioSID,
 		Password: configs.TwilioAuthToken,
@@ -33,7 +32,7 @@ func NewTwilioService() *TwilioService {
 	}
 }
 
-func (s *TwilioService) Send(to string) error {
+func (s *TwilioService) Send(ctx context.Context, to string) error {
 	params := &verify.CreateVerificationParams{}
 	params.SetTo(to)
 	params.SetChannel("sms")
@@ -47,7 +46,7 @@ func (s *TwilioService) Send(to string) error {
 	return nil
 }
 
-func (s *TwilioService) Verify(phone, code string) (bool, error) {
+func (s *TwilioService) Verify(ctx context.Context, phone, code string) (bool, error) {
 	params := &verify.CreateVerificationCheckParams{}
 	params.SetTo(phone)
 	params.SetCode(code)
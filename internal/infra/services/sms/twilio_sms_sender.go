@@ -0,0 +1,43 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/paulozy/costurai/configs"
+	"github.com/twilio/twilio-go"
+	api "github.com/twilio/twilio-go/rest/api/v2010"
+)
+
+// TwilioSMSSender sends plain messages through Twilio's Messaging API. Unlike
+// TwilioService it doesn't rely on Twilio Verify to generate the codes.
+type TwilioSMSSender struct {
+	Client *twilio.RestClient
+	From   string
+}
+
+func NewTwilioSMSSender(configs *configs.Config) *TwilioSMSSender {
+	params := twilio.ClientParams{
+		Username: configs.TwilioSID,
+		Password: configs.TwilioAuthToken,
+	}
+
+	client := twilio.NewRestClientWithParams(params)
+	client.SetTimeout(time.Duration(configs.SMSTimeout) * time.Second)
+
+	return &TwilioSMSSender{
+		Client: client,
+		From:   configs.TwilioFromNumber,
+	}
+}
+
+func (s *TwilioSMSSender) Send(ctx context.Context, to string, body string) error {
+	params := &api.CreateMessageParams{}
+	params.SetTo(to)
+	params.SetFrom(s.From)
+	params.SetBody(body)
+
+	_, err := s.Client.Api.CreateMessage(params)
+
+	return err
+}
@@ -0,0 +1,78 @@
+package usecases
+
+import (
+	"context"
+	"strings"
+
+	"github.com/paulozy/costurai/internal/entity"
+	"github.com/paulozy/costurai/internal/infra/database"
+	"github.com/paulozy/costurai/pkg"
+	"github.com/paulozy/costurai/pkg/paginator"
+)
+
+const maxPageLimit = 100
+
+type ListAccountsInput struct {
+	Query   string `form:"q"` // start of the email
+	Enabled *bool  `form:"enabled"`
+
+	Limit int64 `form:"limit"`
+	Page  int64 `form:"page"`
+}
+
+func (input ListAccountsInput) params() database.AccountSearchParams {
+	return database.AccountSearchParams{
+		Query:   strings.ToLower(strings.TrimSpace(input.Query)),
+		Enabled: input.Enabled,
+		Limit:   min(input.Limit, maxPageLimit),
+		Page:    input.Page,
+	}
+}
+
+type ListUsersUseCase struct {
+	UserRepository database.UserRepositoryInterface
+}
+
+func NewListUsersUseCase(userRepo database.UserRepositoryInterface) *ListUsersUseCase {
+	return &ListUsersUseCase{
+		UserRepository: userRepo,
+	}
+}
+
+func (uc *ListUsersUseCase) Execute(ctx context.Context, input ListAccountsInput) (*paginator.Paginate[entity.User], pkg.Error) {
+	params := input.params()
+
+	users, total, err := uc.UserRepository.Search(ctx, params)
+	if err != nil {
+		return nil, pkg.NewInternalServerError(err)
+	}
+
+	return &paginator.Paginate[entity.User]{
+		Items:          &users,
+		PaginationInfo: paginator.NewPaginatation(params.Limit, params.Page, total),
+	}, pkg.Error{}
+}
+
+type ListDressmakersUseCase struct {
+	DressmakerRepository database.DressmakerRepositoryInterface
+}
+
+func NewListDressmakersUseCase(dmRepo database.DressmakerRepositoryInterface) *ListDressmakersUseCase {
+	return &ListDressmakersUseCase{
+		DressmakerRepository: dmRepo,
+	}
+}
+
+func (uc *ListDressmakersUseCase) Execute(ctx context.Context, input ListAccountsInput) (*paginator.Paginate[entity.Dressmaker], pkg.Error) {
+	params := input.params()
+
+	dressmakers, total, err := uc.DressmakerRepository.Search(ctx, params)
+	if err != nil {
+		return nil, pkg.NewInternalServerError(err)
+	}
+
+	return &paginator.Paginate[entity.Dressmaker]{
+		Items:          &dressmakers,
+		PaginationInfo: paginator.NewPaginatation(params.Limit, params.Page, total),
+	}, pkg.Error{}
+}
@@ -0,0 +1,49 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/paulozy/costurai/internal/entity"
+	"github.com/paulozy/costurai/internal/infra/database"
+	"github.com/paulozy/costurai/pkg"
+	"github.com/paulozy/costurai/pkg/paginator"
+)
+
+type ListAuditLogInput struct {
+	ActorID    string `form:"actorId"`
+	TargetType string `form:"targetType"`
+	TargetID   string `form:"targetId"`
+
+	Limit int64 `form:"limit"`
+	Page  int64 `form:"page"`
+}
+
+type ListAuditLogUseCase struct {
+	AuditLogRepository database.AuditLogRepositoryInterface
+}
+
+func NewListAuditLogUseCase(auditLogRepo database.AuditLogRepositoryInterface) *ListAuditLogUseCase {
+	return &ListAuditLogUseCase{
+		AuditLogRepository: auditLogRepo,
+	}
+}
+
+func (uc *ListAuditLogUseCase) Execute(ctx context.Context, input ListAuditLogInput) (*paginator.Paginate[entity.AuditEntry], pkg.Error) {
+	params := database.AuditLogSearchParams{
+		ActorID:    input.ActorID,
+		TargetType: input.TargetType,
+		TargetID:   input.TargetID,
+		Limit:      min(input.Limit, maxPageLimit),
+		Page:       input.Page,
+	}
+
+	entries, total, err := uc.AuditLogRepository.Search(ctx, params)
+	if err != nil {
+		return nil, pkg.NewInternalServerError(err)
+	}
+
+	return &paginator.Paginate[entity.AuditEntry]{
+		Items:          &entries,
+		PaginationInfo: paginator.NewPaginatation(params.Limit, params.Page, total),
+	}, pkg.Error{}
+}
@@ -0,0 +1,172 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/paulozy/costurai/internal/entity"
+	"github.com/paulozy/costurai/internal/infra/database"
+	dressmakerUseCases "github.com/paulozy/costurai/internal/usecase/dressmaker"
+	"github.com/paulozy/costurai/pkg"
+)
+
+const (
+	AuditActionEnable  = "enable"
+	AuditActionDisable = "disable"
+)
+
+type SetAccountStatusInput struct {
+	ActorID string `json:"-"`
+	ID      string `json:"-"`
+	Enabled bool   `json:"-"`
+	Reason  string `json:"reason"`
+}
+
+func (input SetAccountStatusInput) validate() pkg.Error {
+	if input.Reason == "" {
+		return pkg.NewMissingFieldError("reason")
+	}
+
+	if !input.Enabled && input.ID == input.ActorID {
+		return pkg.NewForbiddenError("admins can't disable their own account")
+	}
+
+	return pkg.Error{}
+}
+
+func (input SetAccountStatusInput) action() string {
+	if input.Enabled {
+		return AuditActionEnable
+	}
+
+	return AuditActionDisable
+}
+
+// SetUserStatusUseCase suspends a user, ending their sessions, or reinstates
+// them.
+type SetUserStatusUseCase struct {
+	UserRepository         database.UserRepositoryInterface
+	RefreshTokenRepository database.RefreshTokenRepositoryInterface
+	AuditLogRepository     database.AuditLogRepositoryInterface
+}
+
+func NewSetUserStatusUseCase(
+	userRepo database.UserRepositoryInterface,
+	refreshTokenRepo database.RefreshTokenRepositoryInterface,
+	auditLogRepo database.AuditLogRepositoryInterface,
+) *SetUserStatusUseCase {
+	return &SetUserStatusUseCase{
+		UserRepository:         userRepo,
+		RefreshTokenRepository: refreshTokenRepo,
+		AuditLogRepository:     auditLogRepo,
+	}
+}
+
+func (uc *SetUserStatusUseCase) Execute(ctx context.Context, input SetAccountStatusInput) (*entity.User, pkg.Error) {
+	if ucErr := input.validate(); ucErr.Message != "" {
+		return nil, ucErr
+	}
+
+	user, err := uc.UserRepository.FindByID(ctx, input.ID)
+	if err != nil {
+		return nil, pkg.NewInternalServerError(err)
+	}
+
+	if user == nil {
+		return nil, pkg.NewNotFoundError("user")
+	}
+
+	if input.Enabled {
+		user.Reinstate()
+	} else {
+		user.Suspend()
+	}
+
+	if err := uc.UserRepository.Update(ctx, user); err != nil {
+		return nil, pkg.NewInternalServerError(err)
+	}
+
+	if !input.Enabled {
+		if err := uc.RefreshTokenRepository.RevokeSubject(ctx, user.ID); err != nil {
+			return nil, pkg.NewInternalServerError(err)
+		}
+	}
+
+	err = uc.AuditLogRepository.Create(ctx, entity.NewAuditEntry(
+		input.ActorID, input.action(), entity.AuditTargetUser, user.ID, input.Reason,
+		map[string]any{"enabled": user.Enabled, "suspended": user.Suspended},
+	))
+	if err != nil {
+		return nil, pkg.NewInternalServerError(err)
+	}
+
+	return user, pkg.Error{}
+}
+
+// SetDressmakerStatusUseCase suspends a dressmaker, hiding them from
+// customers and ending their sessions, or reinstates them.
+type SetDressmakerStatusUseCase struct {
+	DressmakerRepository   database.DressmakerRepositoryInterface
+	SubscriptionRepository database.SubscriptionRepositoryInterface
+	RefreshTokenRepository database.RefreshTokenRepositoryInterface
+	AuditLogRepository     database.AuditLogRepositoryInterface
+}
+
+func NewSetDressmakerStatusUseCase(
+	dmRepo database.DressmakerRepositoryInterface,
+	subRepo database.SubscriptionRepositoryInterface,
+	refreshTokenRepo database.RefreshTokenRepositoryInterface,
+	auditLogRepo database.AuditLogRepositoryInterface,
+) *SetDressmakerStatusUseCase {
+	return &SetDressmakerStatusUseCase{
+		DressmakerRepository:   dmRepo,
+		SubscriptionRepository: subRepo,
+		RefreshTokenRepository: refreshTokenRepo,
+		AuditLogRepository:     auditLogRepo,
+	}
+}
+
+func (uc *SetDressmakerStatusUseCase) Execute(ctx context.Context, input SetAccountStatusInput) (*entity.Dressmaker, pkg.Error) {
+	if ucErr := input.validate(); ucErr.Message != "" {
+		return nil, ucErr
+	}
+
+	dressmaker, err := uc.DressmakerRepository.FindByID(ctx, input.ID)
+	if err != nil {
+		return nil, pkg.NewInternalServerError(err)
+	}
+
+	if dressmaker == nil {
+		return nil, pkg.NewNotFoundError("dressmaker")
+	}
+
+	if input.Enabled {
+		sub, err := dressmakerUseCases.FindCurrentSubscription(ctx, uc.SubscriptionRepository, dressmaker)
+		if err != nil {
+			return nil, pkg.NewInternalServerError(err)
+		}
+
+		dressmaker.Reinstate(sub)
+	} else {
+		dressmaker.Suspend()
+	}
+
+	if err := uc.DressmakerRepository.Update(ctx, dressmaker); err != nil {
+		return nil, pkg.NewInternalServerError(err)
+	}
+
+	if !input.Enabled {
+		if err := uc.RefreshTokenRepository.RevokeSubject(ctx, dressmaker.ID); err != nil {
+			return nil, pkg.NewInternalServerError(err)
+		}
+	}
+
+	err = uc.AuditLogRepository.Create(ctx, entity.NewAuditEntry(
+		input.ActorID, input.action(), entity.AuditTargetDressmaker, dressmaker.ID, input.Reason,
+		map[string]any{"enabled": dressmaker.Enabled, "suspended": dressmaker.Suspended},
+	))
+	if err != nil {
+		return nil, pkg.NewInternalServerError(err)
+	}
+
+	return dressmaker, pkg.Error{}
+}
@@ -0,0 +1,199 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/paulozy/costurai/internal/entity"
+	"github.com/paulozy/costurai/internal/infra/database"
+	dressmakerUseCases "github.com/paulozy/costurai/internal/usecase/dressmaker"
+	"github.com/paulozy/costurai/pkg"
+	"github.com/paulozy/costurai/pkg/paginator"
+)
+
+const AuditActionAdjustSubscription = "adjust"
+
+type ListSubscriptionsInput struct {
+	DressmakerID string        `form:"dressmakerId"`
+	Status       entity.Status `form:"status"`
+
+	Limit int64 `form:"limit"`
+	Page  int64 `form:"page"`
+}
+
+type ListSubscriptionsUseCase struct {
+	SubscriptionRepository database.SubscriptionRepositoryInterface
+}
+
+func NewListSubscriptionsUseCase(subRepo database.SubscriptionRepositoryInterface) *ListSubscriptionsUseCase {
+	return &ListSubscriptionsUseCase{
+		SubscriptionRepository: subRepo,
+	}
+}
+
+func (uc *ListSubscriptionsUseCase) Execute(ctx context.Context, input ListSubscriptionsInput) (*paginator.Paginate[entity.Subscription], pkg.Error) {
+	params := database.SubscriptionSearchParams{
+		DressmakerID: input.DressmakerID,
+		Status:       input.Status,
+		Limit:        min(input.Limit, maxPageLimit),
+		Page:         input.Page,
+	}
+
+	subscriptions, total, err := uc.SubscriptionRepository.Search(ctx, params)
+	if err != nil {
+		return nil, pkg.NewInternalServerError(err)
+	}
+
+	return &paginator.Paginate[entity.Subscription]{
+		Items:          &subscriptions,
+		PaginationInfo: paginator.NewPaginatation(params.Limit, params.Page, total),
+	}, pkg.Error{}
+}
+
+type SubscriptionDetails struct {
+	Subscription entity.Subscription `json:"subscription"`
+	Dressmaker   *entity.Dressmaker  `json:"dressmaker"`
+	History      []entity.AuditEntry `json:"history"` // latest admin adjustments
+}
+
+type ShowSubscriptionUseCase struct {
+	SubscriptionRepository database.SubscriptionRepositoryInterface
+	DressmakerRepository   database.DressmakerRepositoryInterface
+	AuditLogRepository     database.AuditLogRepositoryInterface
+}
+
+func NewShowSubscriptionUseCase(
+	subRepo database.SubscriptionRepositoryInterface,
+	dmRepo database.DressmakerRepositoryInterface,
+	auditLogRepo database.AuditLogRepositoryInterface,
+) *ShowSubscriptionUseCase {
+	return &ShowSubscriptionUseCase{
+		SubscriptionRepository: subRepo,
+		DressmakerRepository:   dmRepo,
+		AuditLogRepository:     auditLogRepo,
+	}
+}
+
+// Execute returns the subscription with its dressmaker and the latest
+// adjustments admins made to it.
+func (uc *ShowSubscriptionUseCase) Execute(ctx context.Context, id string) (*SubscriptionDetails, pkg.Error) {
+	subscription, err := uc.SubscriptionRepository.FindByID(ctx, id)
+	if err != nil {
+		return nil, pkg.NewInternalServerError(err)
+	}
+
+	if subscription == nil {
+		return nil, pkg.NewNotFoundError("subscription")
+	}
+
+	dressmaker, err := uc.DressmakerRepository.FindByID(ctx, subscription.DressmakerID)
+	if err != nil {
+		return nil, pkg.NewInternalServerError(err)
+	}
+
+	history, _, err := uc.AuditLogRepository.Search(ctx, database.AuditLogSearchParams{
+		TargetType: entity.AuditTargetSubscription,
+		TargetID:   subscription.ID,
+		Limit:      maxPageLimit,
+		Page:       1,
+	})
+	if err != nil {
+		return nil, pkg.NewInternalServerError(err)
+	}
+
+	return &SubscriptionDetails{
+		Subscription: *subscription,
+		Dressmaker:   dressmaker,
+		History:      history,
+	}, pkg.Error{}
+}
+
+type AdjustSubscriptionInput struct {
+	ActorID    string         `json:"-"`
+	ID         string         `json:"-"`
+	Status     *entity.Status `json:"status"`
+	ExpiresAt  *time.Time     `json:"expiresAt"`
+	GraceUntil *time.Time     `json:"graceUntil"`
+	Reason     string         `json:"reason"`
+}
+
+// AdjustSubscriptionUseCase lets an admin correct a subscription by hand,
+// e.g. to extend it after a payment the gateway failed to report. It doesn't
+// touch the subscription at the payment gateway, but lists or hides the
+// dressmaker accordingly.
+type AdjustSubscriptionUseCase struct {
+	SubscriptionRepository database.SubscriptionRepositoryInterface
+	DressmakerRepository   database.DressmakerRepositoryInterface
+	AuditLogRepository     database.AuditLogRepositoryInterface
+}
+
+func NewAdjustSubscriptionUseCase(
+	subRepo database.SubscriptionRepositoryInterface,
+	dmRepo database.DressmakerRepositoryInterface,
+	auditLogRepo database.AuditLogRepositoryInterface,
+) *AdjustSubscriptionUseCase {
+	return &AdjustSubscriptionUseCase{
+		SubscriptionRepository: subRepo,
+		DressmakerRepository:   dmRepo,
+		AuditLogRepository:     auditLogRepo,
+	}
+}
+
+func (uc *AdjustSubscriptionUseCase) Execute(ctx context.Context, input AdjustSubscriptionInput) (*entity.Subscription, pkg.Error) {
+	if input.Reason == "" {
+		return nil, pkg.NewMissingFieldError("reason")
+	}
+
+	changes := map[string]any{}
+	if input.Status != nil {
+		changes["status"] = *input.Status
+	}
+	if input.ExpiresAt != nil {
+		changes["expiresAt"] = *input.ExpiresAt
+	}
+	if input.GraceUntil != nil {
+		changes["graceUntil"] = *input.GraceUntil
+	}
+
+	if len(changes) == 0 {
+		return nil, pkg.Error{
+			Message: "one of status, expiresAt or graceUntil is required",
+			Status:  400,
+		}
+	}
+
+	subscription, err := uc.SubscriptionRepository.FindByID(ctx, input.ID)
+	if err != nil {
+		return nil, pkg.NewInternalServerError(err)
+	}
+
+	if subscription == nil {
+		return nil, pkg.NewNotFoundError("subscription")
+	}
+
+	err = subscription.Adjust(entity.SubscriptionAdjustment{
+		Status:     input.Status,
+		ExpiresAt:  input.ExpiresAt,
+		GraceUntil: input.GraceUntil,
+	})
+	if err != nil {
+		return nil, pkg.NewBadRequestError(err)
+	}
+
+	if err := uc.SubscriptionRepository.Update(ctx, subscription); err != nil {
+		return nil, pkg.NewInternalServerError(err)
+	}
+
+	if err := dressmakerUseCases.ApplySubscription(ctx, uc.DressmakerRepository, uc.SubscriptionRepository, subscription); err != nil {
+		return nil, pkg.NewInternalServerError(err)
+	}
+
+	err = uc.AuditLogRepository.Create(ctx, entity.NewAuditEntry(
+		input.ActorID, AuditActionAdjustSubscription, entity.AuditTargetSubscription, subscription.ID, input.Reason, changes,
+	))
+	if err != nil {
+		return nil, pkg.NewInternalServerError(err)
+	}
+
+	return subscription, pkg.Error{}
+}
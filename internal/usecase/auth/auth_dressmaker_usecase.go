@@ -1,6 +1,9 @@
 package usecases
 
 import (
+	"context"
+
+	"github.com/paulozy/costurai/configs"
 	"github.com/paulozy/costurai/internal/infra/database"
 	"github.com/paulozy/costurai/internal/usecase/auth/dtos"
 	"github.com/paulozy/costurai/pkg"
@@ -8,49 +11,65 @@ import (
 
 type AuthDressmakerUseCase struct {
 	DressMakerRepository database.DressmakerRepositoryInterface
+	sessions             sessionIssuer
+	guard                loginGuard
 }
 
 type NewAuthDressmakerUseCaseInput struct {
-	DressmakerRepository database.DressmakerRepositoryInterface
+	DressmakerRepository   database.DressmakerRepositoryInterface
+	RefreshTokenRepository database.RefreshTokenRepositoryInterface
+	ThrottleRepository     database.ThrottleRepositoryInterface
+	Config                 *configs.Config
 }
 
 func NewDressmakerAuthenticationUseCase(repositories NewAuthDressmakerUseCaseInput) *AuthDressmakerUseCase {
 	return &AuthDressmakerUseCase{
 		DressMakerRepository: repositories.DressmakerRepository,
+		sessions:             newSessionIssuer(repositories.RefreshTokenRepository, repositories.Config),
+		guard:                newLoginGuard(AccountTypeDressmaker, repositories.ThrottleRepository, repositories.Config),
 	}
 }
 
-func (useCase *AuthDressmakerUseCase) Execute(data dtos.AuthenticationInput) (dtos.AuthDressmakerOutput, pkg.Error) {
-	dressmakerExists, err := useCase.DressMakerRepository.Exists(data.Email)
+func (useCase *AuthDressmakerUseCase) Execute(ctx context.Context, data dtos.AuthenticationInput) (dtos.AuthDressmakerOutput, pkg.Error) {
+	if err := useCase.guard.allow(ctx, data); err.Message != "" {
+		return dtos.AuthDressmakerOutput{}, err
+	}
+
+	dressmakerExists, err := useCase.DressMakerRepository.Exists(ctx, data.Email)
 	if err != nil {
 		return dtos.AuthDressmakerOutput{}, pkg.NewInternalServerError(err)
 	}
 
 	if !dressmakerExists {
-		return dtos.AuthDressmakerOutput{}, pkg.NewInvalidCredentialsError()
+		return dtos.AuthDressmakerOutput{}, useCase.guard.failed(ctx, data)
 	}
 
-	dressmaker, err := useCase.DressMakerRepository.FindByEmail(data.Email)
+	dressmaker, err := useCase.DressMakerRepository.FindByEmail(ctx, data.Email)
 	if err != nil {
 		return dtos.AuthDressmakerOutput{}, pkg.NewInternalServerError(err)
 	}
 
 	isValidPass := pkg.CompareHashAndPassword(dressmaker.Password, data.Password)
 	if !isValidPass {
-		return dtos.AuthDressmakerOutput{}, pkg.NewInvalidCredentialsError()
+		return dtos.AuthDressmakerOutput{}, useCase.guard.failed(ctx, data)
+	}
+
+	if err := useCase.guard.succeeded(ctx, data); err != nil {
+		return dtos.AuthDressmakerOutput{}, pkg.NewInternalServerError(err)
+	}
+
+	if dressmaker.Suspended {
+		return dtos.AuthDressmakerOutput{}, newAccountSuspendedError()
 	}
 
-	token, err := pkg.GenerateToken(pkg.GenerateTokenInput{
-		Issuer:  dressmaker.Name,
-		Subject: dressmaker.ID,
-	})
+	session, err := useCase.sessions.issue(ctx, "", dressmaker.ID, dressmaker.Name, pkg.RoleDressmaker)
 	if err != nil {
 		return dtos.AuthDressmakerOutput{}, pkg.NewInternalServerError(err)
 	}
 
 	response := dtos.AuthDressmakerOutput{
-		Token: token,
-		User:  *dressmaker,
+		Session: session,
+		User:    *dressmaker,
 	}
 
 	return response, pkg.Error{}
@@ -1,6 +1,9 @@
 package usecases
 
 import (
+	"context"
+
+	"github.com/paulozy/costurai/configs"
 	"github.com/paulozy/costurai/internal/infra/database"
 	"github.com/paulozy/costurai/internal/usecase/auth/dtos"
 	"github.com/paulozy/costurai/pkg"
@@ -8,49 +11,70 @@ import (
 
 type AuthUserUseCase struct {
 	UserRepository database.UserRepositoryInterface
+	sessions       sessionIssuer
+	guard          loginGuard
 }
 
 type NewAuthUserUseCaseInput struct {
-	UserRepository database.UserRepositoryInterface
+	UserRepository         database.UserRepositoryInterface
+	RefreshTokenRepository database.RefreshTokenRepositoryInterface
+	ThrottleRepository     database.ThrottleRepositoryInterface
+	Config                 *configs.Config
 }
 
 func NewUserAuthUseCase(repositories NewAuthUserUseCaseInput) *AuthUserUseCase {
 	return &AuthUserUseCase{
 		UserRepository: repositories.UserRepository,
+		sessions:       newSessionIssuer(repositories.RefreshTokenRepository, repositories.Config),
+		guard:          newLoginGuard(AccountTypeUser, repositories.ThrottleRepository, repositories.Config),
 	}
 }
 
-func (useCase *AuthUserUseCase) UserExecute(data dtos.AuthenticationInput) (dtos.AuthUserOutput, pkg.Error) {
-	userExists, err := useCase.UserRepository.Exists(data.Email)
+func (useCase *AuthUserUseCase) Execute(ctx context.Context, data dtos.AuthenticationInput) (dtos.AuthUserOutput, pkg.Error) {
+	if err := useCase.guard.allow(ctx, data); err.Message != "" {
+		return dtos.AuthUserOutput{}, err
+	}
+
+	userExists, err := useCase.UserRepository.Exists(ctx, data.Email)
 	if err != nil {
 		return dtos.AuthUserOutput{}, pkg.NewInternalServerError(err)
 	}
 
 	if !userExists {
-		return dtos.AuthUserOutput{}, pkg.NewInvalidCredentialsError()
+		return dtos.AuthUserOutput{}, useCase.guard.failed(ctx, data)
 	}
 
-	user, err := useCase.UserRepository.FindByEmail(data.Email)
+	user, err := useCase.UserRepository.FindByEmail(ctx, data.Email)
 	if err != nil {
 		return dtos.AuthUserOutput{}, pkg.NewInternalServerError(err)
 	}
 
 	isValidPass := pkg.CompareHashAndPassword(user.Password, data.Password)
 	if !isValidPass {
-		return dtos.AuthUserOutput{}, pkg.NewInvalidCredentialsError()
+		return dtos.AuthUserOutput{}, useCase.guard.failed(ctx, data)
+	}
+
+	role := pkg.RoleUser
+	if user.Admin {
+		role = pkg.RoleAdmin
+	}
+
+	if err := useCase.guard.succeeded(ctx, data); err != nil {
+		return dtos.AuthUserOutput{}, pkg.NewInternalServerError(err)
+	}
+
+	if user.Suspended {
+		return dtos.AuthUserOutput{}, newAccountSuspendedError()
 	}
 
-	token, err := pkg.GenerateToken(pkg.GenerateTokenInput{
-		Issuer:  user.Name,
-		Subject: user.ID,
-	})
+	session, err := useCase.sessions.issue(ctx, "", user.ID, user.Name, role)
 	if err != nil {
 		return dtos.AuthUserOutput{}, pkg.NewInternalServerError(err)
 	}
 
 	response := dtos.AuthUserOutput{
-		Token: token,
-		User:  *user,
+		Session: session,
+		User:    *user,
 	}
 
 	return response, pkg.Error{}
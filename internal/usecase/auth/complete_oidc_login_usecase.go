@@ -0,0 +1,312 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/paulozy/costurai/configs"
+	"github.com/paulozy/costurai/internal/entity"
+	"github.com/paulozy/costurai/internal/infra/database"
+	oidc "github.com/paulozy/costurai/internal/infra/services/oidc"
+	"github.com/paulozy/costurai/internal/usecase/auth/dtos"
+	dressmakerUseCases "github.com/paulozy/costurai/internal/usecase/dressmaker"
+	"github.com/paulozy/costurai/pkg"
+)
+
+type CompleteOIDCLoginUseCase struct {
+	Provider                 oidc.OIDCProviderInterface
+	OIDCLoginStateRepository database.OIDCLoginStateRepositoryInterface
+	IdentityRepository       database.IdentityRepositoryInterface
+	UserRepository           database.UserRepositoryInterface
+	DressmakerRepository     database.DressmakerRepositoryInterface
+	RefreshTokenRepository   database.RefreshTokenRepositoryInterface
+	OneTimeTokenRepository   database.OneTimeTokenRepositoryInterface
+	CreateDressmakerUseCase  *dressmakerUseCases.CreateDressMakerUseCase
+	sessions                 sessionIssuer
+}
+
+type NewCompleteOIDCLoginUseCaseInput struct {
+	Provider                 oidc.OIDCProviderInterface
+	OIDCLoginStateRepository database.OIDCLoginStateRepositoryInterface
+	IdentityRepository       database.IdentityRepositoryInterface
+	UserRepository           database.UserRepositoryInterface
+	DressmakerRepository     database.DressmakerRepositoryInterface
+	RefreshTokenRepository   database.RefreshTokenRepositoryInterface
+	OneTimeTokenRepository   database.OneTimeTokenRepositoryInterface
+	Config                   *configs.Config
+}
+
+func NewCompleteOIDCLoginUseCase(input NewCompleteOIDCLoginUseCaseInput) *CompleteOIDCLoginUseCase {
+	return &CompleteOIDCLoginUseCase{
+		Provider:                 input.Provider,
+		OIDCLoginStateRepository: input.OIDCLoginStateRepository,
+		IdentityRepository:       input.IdentityRepository,
+		UserRepository:           input.UserRepository,
+		DressmakerRepository:     input.DressmakerRepository,
+		RefreshTokenRepository:   input.RefreshTokenRepository,
+		OneTimeTokenRepository:   input.OneTimeTokenRepository,
+		CreateDressmakerUseCase:  dressmakerUseCases.NewCreateDressMakerUseCase(input.DressmakerRepository),
+		sessions:                 newSessionIssuer(input.RefreshTokenRepository, input.Config),
+	}
+}
+
+// Execute redeems the code the provider redirected back with and logs into
+// the account linked to the provider identity. The first login links the
+// account with the same verified email, or creates one.
+func (uc *CompleteOIDCLoginUseCase) Execute(ctx context.Context, input dtos.CompleteOIDCLoginInput) (dtos.OIDCLoginOutput, pkg.Error) {
+	if input.State == "" {
+		return dtos.OIDCLoginOutput{}, pkg.NewMissingFieldError("state")
+	}
+
+	if input.Code == "" {
+		return dtos.OIDCLoginOutput{}, pkg.NewMissingFieldError("code")
+	}
+
+	state, err := uc.OIDCLoginStateRepository.Consume(ctx, pkg.HashToken(input.State))
+	if err != nil {
+		return dtos.OIDCLoginOutput{}, pkg.NewInternalServerError(err)
+	}
+
+	if state == nil || state.HasExpired() {
+		return dtos.OIDCLoginOutput{}, pkg.Error{
+			Message: "Invalid or expired login state",
+			Status:  400,
+		}
+	}
+
+	identity, err := uc.Provider.Exchange(ctx, input.Code, state.CodeVerifier, state.Nonce)
+	if err != nil {
+		return dtos.OIDCLoginOutput{}, pkg.Error{
+			Message: "Could not verify the identity provider login",
+			Error:   err.Error(),
+			Status:  401,
+		}
+	}
+
+	if identity.Email == "" || !identity.EmailVerified {
+		return dtos.OIDCLoginOutput{}, pkg.NewForbiddenError("the identity provider has not verified the email")
+	}
+
+	output := dtos.OIDCLoginOutput{AccountType: state.AccountType}
+
+	link, err := uc.IdentityRepository.FindByIssuerAndSubject(ctx, identity.Issuer, identity.Subject, state.AccountType)
+	if err != nil {
+		return dtos.OIDCLoginOutput{}, pkg.NewInternalServerError(err)
+	}
+
+	var subject, name string
+	var role pkg.Role
+	var suspended bool
+	var loginErr pkg.Error
+
+	if state.AccountType == AccountTypeDressmaker {
+		output.Dressmaker, output.Created, loginErr = uc.dressmakerAccount(ctx, link, identity, input.Profile)
+		if loginErr.Message == "" {
+			subject, name, role = output.Dressmaker.ID, output.Dressmaker.Name, pkg.RoleDressmaker
+			suspended = output.Dressmaker.Suspended
+		}
+	} else {
+		output.User, output.Created, loginErr = uc.userAccount(ctx, link, identity, input.Profile)
+		if loginErr.Message == "" {
+			subject, name, role = output.User.ID, output.User.Name, pkg.RoleUser
+			suspended = output.User.Suspended
+			if output.User.Admin {
+				role = pkg.RoleAdmin
+			}
+		}
+	}
+
+	if loginErr.Message != "" {
+		return dtos.OIDCLoginOutput{}, loginErr
+	}
+
+	if suspended {
+		return dtos.OIDCLoginOutput{}, newAccountSuspendedError()
+	}
+
+	if link == nil {
+		// the provider vouched for the address the account is linked by, so
+		// an unverified account is taken back from whoever signed it up
+		if ucErr := uc.verifyEmail(ctx, output); ucErr.Message != "" {
+			return dtos.OIDCLoginOutput{}, ucErr
+		}
+
+		err = uc.IdentityRepository.Create(ctx, entity.NewIdentity(identity.Issuer, identity.Subject, state.AccountType, subject, identity.Email))
+		if err != nil {
+			return dtos.OIDCLoginOutput{}, pkg.NewInternalServerError(err)
+		}
+	}
+
+	output.Session, err = uc.sessions.issue(ctx, "", subject, name, role)
+	if err != nil {
+		return dtos.OIDCLoginOutput{}, pkg.NewInternalServerError(err)
+	}
+
+	return output, pkg.Error{}
+}
+
+func (uc *CompleteOIDCLoginUseCase) verifyEmail(ctx context.Context, output dtos.OIDCLoginOutput) pkg.Error {
+	var err error
+
+	if output.Dressmaker != nil && !output.Dressmaker.EmailVerified {
+		if !output.Created {
+			err = uc.dropCredentials(ctx, output.Dressmaker.ID, output.Dressmaker.ChangePassword)
+		}
+
+		if err == nil {
+			output.Dressmaker.VerifyEmail()
+			err = uc.DressmakerRepository.Update(ctx, output.Dressmaker)
+		}
+	}
+
+	if output.User != nil && !output.User.EmailVerified {
+		if !output.Created {
+			err = uc.dropCredentials(ctx, output.User.ID, output.User.ChangePassword)
+		}
+
+		if err == nil {
+			output.User.VerifyEmail()
+			err = uc.UserRepository.Update(ctx, output.User)
+		}
+	}
+
+	if err != nil {
+		return pkg.NewInternalServerError(err)
+	}
+
+	return pkg.Error{}
+}
+
+// dropCredentials locks out whoever signed the account up with an address
+// they never proved to own, before its owner is linked to it through the
+// provider: the password is replaced, and sessions and pending email changes
+// are revoked. The owner can set a password again with a reset.
+func (uc *CompleteOIDCLoginUseCase) dropCredentials(ctx context.Context, subject string, changePassword func(string) error) error {
+	password, err := pkg.GenerateOpaqueToken()
+	if err != nil {
+		return err
+	}
+
+	if err := changePassword(password); err != nil {
+		return err
+	}
+
+	if err := uc.RefreshTokenRepository.RevokeSubject(ctx, subject); err != nil {
+		return err
+	}
+
+	return uc.OneTimeTokenRepository.DeleteBySubject(ctx, entity.PurposeEmailChange, subject)
+}
+
+func (uc *CompleteOIDCLoginUseCase) userAccount(ctx context.Context, link *entity.Identity, identity *oidc.Identity, profile *dtos.OIDCProfileInput) (*entity.User, bool, pkg.Error) {
+	if link != nil {
+		user, err := uc.UserRepository.FindByID(ctx, link.AccountID)
+		if err != nil {
+			return nil, false, pkg.NewInternalServerError(err)
+		}
+
+		if user == nil {
+			return nil, false, pkg.NewNotFoundError("user")
+		}
+
+		return user, false, pkg.Error{}
+	}
+
+	user, err := uc.UserRepository.FindByEmail(ctx, identity.Email)
+	if err != nil {
+		return nil, false, pkg.NewInternalServerError(err)
+	}
+
+	if user != nil {
+		return user, false, pkg.Error{}
+	}
+
+	name := identity.Name
+	var location entity.Location
+	if profile != nil {
+		if profile.Name != "" {
+			name = profile.Name
+		}
+
+		if profile.Location != nil {
+			location = *profile.Location
+		}
+	}
+
+	if name == "" {
+		return nil, false, pkg.NewMissingFieldError("profile.name")
+	}
+
+	// the account can only be logged into through the provider until its
+	// owner sets a password with a reset
+	password, err := pkg.GenerateOpaqueToken()
+	if err != nil {
+		return nil, false, pkg.NewInternalServerError(err)
+	}
+
+	user, err = entity.NewUser(identity.Email, password, name, location)
+	if err != nil {
+		return nil, false, pkg.NewInternalServerError(err)
+	}
+
+	err = uc.UserRepository.Create(ctx, user)
+	if err != nil {
+		return nil, false, pkg.NewInternalServerError(err)
+	}
+
+	return user, true, pkg.Error{}
+}
+
+func (uc *CompleteOIDCLoginUseCase) dressmakerAccount(ctx context.Context, link *entity.Identity, identity *oidc.Identity, profile *dtos.OIDCProfileInput) (*entity.Dressmaker, bool, pkg.Error) {
+	if link != nil {
+		dressmaker, err := uc.DressmakerRepository.FindByID(ctx, link.AccountID)
+		if err != nil {
+			return nil, false, pkg.NewInternalServerError(err)
+		}
+
+		if dressmaker == nil {
+			return nil, false, pkg.NewNotFoundError("dressmaker")
+		}
+
+		return dressmaker, false, pkg.Error{}
+	}
+
+	dressmaker, err := uc.DressmakerRepository.FindByEmail(ctx, identity.Email)
+	if err != nil {
+		return nil, false, pkg.NewInternalServerError(err)
+	}
+
+	if dressmaker != nil {
+		return dressmaker, false, pkg.Error{}
+	}
+
+	if profile == nil {
+		return nil, false, pkg.Error{
+			Message: "profile is required to create a dressmaker account",
+			Status:  422,
+		}
+	}
+
+	password, err := pkg.GenerateOpaqueToken()
+	if err != nil {
+		return nil, false, pkg.NewInternalServerError(err)
+	}
+
+	name := profile.Name
+	if name == "" {
+		name = identity.Name
+	}
+
+	dressmaker, createErr := uc.CreateDressmakerUseCase.Execute(ctx, entity.CreateDressmakerInput{
+		Email:    identity.Email,
+		Password: password,
+		Name:     name,
+		Contact:  profile.Contact,
+		Services: profile.Services,
+		Address:  profile.Address,
+	})
+	if createErr.Message != "" {
+		return nil, false, createErr
+	}
+
+	return dressmaker, true, pkg.Error{}
+}
@@ -0,0 +1,67 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/paulozy/costurai/internal/entity"
+	"github.com/paulozy/costurai/internal/infra/database"
+	"github.com/paulozy/costurai/internal/usecase/auth/dtos"
+	"github.com/paulozy/costurai/pkg"
+)
+
+type ConfirmEmailChangeUseCase struct {
+	accounts emailAccounts
+	mailer   emailTokenMailer
+}
+
+type NewConfirmEmailChangeUseCaseInput struct {
+	DressmakerRepository   database.DressmakerRepositoryInterface
+	UserRepository         database.UserRepositoryInterface
+	OneTimeTokenRepository database.OneTimeTokenRepositoryInterface
+}
+
+func NewConfirmEmailChangeUseCase(input NewConfirmEmailChangeUseCaseInput) *ConfirmEmailChangeUseCase {
+	return &ConfirmEmailChangeUseCase{
+		accounts: emailAccounts{
+			users:       input.UserRepository,
+			dressmakers: input.DressmakerRepository,
+		},
+		mailer: emailTokenMailer{repository: input.OneTimeTokenRepository},
+	}
+}
+
+// Execute swaps the account's email for the address the link was sent to,
+// which counts as verified.
+func (uc *ConfirmEmailChangeUseCase) Execute(ctx context.Context, input dtos.EmailTokenInput) pkg.Error {
+	token, ucErr := uc.mailer.consume(ctx, entity.PurposeEmailChange, input.Token)
+	if ucErr.Message != "" {
+		return ucErr
+	}
+
+	account, err := uc.accounts.find(ctx, token.AccountType, token.Subject)
+	if err != nil {
+		return pkg.NewInternalServerError(err)
+	}
+
+	if account == nil {
+		return invalidEmailTokenError()
+	}
+
+	// someone else may have signed up with the address since it was requested
+	taken, err := uc.accounts.taken(ctx, token.AccountType, token.Email)
+	if err != nil {
+		return pkg.NewInternalServerError(err)
+	}
+
+	if taken {
+		return pkg.NewEntityAlreadyExistsError(token.AccountType)
+	}
+
+	account.changeEmail(token.Email)
+
+	if err := uc.accounts.save(ctx, account); err != nil {
+		return pkg.NewInternalServerError(err)
+	}
+
+	return pkg.Error{}
+}
@@ -5,14 +5,58 @@ import "github.com/paulozy/costurai/internal/entity"
 type AuthenticationInput struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
+	IP       string `json:"-"` // client address, used to throttle failed logins
+}
+
+// Session is the pair of tokens handed out on login and on every refresh.
+type Session struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refreshToken"`
+	ExpiresIn    int64  `json:"expiresIn"` // access token lifetime in seconds
+}
+
+type RefreshTokenInput struct {
+	RefreshToken string `json:"refreshToken"`
 }
 
 type AuthDressmakerOutput struct {
-	Token string            `json:"token"`
-	User  entity.Dressmaker `json:"user"`
+	Session
+	User entity.Dressmaker `json:"user"`
 }
 
 type AuthUserOutput struct {
-	Token string      `json:"token"`
-	User  entity.User `json:"user"`
+	Session
+	User entity.User `json:"user"`
+}
+
+type RequestPasswordResetInput struct {
+	Email       string `json:"email"`
+	AccountType string `json:"accountType"` // "user" or "dressmaker"
+	Channel     string `json:"channel"`     // "email" (default) or "sms"
+}
+
+// ResetPasswordInput takes either the token of an emailed link, or the code
+// texted to the account's verified phone together with the account.
+type ResetPasswordInput struct {
+	Token       string `json:"token"`
+	Email       string `json:"email"`
+	AccountType string `json:"accountType"`
+	Code        string `json:"code"`
+	Password    string `json:"password"`
+}
+
+// EmailAccountInput identifies the signed in account, or the account just
+// created, an email flow applies to.
+type EmailAccountInput struct {
+	AccountType string `json:"-"`
+	Subject     string `json:"-"`
+}
+
+type RequestEmailChangeInput struct {
+	EmailAccountInput
+	Email string `json:"email"` // the new address
+}
+
+type EmailTokenInput struct {
+	Token string `json:"token"`
 }
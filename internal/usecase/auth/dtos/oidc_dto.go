@@ -0,0 +1,35 @@
+package dtos
+
+import "github.com/paulozy/costurai/internal/entity"
+
+type StartOIDCLoginInput struct {
+	AccountType string `json:"accountType"` // "user" or "dressmaker"
+}
+
+type StartOIDCLoginOutput struct {
+	AuthorizationURL string `json:"authorizationUrl"`
+}
+
+type CompleteOIDCLoginInput struct {
+	State string `json:"state"`
+	Code  string `json:"code"`
+	// Profile fills in accounts created on their first login. Dressmakers
+	// must send contact, services and address; users may send a location.
+	Profile *OIDCProfileInput `json:"profile,omitempty"`
+}
+
+type OIDCProfileInput struct {
+	Name     string           `json:"name,omitempty"`
+	Contact  string           `json:"contact,omitempty"`
+	Services []string         `json:"services,omitempty"`
+	Address  entity.Address   `json:"address,omitempty"`
+	Location *entity.Location `json:"location,omitempty"`
+}
+
+type OIDCLoginOutput struct {
+	Session
+	AccountType string             `json:"accountType"`
+	Created     bool               `json:"created"` // the account was created by this login
+	User        *entity.User       `json:"user,omitempty"`
+	Dressmaker  *entity.Dressmaker `json:"dressmaker,omitempty"`
+}
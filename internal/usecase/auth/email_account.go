@@ -0,0 +1,205 @@
+package usecases
+
+import (
+	"context"
+	"log"
+	"net/url"
+	"time"
+
+	"github.com/paulozy/costurai/configs"
+	"github.com/paulozy/costurai/internal/entity"
+	"github.com/paulozy/costurai/internal/infra/database"
+	notification "github.com/paulozy/costurai/internal/infra/services/notification"
+	"github.com/paulozy/costurai/pkg"
+)
+
+const defaultEmailTokenTTL = 48 * time.Hour
+
+// emailAccount is the user or dressmaker an email flow applies to.
+type emailAccount struct {
+	user       *entity.User
+	dressmaker *entity.Dressmaker
+}
+
+func (a emailAccount) id() string {
+	if a.dressmaker != nil {
+		return a.dressmaker.ID
+	}
+
+	return a.user.ID
+}
+
+func (a emailAccount) name() string {
+	if a.dressmaker != nil {
+		return a.dressmaker.Name
+	}
+
+	return a.user.Name
+}
+
+func (a emailAccount) email() string {
+	if a.dressmaker != nil {
+		return a.dressmaker.Email
+	}
+
+	return a.user.Email
+}
+
+func (a emailAccount) emailVerified() bool {
+	if a.dressmaker != nil {
+		return a.dressmaker.EmailVerified
+	}
+
+	return a.user.EmailVerified
+}
+
+func (a emailAccount) verifyEmail() {
+	if a.dressmaker != nil {
+		a.dressmaker.VerifyEmail()
+		return
+	}
+
+	a.user.VerifyEmail()
+}
+
+func (a emailAccount) changeEmail(email string) {
+	if a.dressmaker != nil {
+		a.dressmaker.ChangeEmail(email)
+		return
+	}
+
+	a.user.ChangeEmail(email)
+}
+
+type emailAccounts struct {
+	users       database.UserRepositoryInterface
+	dressmakers database.DressmakerRepositoryInterface
+}
+
+// find returns nil when there is no account with the ID.
+func (r emailAccounts) find(ctx context.Context, accountType, id string) (*emailAccount, error) {
+	if accountType == AccountTypeDressmaker {
+		dressmaker, err := r.dressmakers.FindByID(ctx, id)
+		if err != nil || dressmaker == nil {
+			return nil, err
+		}
+
+		return &emailAccount{dressmaker: dressmaker}, nil
+	}
+
+	user, err := r.users.FindByID(ctx, id)
+	if err != nil || user == nil {
+		return nil, err
+	}
+
+	return &emailAccount{user: user}, nil
+}
+
+// taken reports whether another account of the same type uses the email.
+func (r emailAccounts) taken(ctx context.Context, accountType, email string) (bool, error) {
+	if accountType == AccountTypeDressmaker {
+		return r.dressmakers.Exists(ctx, email)
+	}
+
+	return r.users.Exists(ctx, email)
+}
+
+func (r emailAccounts) save(ctx context.Context, account *emailAccount) error {
+	if account.dressmaker != nil {
+		return r.dressmakers.Update(ctx, account.dressmaker)
+	}
+
+	return r.users.Update(ctx, account.user)
+}
+
+// emailTokenMailer mails single-use links that prove control of an address.
+type emailTokenMailer struct {
+	repository database.OneTimeTokenRepositoryInterface
+	notifier   notification.NotificationServiceInterface
+	ttl        time.Duration
+}
+
+func newEmailTokenMailer(repo database.OneTimeTokenRepositoryInterface, notifier notification.NotificationServiceInterface, cfg *configs.Config) emailTokenMailer {
+	mailer := emailTokenMailer{
+		repository: repo,
+		notifier:   notifier,
+		ttl:        defaultEmailTokenTTL,
+	}
+
+	if cfg != nil && cfg.EmailTokenExpiresIn > 0 {
+		mailer.ttl = time.Duration(cfg.EmailTokenExpiresIn) * time.Hour
+	}
+
+	return mailer
+}
+
+// issue replaces any pending token of the purpose with a new one bound to
+// the email, returning its plain secret.
+func (m emailTokenMailer) issue(ctx context.Context, purpose entity.OneTimeTokenPurpose, accountType, subject, email string) (string, error) {
+	if err := m.repository.DeleteBySubject(ctx, purpose, subject); err != nil {
+		return "", err
+	}
+
+	token, plain, err := entity.NewOneTimeToken(purpose, subject, accountType, email, m.ttl)
+	if err != nil {
+		return "", err
+	}
+
+	if err := m.repository.Create(ctx, token); err != nil {
+		return "", err
+	}
+
+	return plain, nil
+}
+
+// notify delivers the message, logging failures since the owner can ask for
+// another link.
+func (m emailTokenMailer) notify(ctx context.Context, message notification.Message) {
+	if err := m.notifier.Notify(ctx, message); err != nil {
+		log.Printf("could not send %q to %s: %v", message.Subject, message.To, err)
+	}
+}
+
+// consume marks the token as used, so it only works once.
+func (m emailTokenMailer) consume(ctx context.Context, purpose entity.OneTimeTokenPurpose, plain string) (*entity.OneTimeToken, pkg.Error) {
+	if plain == "" {
+		return nil, pkg.NewMissingFieldError("token")
+	}
+
+	token, err := m.repository.FindByHash(ctx, purpose, pkg.HashToken(plain))
+	if err != nil {
+		return nil, pkg.NewInternalServerError(err)
+	}
+
+	if token == nil || !token.IsUsable() {
+		return nil, invalidEmailTokenError()
+	}
+
+	marked, err := m.repository.MarkUsed(ctx, token.ID)
+	if err != nil {
+		return nil, pkg.NewInternalServerError(err)
+	}
+
+	if !marked {
+		return nil, invalidEmailTokenError()
+	}
+
+	return token, pkg.Error{}
+}
+
+func invalidEmailTokenError() pkg.Error {
+	return pkg.Error{
+		Message: "invalid or expired token",
+		Status:  400,
+	}
+}
+
+// tokenLink appends the token to the front-end page that handles it, or
+// returns the bare token when no page is configured.
+func tokenLink(base, token string) string {
+	if base == "" {
+		return token
+	}
+
+	return base + "?token=" + url.QueryEscape(token)
+}
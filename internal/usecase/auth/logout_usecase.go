@@ -0,0 +1,47 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/paulozy/costurai/internal/infra/database"
+	"github.com/paulozy/costurai/internal/usecase/auth/dtos"
+	"github.com/paulozy/costurai/pkg"
+)
+
+type LogoutUseCase struct {
+	RefreshTokenRepository database.RefreshTokenRepositoryInterface
+}
+
+type NewLogoutUseCaseInput struct {
+	RefreshTokenRepository database.RefreshTokenRepositoryInterface
+}
+
+func NewLogoutUseCase(input NewLogoutUseCaseInput) *LogoutUseCase {
+	return &LogoutUseCase{
+		RefreshTokenRepository: input.RefreshTokenRepository,
+	}
+}
+
+// Execute revokes the session the refresh token belongs to, which also
+// invalidates the access tokens issued for it. Unknown tokens are ignored
+// so logging out twice is harmless.
+func (uc *LogoutUseCase) Execute(ctx context.Context, input dtos.RefreshTokenInput) pkg.Error {
+	if input.RefreshToken == "" {
+		return pkg.NewMissingFieldError("refreshToken")
+	}
+
+	token, err := uc.RefreshTokenRepository.FindByHash(ctx, pkg.HashToken(input.RefreshToken))
+	if err != nil {
+		return pkg.NewInternalServerError(err)
+	}
+
+	if token == nil {
+		return pkg.Error{}
+	}
+
+	if err := uc.RefreshTokenRepository.RevokeFamily(ctx, token.FamilyID); err != nil {
+		return pkg.NewInternalServerError(err)
+	}
+
+	return pkg.Error{}
+}
@@ -0,0 +1,70 @@
+package usecases
+
+import (
+	"context"
+	"log"
+
+	"github.com/paulozy/costurai/configs"
+	"github.com/paulozy/costurai/internal/infra/database"
+	"github.com/paulozy/costurai/internal/usecase/auth/dtos"
+	"github.com/paulozy/costurai/pkg"
+)
+
+type RefreshTokenUseCase struct {
+	RefreshTokenRepository database.RefreshTokenRepositoryInterface
+	sessions               sessionIssuer
+}
+
+type NewRefreshTokenUseCaseInput struct {
+	RefreshTokenRepository database.RefreshTokenRepositoryInterface
+	Config                 *configs.Config
+}
+
+func NewRefreshTokenUseCase(input NewRefreshTokenUseCaseInput) *RefreshTokenUseCase {
+	return &RefreshTokenUseCase{
+		RefreshTokenRepository: input.RefreshTokenRepository,
+		sessions:               newSessionIssuer(input.RefreshTokenRepository, input.Config),
+	}
+}
+
+// Execute rotates the refresh token. Presenting a token that was already
+// rotated means it leaked, so the whole session is revoked.
+func (uc *RefreshTokenUseCase) Execute(ctx context.Context, input dtos.RefreshTokenInput) (dtos.Session, pkg.Error) {
+	if input.RefreshToken == "" {
+		return dtos.Session{}, pkg.NewMissingFieldError("refreshToken")
+	}
+
+	token, err := uc.RefreshTokenRepository.FindByHash(ctx, pkg.HashToken(input.RefreshToken))
+	if err != nil {
+		return dtos.Session{}, pkg.NewInternalServerError(err)
+	}
+
+	if token == nil || token.RevokedAt != nil || token.HasExpired() {
+		return dtos.Session{}, pkg.NewInvalidCredentialsError()
+	}
+
+	marked := false
+	if token.UsedAt == nil {
+		marked, err = uc.RefreshTokenRepository.MarkUsed(ctx, token.ID)
+		if err != nil {
+			return dtos.Session{}, pkg.NewInternalServerError(err)
+		}
+	}
+
+	if !marked {
+		log.Printf("refresh token reuse detected, revoking session %s of %s", token.FamilyID, token.Subject)
+
+		if err := uc.RefreshTokenRepository.RevokeFamily(ctx, token.FamilyID); err != nil {
+			return dtos.Session{}, pkg.NewInternalServerError(err)
+		}
+
+		return dtos.Session{}, pkg.NewInvalidCredentialsError()
+	}
+
+	session, err := uc.sessions.issue(ctx, token.FamilyID, token.Subject, token.Issuer, token.Role)
+	if err != nil {
+		return dtos.Session{}, pkg.NewInternalServerError(err)
+	}
+
+	return session, pkg.Error{}
+}
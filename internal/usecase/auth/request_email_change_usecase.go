@@ -0,0 +1,107 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"strings"
+
+	"github.com/paulozy/costurai/configs"
+	"github.com/paulozy/costurai/internal/entity"
+	"github.com/paulozy/costurai/internal/infra/database"
+	notification "github.com/paulozy/costurai/internal/infra/services/notification"
+	"github.com/paulozy/costurai/internal/usecase/auth/dtos"
+	"github.com/paulozy/costurai/pkg"
+)
+
+type RequestEmailChangeUseCase struct {
+	accounts  emailAccounts
+	mailer    emailTokenMailer
+	ChangeURL string
+}
+
+type NewRequestEmailChangeUseCaseInput struct {
+	DressmakerRepository   database.DressmakerRepositoryInterface
+	UserRepository         database.UserRepositoryInterface
+	OneTimeTokenRepository database.OneTimeTokenRepositoryInterface
+	NotificationService    notification.NotificationServiceInterface
+	Config                 *configs.Config
+}
+
+func NewRequestEmailChangeUseCase(input NewRequestEmailChangeUseCaseInput) *RequestEmailChangeUseCase {
+	return &RequestEmailChangeUseCase{
+		accounts: emailAccounts{
+			users:       input.UserRepository,
+			dressmakers: input.DressmakerRepository,
+		},
+		mailer:    newEmailTokenMailer(input.OneTimeTokenRepository, input.NotificationService, input.Config),
+		ChangeURL: input.Config.EmailChangeURL,
+	}
+}
+
+// Execute mails a confirmation link to the new address. The email is only
+// swapped once the link is followed, so the account can't be moved to an
+// address its owner doesn't control. The current address is told about it.
+func (uc *RequestEmailChangeUseCase) Execute(ctx context.Context, input dtos.RequestEmailChangeInput) pkg.Error {
+	email := strings.TrimSpace(input.Email)
+	if email == "" {
+		return pkg.NewMissingFieldError("email")
+	}
+
+	if address, err := mail.ParseAddress(email); err != nil || address.Address != email {
+		return pkg.Error{
+			Message: "email is not a valid address",
+			Status:  400,
+		}
+	}
+
+	account, err := uc.accounts.find(ctx, input.AccountType, input.Subject)
+	if err != nil {
+		return pkg.NewInternalServerError(err)
+	}
+
+	if account == nil {
+		return pkg.NewNotFoundError(input.AccountType)
+	}
+
+	if strings.EqualFold(account.email(), email) {
+		return pkg.Error{
+			Message: "email is the same as the current one",
+			Status:  400,
+		}
+	}
+
+	taken, err := uc.accounts.taken(ctx, input.AccountType, email)
+	if err != nil {
+		return pkg.NewInternalServerError(err)
+	}
+
+	if taken {
+		return pkg.NewEntityAlreadyExistsError(input.AccountType)
+	}
+
+	plain, err := uc.mailer.issue(ctx, entity.PurposeEmailChange, input.AccountType, account.id(), email)
+	if err != nil {
+		return pkg.NewInternalServerError(err)
+	}
+
+	uc.mailer.notify(ctx, notification.Message{
+		To:      email,
+		Subject: "Confirme seu novo email",
+		Body: fmt.Sprintf(
+			"Olá, %s! Para usar este email na sua conta acesse %s. O link expira em %d horas.",
+			account.name(), tokenLink(uc.ChangeURL, plain), int(uc.mailer.ttl.Hours()),
+		),
+	})
+
+	uc.mailer.notify(ctx, notification.Message{
+		To:      account.email(),
+		Subject: "Alteração de email",
+		Body: fmt.Sprintf(
+			"Olá, %s! Foi pedida a troca do email da sua conta para %s. Se não foi você, altere sua senha.",
+			account.name(), email,
+		),
+	})
+
+	return pkg.Error{}
+}
@@ -0,0 +1,74 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/paulozy/costurai/configs"
+	"github.com/paulozy/costurai/internal/entity"
+	"github.com/paulozy/costurai/internal/infra/database"
+	notification "github.com/paulozy/costurai/internal/infra/services/notification"
+	"github.com/paulozy/costurai/internal/usecase/auth/dtos"
+	"github.com/paulozy/costurai/pkg"
+)
+
+type RequestEmailVerificationUseCase struct {
+	accounts        emailAccounts
+	mailer          emailTokenMailer
+	VerificationURL string
+}
+
+type NewRequestEmailVerificationUseCaseInput struct {
+	DressmakerRepository   database.DressmakerRepositoryInterface
+	UserRepository         database.UserRepositoryInterface
+	OneTimeTokenRepository database.OneTimeTokenRepositoryInterface
+	NotificationService    notification.NotificationServiceInterface
+	Config                 *configs.Config
+}
+
+func NewRequestEmailVerificationUseCase(input NewRequestEmailVerificationUseCaseInput) *RequestEmailVerificationUseCase {
+	return &RequestEmailVerificationUseCase{
+		accounts: emailAccounts{
+			users:       input.UserRepository,
+			dressmakers: input.DressmakerRepository,
+		},
+		mailer:          newEmailTokenMailer(input.OneTimeTokenRepository, input.NotificationService, input.Config),
+		VerificationURL: input.Config.EmailVerificationURL,
+	}
+}
+
+// Execute mails the account a link that verifies its current email. A new
+// link replaces the ones sent before.
+func (uc *RequestEmailVerificationUseCase) Execute(ctx context.Context, input dtos.EmailAccountInput) pkg.Error {
+	account, err := uc.accounts.find(ctx, input.AccountType, input.Subject)
+	if err != nil {
+		return pkg.NewInternalServerError(err)
+	}
+
+	if account == nil {
+		return pkg.NewNotFoundError(input.AccountType)
+	}
+
+	if account.emailVerified() {
+		return pkg.Error{
+			Message: "email is already verified",
+			Status:  400,
+		}
+	}
+
+	plain, err := uc.mailer.issue(ctx, entity.PurposeEmailVerification, input.AccountType, account.id(), account.email())
+	if err != nil {
+		return pkg.NewInternalServerError(err)
+	}
+
+	uc.mailer.notify(ctx, notification.Message{
+		To:      account.email(),
+		Subject: "Confirme seu email",
+		Body: fmt.Sprintf(
+			"Olá, %s! Para confirmar seu email acesse %s. O link expira em %d horas.",
+			account.name(), tokenLink(uc.VerificationURL, plain), int(uc.mailer.ttl.Hours()),
+		),
+	})
+
+	return pkg.Error{}
+}
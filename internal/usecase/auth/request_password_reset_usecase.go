@@ -0,0 +1,203 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/paulozy/costurai/configs"
+	"github.com/paulozy/costurai/internal/entity"
+	"github.com/paulozy/costurai/internal/infra/database"
+	notification "github.com/paulozy/costurai/internal/infra/services/notification"
+	sms "github.com/paulozy/costurai/internal/infra/services/sms"
+	"github.com/paulozy/costurai/internal/usecase/auth/dtos"
+	"github.com/paulozy/costurai/pkg"
+)
+
+const (
+	AccountTypeUser       = "user"
+	AccountTypeDressmaker = "dressmaker"
+
+	ResetChannelEmail = "email"
+	ResetChannelSMS   = "sms"
+
+	defaultPasswordResetTTL = 30 * time.Minute
+)
+
+type RequestPasswordResetUseCase struct {
+	DressmakerRepository   database.DressmakerRepositoryInterface
+	UserRepository         database.UserRepositoryInterface
+	OneTimeTokenRepository database.OneTimeTokenRepositoryInterface
+	NotificationService    notification.NotificationServiceInterface
+	OTPService             sms.OTPServiceInterface
+	ResetURL               string
+	TTL                    time.Duration
+	otpLimiter             attemptLimiter
+}
+
+type NewRequestPasswordResetUseCaseInput struct {
+	DressmakerRepository   database.DressmakerRepositoryInterface
+	UserRepository         database.UserRepositoryInterface
+	OneTimeTokenRepository database.OneTimeTokenRepositoryInterface
+	NotificationService    notification.NotificationServiceInterface
+	OTPService             sms.OTPServiceInterface
+	ThrottleRepository     database.ThrottleRepositoryInterface
+	Config                 *configs.Config
+}
+
+func NewRequestPasswordResetUseCase(input NewRequestPasswordResetUseCaseInput) *RequestPasswordResetUseCase {
+	ttl := defaultPasswordResetTTL
+	if input.Config.PasswordResetExpiresIn > 0 {
+		ttl = time.Duration(input.Config.PasswordResetExpiresIn) * time.Minute
+	}
+
+	return &RequestPasswordResetUseCase{
+		DressmakerRepository:   input.DressmakerRepository,
+		UserRepository:         input.UserRepository,
+		OneTimeTokenRepository: input.OneTimeTokenRepository,
+		NotificationService:    input.NotificationService,
+		OTPService:             input.OTPService,
+		ResetURL:               input.Config.PasswordResetURL,
+		TTL:                    ttl,
+		otpLimiter:             otpSendLimiter(input.ThrottleRepository, input.Config),
+	}
+}
+
+// Execute mails a reset link, or texts a code to the verified phone, when the
+// email belongs to an account. The outcome is the same whether or not it
+// does, so the endpoint can't be used to find out who is registered.
+func (uc *RequestPasswordResetUseCase) Execute(ctx context.Context, input dtos.RequestPasswordResetInput) pkg.Error {
+	if input.Email == "" {
+		return pkg.NewMissingFieldError("email")
+	}
+
+	if input.AccountType != AccountTypeUser && input.AccountType != AccountTypeDressmaker {
+		return pkg.Error{
+			Message: "accountType must be one of user or dressmaker",
+			Status:  400,
+		}
+	}
+
+	if input.Channel == "" {
+		input.Channel = ResetChannelEmail
+	}
+
+	if input.Channel != ResetChannelEmail && input.Channel != ResetChannelSMS {
+		return pkg.Error{
+			Message: "channel must be one of email or sms",
+			Status:  400,
+		}
+	}
+
+	account, err := findResetAccount(ctx, uc.UserRepository, uc.DressmakerRepository, input.AccountType, input.Email)
+	if err != nil {
+		return pkg.NewInternalServerError(err)
+	}
+
+	if account == nil {
+		return pkg.Error{}
+	}
+
+	if input.Channel == ResetChannelSMS {
+		uc.textCode(ctx, account)
+		return pkg.Error{}
+	}
+
+	subject, name := account.id, account.name
+
+	err = uc.OneTimeTokenRepository.DeleteBySubject(ctx, entity.PurposePasswordReset, subject)
+	if err != nil {
+		return pkg.NewInternalServerError(err)
+	}
+
+	token, plain, err := entity.NewOneTimeToken(entity.PurposePasswordReset, subject, input.AccountType, input.Email, uc.TTL)
+	if err != nil {
+		return pkg.NewInternalServerError(err)
+	}
+
+	err = uc.OneTimeTokenRepository.Create(ctx, token)
+	if err != nil {
+		return pkg.NewInternalServerError(err)
+	}
+
+	message := notification.Message{
+		To:      input.Email,
+		Subject: "Redefinição de senha",
+		Body: fmt.Sprintf(
+			"Olá, %s! Para redefinir sua senha acesse %s. O link expira em %d minutos. Se você não pediu a redefinição, ignore este email.",
+			name, uc.resetLink(plain), int(uc.TTL.Minutes()),
+		),
+	}
+
+	if err := uc.NotificationService.Notify(ctx, message); err != nil {
+		log.Printf("could not send password reset to %s: %v", subject, err)
+	}
+
+	return pkg.Error{}
+}
+
+// textCode sends a reset code to the account's verified phone, sharing the
+// throttling of the other codes sent to it. Accounts without a verified phone
+// can only reset by email. Nothing is reported back, so the response doesn't
+// tell registered accounts apart.
+func (uc *RequestPasswordResetUseCase) textCode(ctx context.Context, account *resetAccount) {
+	if account.phone == "" {
+		return
+	}
+
+	key := otpSendKey(account.phone)
+	if limitErr := uc.otpLimiter.check(ctx, key); limitErr.Message != "" {
+		return
+	}
+
+	if err := uc.otpLimiter.register(ctx, key); err != nil {
+		log.Printf("could not throttle password reset code to %s: %v", account.id, err)
+		return
+	}
+
+	if err := uc.OTPService.Send(ctx, account.phone); err != nil {
+		log.Printf("could not text password reset code to %s: %v", account.id, err)
+	}
+}
+
+// resetAccount is the account a password reset applies to. Phone is only set
+// when the owner verified it.
+type resetAccount struct {
+	id    string
+	name  string
+	phone string
+}
+
+// findResetAccount returns nil when no account of the type has the email.
+func findResetAccount(ctx context.Context, users database.UserRepositoryInterface, dressmakers database.DressmakerRepositoryInterface, accountType, email string) (*resetAccount, error) {
+	if accountType == AccountTypeDressmaker {
+		dressmaker, err := dressmakers.FindByEmail(ctx, email)
+		if err != nil || dressmaker == nil {
+			return nil, err
+		}
+
+		account := &resetAccount{id: dressmaker.ID, name: dressmaker.Name}
+		if dressmaker.PhoneVerifiedAt != nil {
+			account.phone = dressmaker.Phone
+		}
+
+		return account, nil
+	}
+
+	user, err := users.FindByEmail(ctx, email)
+	if err != nil || user == nil {
+		return nil, err
+	}
+
+	account := &resetAccount{id: user.ID, name: user.Name}
+	if user.PhoneVerifiedAt != nil {
+		account.phone = user.Phone
+	}
+
+	return account, nil
+}
+
+func (uc *RequestPasswordResetUseCase) resetLink(token string) string {
+	return tokenLink(uc.ResetURL, token)
+}
@@ -0,0 +1,185 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/paulozy/costurai/internal/entity"
+	"github.com/paulozy/costurai/internal/infra/database"
+	sms "github.com/paulozy/costurai/internal/infra/services/sms"
+	"github.com/paulozy/costurai/internal/usecase/auth/dtos"
+	"github.com/paulozy/costurai/pkg"
+)
+
+type ResetPasswordUseCase struct {
+	DressmakerRepository   database.DressmakerRepositoryInterface
+	UserRepository         database.UserRepositoryInterface
+	OneTimeTokenRepository database.OneTimeTokenRepositoryInterface
+	RefreshTokenRepository database.RefreshTokenRepositoryInterface
+	OTPService             sms.OTPServiceInterface
+}
+
+type NewResetPasswordUseCaseInput struct {
+	DressmakerRepository   database.DressmakerRepositoryInterface
+	UserRepository         database.UserRepositoryInterface
+	OneTimeTokenRepository database.OneTimeTokenRepositoryInterface
+	RefreshTokenRepository database.RefreshTokenRepositoryInterface
+	OTPService             sms.OTPServiceInterface
+}
+
+func NewResetPasswordUseCase(input NewResetPasswordUseCaseInput) *ResetPasswordUseCase {
+	return &ResetPasswordUseCase{
+		DressmakerRepository:   input.DressmakerRepository,
+		UserRepository:         input.UserRepository,
+		OneTimeTokenRepository: input.OneTimeTokenRepository,
+		RefreshTokenRepository: input.RefreshTokenRepository,
+		OTPService:             input.OTPService,
+	}
+}
+
+// Execute sets the new password and signs the account out everywhere.
+func (uc *ResetPasswordUseCase) Execute(ctx context.Context, input dtos.ResetPasswordInput) pkg.Error {
+	if input.Token == "" && input.Code == "" {
+		return pkg.NewMissingFieldError("token")
+	}
+
+	if input.Password == "" {
+		return pkg.NewMissingFieldError("password")
+	}
+
+	var accountType, subject string
+	var ucErr pkg.Error
+	if input.Token != "" {
+		accountType, subject, ucErr = uc.redeemToken(ctx, input.Token)
+	} else {
+		accountType, subject, ucErr = uc.checkCode(ctx, input)
+	}
+
+	if ucErr.Message != "" {
+		return ucErr
+	}
+
+	if ucErr := uc.changePassword(ctx, accountType, subject, input.Password); ucErr.Message != "" {
+		return ucErr
+	}
+
+	err := uc.RefreshTokenRepository.RevokeSubject(ctx, subject)
+	if err != nil {
+		return pkg.NewInternalServerError(err)
+	}
+
+	return pkg.Error{}
+}
+
+// redeemToken uses up the token of an emailed reset link.
+func (uc *ResetPasswordUseCase) redeemToken(ctx context.Context, plain string) (string, string, pkg.Error) {
+	token, err := uc.OneTimeTokenRepository.FindByHash(ctx, entity.PurposePasswordReset, pkg.HashToken(plain))
+	if err != nil {
+		return "", "", pkg.NewInternalServerError(err)
+	}
+
+	if token == nil || !token.IsUsable() {
+		return "", "", invalidResetTokenError()
+	}
+
+	marked, err := uc.OneTimeTokenRepository.MarkUsed(ctx, token.ID)
+	if err != nil {
+		return "", "", pkg.NewInternalServerError(err)
+	}
+
+	if !marked {
+		return "", "", invalidResetTokenError()
+	}
+
+	return token.AccountType, token.Subject, pkg.Error{}
+}
+
+// checkCode verifies the code texted to the account's verified phone. Unknown
+// accounts fail the same way as wrong codes.
+func (uc *ResetPasswordUseCase) checkCode(ctx context.Context, input dtos.ResetPasswordInput) (string, string, pkg.Error) {
+	if input.Email == "" {
+		return "", "", pkg.NewMissingFieldError("email")
+	}
+
+	if input.AccountType != AccountTypeUser && input.AccountType != AccountTypeDressmaker {
+		return "", "", pkg.Error{
+			Message: "accountType must be one of user or dressmaker",
+			Status:  400,
+		}
+	}
+
+	account, err := findResetAccount(ctx, uc.UserRepository, uc.DressmakerRepository, input.AccountType, input.Email)
+	if err != nil {
+		return "", "", pkg.NewInternalServerError(err)
+	}
+
+	if account == nil || account.phone == "" {
+		return "", "", invalidResetCodeError()
+	}
+
+	ok, err := uc.OTPService.Verify(ctx, account.phone, input.Code)
+	if err != nil {
+		return "", "", pkg.NewInternalServerError(err)
+	}
+
+	if !ok {
+		return "", "", invalidResetCodeError()
+	}
+
+	return input.AccountType, account.id, pkg.Error{}
+}
+
+func (uc *ResetPasswordUseCase) changePassword(ctx context.Context, accountType, subject, password string) pkg.Error {
+	if accountType == AccountTypeDressmaker {
+		dressmaker, err := uc.DressmakerRepository.FindByID(ctx, subject)
+		if err != nil {
+			return pkg.NewInternalServerError(err)
+		}
+
+		if dressmaker == nil {
+			return invalidResetTokenError()
+		}
+
+		if err := dressmaker.ChangePassword(password); err != nil {
+			return pkg.NewInternalServerError(err)
+		}
+
+		if err := uc.DressmakerRepository.Update(ctx, dressmaker); err != nil {
+			return pkg.NewInternalServerError(err)
+		}
+
+		return pkg.Error{}
+	}
+
+	user, err := uc.UserRepository.FindByID(ctx, subject)
+	if err != nil {
+		return pkg.NewInternalServerError(err)
+	}
+
+	if user == nil {
+		return invalidResetTokenError()
+	}
+
+	if err := user.ChangePassword(password); err != nil {
+		return pkg.NewInternalServerError(err)
+	}
+
+	if err := uc.UserRepository.Update(ctx, user); err != nil {
+		return pkg.NewInternalServerError(err)
+	}
+
+	return pkg.Error{}
+}
+
+func invalidResetTokenError() pkg.Error {
+	return pkg.Error{
+		Message: "invalid or expired reset token",
+		Status:  400,
+	}
+}
+
+func invalidResetCodeError() pkg.Error {
+	return pkg.Error{
+		Message: "invalid or expired reset code",
+		Status:  400,
+	}
+}
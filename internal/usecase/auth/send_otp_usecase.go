@@ -1,8 +1,12 @@
 package usecases
 
 import (
+	"context"
+
 	"fmt"
 
+	"github.com/paulozy/costurai/configs"
+	"github.com/paulozy/costurai/internal/infra/database"
 	services "github.com/paulozy/costurai/internal/infra/services/sms"
 	"github.com/paulozy/costurai/internal/usecase/auth/dtos"
 	"github.com/paulozy/costurai/pkg"
@@ -10,25 +14,45 @@ import (
 
 type SendOTPUseCase struct {
 	OTPService services.OTPServiceInterface
+	limiter    attemptLimiter
 }
 
 type NewSendOTPUseCaseInput struct {
-	OTPService services.OTPServiceInterface
+	OTPService         services.OTPServiceInterface
+	ThrottleRepository database.ThrottleRepositoryInterface
+	Config             *configs.Config
 }
 
 func NewSentOTPUseCase(services NewSendOTPUseCaseInput) *SendOTPUseCase {
 	return &SendOTPUseCase{
 		OTPService: services.OTPService,
+		limiter:    otpSendLimiter(services.ThrottleRepository, services.Config),
 	}
 }
 
-func (useCase *SendOTPUseCase) Execute(payload dtos.SendOTPInput) pkg.Error {
-	err := useCase.OTPService.Send(payload.Phone)
+func (useCase *SendOTPUseCase) Execute(ctx context.Context, payload dtos.SendOTPInput) pkg.Error {
+	phone, err := pkg.NormalizePhone(payload.Phone)
+	if err != nil {
+		return pkg.NewBadRequestError(err)
+	}
+
+	// every send costs an SMS, so the phone is throttled whether or not the
+	// code is ever used
+	if limitErr := useCase.limiter.check(ctx, otpSendKey(phone)); limitErr.Message != "" {
+		return limitErr
+	}
+
+	if err := useCase.limiter.register(ctx, otpSendKey(phone)); err != nil {
+		return pkg.NewInternalServerError(err)
+	}
+
+	err = useCase.OTPService.Send(ctx, phone)
 	if err != nil {
 		fmt.Println(err)
 		return pkg.Error{
 			Error:   err.Error(),
 			Message: "Error on sending code",
+			Status:  500,
 		}
 	}
 
@@ -0,0 +1,79 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/paulozy/costurai/configs"
+	"github.com/paulozy/costurai/internal/entity"
+	"github.com/paulozy/costurai/internal/infra/database"
+	"github.com/paulozy/costurai/internal/usecase/auth/dtos"
+	"github.com/paulozy/costurai/pkg"
+)
+
+const (
+	defaultAccessTokenTTL  = 15 * time.Minute
+	defaultRefreshTokenTTL = 30 * 24 * time.Hour
+)
+
+// sessionIssuer hands out a short-lived access token together with a
+// rotating refresh token persisted in the repository.
+type sessionIssuer struct {
+	repository database.RefreshTokenRepositoryInterface
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+}
+
+func newSessionIssuer(repo database.RefreshTokenRepositoryInterface, cfg *configs.Config) sessionIssuer {
+	issuer := sessionIssuer{
+		repository: repo,
+		accessTTL:  defaultAccessTokenTTL,
+		refreshTTL: defaultRefreshTokenTTL,
+	}
+
+	if cfg != nil && cfg.JWTAccessExpiresIn > 0 {
+		issuer.accessTTL = time.Duration(cfg.JWTAccessExpiresIn) * time.Minute
+	}
+
+	if cfg != nil && cfg.RefreshTokenExpiresIn > 0 {
+		issuer.refreshTTL = time.Duration(cfg.RefreshTokenExpiresIn) * time.Hour
+	}
+
+	return issuer
+}
+
+// issue starts a new session when familyID is empty, or continues the given
+// one after a refresh.
+func (s sessionIssuer) issue(ctx context.Context, familyID, subject, name string, role pkg.Role) (dtos.Session, error) {
+	refreshToken, plain, err := entity.NewRefreshToken(familyID, subject, name, role, s.refreshTTL)
+	if err != nil {
+		return dtos.Session{}, err
+	}
+
+	if err := s.repository.Create(ctx, refreshToken); err != nil {
+		return dtos.Session{}, err
+	}
+
+	token, err := pkg.GenerateToken(pkg.GenerateTokenInput{
+		Issuer:    name,
+		Subject:   subject,
+		Role:      role,
+		SessionID: refreshToken.FamilyID,
+		ExpiresIn: s.accessTTL,
+	})
+	if err != nil {
+		return dtos.Session{}, err
+	}
+
+	return dtos.Session{
+		Token:        token,
+		RefreshToken: plain,
+		ExpiresIn:    int64(s.accessTTL.Seconds()),
+	}, nil
+}
+
+// newAccountSuspendedError is returned instead of a session to accounts an
+// admin suspended.
+func newAccountSuspendedError() pkg.Error {
+	return pkg.NewForbiddenError("account suspended")
+}
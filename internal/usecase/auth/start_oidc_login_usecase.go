@@ -0,0 +1,68 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/paulozy/costurai/internal/entity"
+	"github.com/paulozy/costurai/internal/infra/database"
+	oidc "github.com/paulozy/costurai/internal/infra/services/oidc"
+	"github.com/paulozy/costurai/internal/usecase/auth/dtos"
+	"github.com/paulozy/costurai/pkg"
+)
+
+// oidcLoginTTL is how long someone has to finish logging in at the provider.
+const oidcLoginTTL = 10 * time.Minute
+
+type StartOIDCLoginUseCase struct {
+	Provider                 oidc.OIDCProviderInterface
+	OIDCLoginStateRepository database.OIDCLoginStateRepositoryInterface
+}
+
+type NewStartOIDCLoginUseCaseInput struct {
+	Provider                 oidc.OIDCProviderInterface
+	OIDCLoginStateRepository database.OIDCLoginStateRepositoryInterface
+}
+
+func NewStartOIDCLoginUseCase(input NewStartOIDCLoginUseCaseInput) *StartOIDCLoginUseCase {
+	return &StartOIDCLoginUseCase{
+		Provider:                 input.Provider,
+		OIDCLoginStateRepository: input.OIDCLoginStateRepository,
+	}
+}
+
+// Execute returns the provider URL to send the browser to. The provider
+// redirects back with the state and a code for CompleteOIDCLoginUseCase.
+func (uc *StartOIDCLoginUseCase) Execute(ctx context.Context, input dtos.StartOIDCLoginInput) (dtos.StartOIDCLoginOutput, pkg.Error) {
+	if input.AccountType != AccountTypeUser && input.AccountType != AccountTypeDressmaker {
+		return dtos.StartOIDCLoginOutput{}, pkg.Error{
+			Message: "accountType must be one of user or dressmaker",
+			Status:  400,
+		}
+	}
+
+	state, plainState, err := entity.NewOIDCLoginState(input.AccountType, oidcLoginTTL)
+	if err != nil {
+		return dtos.StartOIDCLoginOutput{}, pkg.NewInternalServerError(err)
+	}
+
+	authorizationURL, err := uc.Provider.AuthorizationURL(ctx, oidc.AuthorizationRequest{
+		State:         plainState,
+		Nonce:         state.Nonce,
+		CodeChallenge: oidc.CodeChallenge(state.CodeVerifier),
+	})
+	if err != nil {
+		return dtos.StartOIDCLoginOutput{}, pkg.Error{
+			Message: "Identity provider unavailable",
+			Error:   err.Error(),
+			Status:  502,
+		}
+	}
+
+	err = uc.OIDCLoginStateRepository.Create(ctx, state)
+	if err != nil {
+		return dtos.StartOIDCLoginOutput{}, pkg.NewInternalServerError(err)
+	}
+
+	return dtos.StartOIDCLoginOutput{AuthorizationURL: authorizationURL}, pkg.Error{}
+}
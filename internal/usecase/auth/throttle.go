@@ -0,0 +1,189 @@
+package usecases
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/paulozy/costurai/configs"
+	"github.com/paulozy/costurai/internal/entity"
+	"github.com/paulozy/costurai/internal/infra/database"
+	"github.com/paulozy/costurai/internal/usecase/auth/dtos"
+	"github.com/paulozy/costurai/pkg"
+)
+
+const (
+	defaultLoginMaxAttempts   = 5
+	defaultLoginIPMaxAttempts = 20
+	defaultLoginBackoff       = time.Second
+	defaultLoginLockout       = 15 * time.Minute
+	loginAttemptWindow        = time.Hour
+
+	defaultOTPSendInterval    = time.Minute
+	defaultOTPSendMaxAttempts = 5
+	otpSendWindow             = time.Hour
+)
+
+// attemptLimiter rejects requests for keys that are blocked and records
+// attempts against them.
+type attemptLimiter struct {
+	repository database.ThrottleRepositoryInterface
+	policy     entity.ThrottlePolicy
+}
+
+// loginGuard throttles failed logins per account and per client IP. IPs get
+// no backoff, since many users may share one.
+type loginGuard struct {
+	accountType string
+	account     attemptLimiter
+	ip          attemptLimiter
+}
+
+func newLoginGuard(accountType string, repo database.ThrottleRepositoryInterface, cfg *configs.Config) loginGuard {
+	account := attemptLimiter{
+		repository: repo,
+		policy: entity.ThrottlePolicy{
+			MaxAttempts: defaultLoginMaxAttempts,
+			Window:      loginAttemptWindow,
+			Backoff:     defaultLoginBackoff,
+			Lockout:     defaultLoginLockout,
+		},
+	}
+
+	if cfg != nil && cfg.LoginMaxAttempts > 0 {
+		account.policy.MaxAttempts = cfg.LoginMaxAttempts
+	}
+
+	if cfg != nil && cfg.LoginBackoff > 0 {
+		account.policy.Backoff = time.Duration(cfg.LoginBackoff) * time.Second
+	}
+
+	if cfg != nil && cfg.LoginLockoutDuration > 0 {
+		account.policy.Lockout = time.Duration(cfg.LoginLockoutDuration) * time.Minute
+	}
+
+	ip := attemptLimiter{
+		repository: repo,
+		policy: entity.ThrottlePolicy{
+			MaxAttempts: defaultLoginIPMaxAttempts,
+			Window:      loginAttemptWindow,
+			Lockout:     account.policy.Lockout,
+		},
+	}
+
+	if cfg != nil && cfg.LoginIPMaxAttempts > 0 {
+		ip.policy.MaxAttempts = cfg.LoginIPMaxAttempts
+	}
+
+	return loginGuard{
+		accountType: accountType,
+		account:     account,
+		ip:          ip,
+	}
+}
+
+func (g loginGuard) accountKey(email string) string {
+	return "login:" + g.accountType + ":" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func (g loginGuard) ipKey(ip string) string {
+	if ip == "" {
+		return ""
+	}
+
+	return "login:ip:" + ip
+}
+
+func (g loginGuard) allow(ctx context.Context, data dtos.AuthenticationInput) pkg.Error {
+	if err := g.ip.check(ctx, g.ipKey(data.IP)); err.Message != "" {
+		return err
+	}
+
+	return g.account.check(ctx, g.accountKey(data.Email))
+}
+
+// failed records a failed login, for unknown emails too so they can't be
+// told apart, and returns the error to respond with.
+func (g loginGuard) failed(ctx context.Context, data dtos.AuthenticationInput) pkg.Error {
+	if err := g.ip.register(ctx, g.ipKey(data.IP)); err != nil {
+		return pkg.NewInternalServerError(err)
+	}
+
+	if err := g.account.register(ctx, g.accountKey(data.Email)); err != nil {
+		return pkg.NewInternalServerError(err)
+	}
+
+	return pkg.NewInvalidCredentialsError()
+}
+
+// succeeded clears the account's failures. The IP's are kept, otherwise an
+// attacker could reset them by logging into their own account.
+func (g loginGuard) succeeded(ctx context.Context, data dtos.AuthenticationInput) error {
+	return g.account.reset(ctx, g.accountKey(data.Email))
+}
+
+func otpSendLimiter(repo database.ThrottleRepositoryInterface, cfg *configs.Config) attemptLimiter {
+	limiter := attemptLimiter{
+		repository: repo,
+		policy: entity.ThrottlePolicy{
+			MaxAttempts: defaultOTPSendMaxAttempts,
+			Window:      otpSendWindow,
+			Backoff:     defaultOTPSendInterval,
+			Lockout:     otpSendWindow,
+		},
+	}
+
+	if cfg != nil && cfg.OTPSendInterval > 0 {
+		limiter.policy.Backoff = time.Duration(cfg.OTPSendInterval) * time.Second
+	}
+
+	if cfg != nil && cfg.OTPSendMaxAttempts > 0 {
+		limiter.policy.MaxAttempts = cfg.OTPSendMaxAttempts
+	}
+
+	return limiter
+}
+
+func otpSendKey(phone string) string {
+	return "otp:send:" + phone
+}
+
+// check returns a 429 error when the key is blocked. Empty keys, such as a
+// missing client IP, are never blocked.
+func (l attemptLimiter) check(ctx context.Context, key string) pkg.Error {
+	if key == "" || l.repository == nil {
+		return pkg.Error{}
+	}
+
+	throttle, err := l.repository.Find(ctx, key)
+	if err != nil {
+		return pkg.NewInternalServerError(err)
+	}
+
+	if throttle == nil {
+		return pkg.Error{}
+	}
+
+	if retryAfter := throttle.RetryAfter(time.Now()); retryAfter > 0 {
+		return pkg.NewTooManyRequestsError(retryAfter)
+	}
+
+	return pkg.Error{}
+}
+
+func (l attemptLimiter) register(ctx context.Context, key string) error {
+	if key == "" || l.repository == nil {
+		return nil
+	}
+
+	_, err := l.repository.RegisterAttempt(ctx, key, l.policy)
+	return err
+}
+
+func (l attemptLimiter) reset(ctx context.Context, key string) error {
+	if key == "" || l.repository == nil {
+		return nil
+	}
+
+	return l.repository.Delete(ctx, key)
+}
@@ -0,0 +1,57 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/paulozy/costurai/internal/entity"
+	"github.com/paulozy/costurai/internal/infra/database"
+	"github.com/paulozy/costurai/internal/usecase/auth/dtos"
+	"github.com/paulozy/costurai/pkg"
+)
+
+type VerifyEmailUseCase struct {
+	accounts emailAccounts
+	mailer   emailTokenMailer
+}
+
+type NewVerifyEmailUseCaseInput struct {
+	DressmakerRepository   database.DressmakerRepositoryInterface
+	UserRepository         database.UserRepositoryInterface
+	OneTimeTokenRepository database.OneTimeTokenRepositoryInterface
+}
+
+func NewVerifyEmailUseCase(input NewVerifyEmailUseCaseInput) *VerifyEmailUseCase {
+	return &VerifyEmailUseCase{
+		accounts: emailAccounts{
+			users:       input.UserRepository,
+			dressmakers: input.DressmakerRepository,
+		},
+		mailer: emailTokenMailer{repository: input.OneTimeTokenRepository},
+	}
+}
+
+// Execute marks the email as verified. Links sent to an address the account
+// no longer uses are rejected.
+func (uc *VerifyEmailUseCase) Execute(ctx context.Context, input dtos.EmailTokenInput) pkg.Error {
+	token, ucErr := uc.mailer.consume(ctx, entity.PurposeEmailVerification, input.Token)
+	if ucErr.Message != "" {
+		return ucErr
+	}
+
+	account, err := uc.accounts.find(ctx, token.AccountType, token.Subject)
+	if err != nil {
+		return pkg.NewInternalServerError(err)
+	}
+
+	if account == nil || account.email() != token.Email {
+		return invalidEmailTokenError()
+	}
+
+	account.verifyEmail()
+
+	if err := uc.accounts.save(ctx, account); err != nil {
+		return pkg.NewInternalServerError(err)
+	}
+
+	return pkg.Error{}
+}
@@ -1,53 +1,49 @@
 package usecases
 
 import (
+	"context"
+
 	"github.com/paulozy/costurai/internal/infra/database"
 	services "github.com/paulozy/costurai/internal/infra/services/sms"
 	"github.com/paulozy/costurai/internal/usecase/auth/dtos"
+	dressmakerUseCases "github.com/paulozy/costurai/internal/usecase/dressmaker"
 	"github.com/paulozy/costurai/pkg"
 )
 
 type VerifyOTPUseCase struct {
-	OTPService           services.OTPServiceInterface
-	DressmakerRepository database.DressmakerRepositoryInterface
-	UserRepository       database.UserRepositoryInterface
+	OTPService             services.OTPServiceInterface
+	DressmakerRepository   database.DressmakerRepositoryInterface
+	UserRepository         database.UserRepositoryInterface
+	SubscriptionRepository database.SubscriptionRepositoryInterface
 }
 
 type NewVerifyOTPUseCaseInput struct {
-	OTPService           services.OTPServiceInterface
-	DressmakerRepository database.DressmakerRepositoryInterface
-	UserRepository       database.UserRepositoryInterface
+	OTPService             services.OTPServiceInterface
+	DressmakerRepository   database.DressmakerRepositoryInterface
+	UserRepository         database.UserRepositoryInterface
+	SubscriptionRepository database.SubscriptionRepositoryInterface
 }
 
 func NewVerifyOTPUseCase(input NewVerifyOTPUseCaseInput) *VerifyOTPUseCase {
 	return &VerifyOTPUseCase{
-		OTPService:           input.OTPService,
-		DressmakerRepository: input.DressmakerRepository,
-		UserRepository:       input.UserRepository,
+		OTPService:             input.OTPService,
+		DressmakerRepository:   input.DressmakerRepository,
+		UserRepository:         input.UserRepository,
+		SubscriptionRepository: input.SubscriptionRepository,
 	}
 }
 
-func (uc *VerifyOTPUseCase) Execute(payload dtos.VerifyOTPInput) pkg.Error {
-	ok, err := uc.OTPService.Verify(payload.Phone, payload.Code)
+func (uc *VerifyOTPUseCase) Execute(ctx context.Context, payload dtos.VerifyOTPInput) pkg.Error {
+	phone, err := pkg.NormalizePhone(payload.Phone)
 	if err != nil {
-		return pkg.Error{
-			Error:   err.Error(),
-			Message: "Error on verify code",
-			Status:  500,
-		}
-	} else if !ok {
-		return pkg.Error{
-			Error:   "Error on verify code",
-			Message: "Invalid code",
-			Status:  400,
-		}
+		return pkg.NewBadRequestError(err)
 	}
 
 	switch payload.Enabling {
 	case "dressmaker":
-		return uc.enableDressmaker(payload.DressmakerID)
+		return uc.enableDressmaker(ctx, payload.DressmakerID, phone, payload.Code)
 	case "user":
-		return uc.enableUser(payload.UserID)
+		return uc.enableUser(ctx, payload.UserID, phone, payload.Code)
 	default:
 		return pkg.Error{
 			Error:   "Error on verify code",
@@ -57,19 +53,57 @@ func (uc *VerifyOTPUseCase) Execute(payload dtos.VerifyOTPInput) pkg.Error {
 	}
 }
 
-func (uc *VerifyOTPUseCase) enableDressmaker(id string) pkg.Error {
-	dressmaker, err := uc.DressmakerRepository.FindByID(id)
+// verifyCode checks the code only once the phone is known to belong to the
+// account, so a mismatched phone doesn't use up the code's attempts.
+func (uc *VerifyOTPUseCase) verifyCode(ctx context.Context, phone, code string) pkg.Error {
+	ok, err := uc.OTPService.Verify(ctx, phone, code)
 	if err != nil {
 		return pkg.Error{
-			Message: err.Error(),
-			Error:   "Dressmaker not found",
-			Status:  404,
+			Error:   err.Error(),
+			Message: "Error on verify code",
+			Status:  500,
+		}
+	} else if !ok {
+		return pkg.Error{
+			Error:   "Error on verify code",
+			Message: "Invalid code",
+			Status:  400,
 		}
 	}
 
-	dressmaker.Enable()
+	return pkg.Error{}
+}
 
-	err = uc.DressmakerRepository.Update(dressmaker)
+func (uc *VerifyOTPUseCase) enableDressmaker(ctx context.Context, id, phone, code string) pkg.Error {
+	dressmaker, err := uc.DressmakerRepository.FindByID(ctx, id)
+	if err != nil {
+		return pkg.NewInternalServerError(err)
+	} else if dressmaker == nil {
+		return pkg.NewNotFoundError("dressmaker")
+	} else if dressmaker.Suspended {
+		return newAccountSuspendedError()
+	}
+
+	// contacts saved before numbers were normalized are compared normalized
+	contact, err := pkg.NormalizePhone(dressmaker.Contact)
+	if err != nil || contact != phone {
+		return pkg.NewForbiddenError("phone does not match the account's phone")
+	}
+
+	if verifyErr := uc.verifyCode(ctx, phone, code); verifyErr.Message != "" {
+		return verifyErr
+	}
+
+	sub, err := dressmakerUseCases.FindCurrentSubscription(ctx, uc.SubscriptionRepository, dressmaker)
+	if err != nil {
+		return pkg.NewInternalServerError(err)
+	}
+
+	// a verified phone only lists dressmakers whose subscription grants access
+	dressmaker.VerifyPhone(phone)
+	dressmaker.RefreshListing(sub)
+
+	err = uc.DressmakerRepository.Update(ctx, dressmaker)
 	if err != nil {
 		return pkg.Error{
 			Message: err.Error(),
@@ -81,19 +115,29 @@ func (uc *VerifyOTPUseCase) enableDressmaker(id string) pkg.Error {
 	return pkg.Error{}
 }
 
-func (uc *VerifyOTPUseCase) enableUser(id string) pkg.Error {
-	user, err := uc.UserRepository.FindByID(id)
+func (uc *VerifyOTPUseCase) enableUser(ctx context.Context, id, phone, code string) pkg.Error {
+	user, err := uc.UserRepository.FindByID(ctx, id)
 	if err != nil {
-		return pkg.Error{
-			Message: err.Error(),
-			Error:   "User not found",
-			Status:  404,
-		}
+		return pkg.NewInternalServerError(err)
+	} else if user == nil {
+		return pkg.NewNotFoundError("user")
+	} else if user.Suspended {
+		return newAccountSuspendedError()
+	}
+
+	// users who signed up without a phone bind the one they verify
+	if user.Phone != "" && user.Phone != phone {
+		return pkg.NewForbiddenError("phone does not match the account's phone")
+	}
+
+	if verifyErr := uc.verifyCode(ctx, phone, code); verifyErr.Message != "" {
+		return verifyErr
 	}
 
+	user.VerifyPhone(phone)
 	user.Enable()
 
-	err = uc.UserRepository.Update(user)
+	err = uc.UserRepository.Update(ctx, user)
 	if err != nil {
 		return pkg.Error{
 			Message: err.Error(),
@@ -1,6 +1,10 @@
 package usecases
 
 import (
+	"context"
+	"errors"
+	"strings"
+
 	"github.com/paulozy/costurai/internal/entity"
 	"github.com/paulozy/costurai/internal/infra/database"
 	"github.com/paulozy/costurai/pkg"
@@ -9,29 +13,43 @@ import (
 type AddDressmakerReviewUseCase struct {
 	DressmakerRepository        database.DressmakerRepositoryInterface
 	DressmakerReviewsRepository database.DressmakerReviewsRepositoryInterface
+	UserRepository              database.UserRepositoryInterface
+	Screener                    *pkg.TextScreener
 }
 
 type AddDressmakerReviewUseCaseInput struct {
-	DressmakerID string  `json:"dressmaker_id"`
-	UserID       string  `json:"user_id"`
+	DressmakerID string  `json:"-"`
+	UserID       string  `json:"-"`
 	Comment      string  `json:"comment"`
 	Grade        float64 `json:"grade"`
 }
 
-func NewAddDressmakerReviewUseCase(dmRepo database.DressmakerRepositoryInterface, dmrRepo database.DressmakerReviewsRepositoryInterface) *AddDressmakerReviewUseCase {
+func NewAddDressmakerReviewUseCase(
+	dmRepo database.DressmakerRepositoryInterface,
+	dmrRepo database.DressmakerReviewsRepositoryInterface,
+	userRepo database.UserRepositoryInterface,
+	screener *pkg.TextScreener,
+) *AddDressmakerReviewUseCase {
 	return &AddDressmakerReviewUseCase{
 		DressmakerRepository:        dmRepo,
 		DressmakerReviewsRepository: dmrRepo,
+		UserRepository:              userRepo,
+		Screener:                    screener,
 	}
 }
 
-func (usecase *AddDressmakerReviewUseCase) Execute(input AddDressmakerReviewUseCaseInput) (*entity.Dressmaker, pkg.Error) {
+func (usecase *AddDressmakerReviewUseCase) Execute(ctx context.Context, input AddDressmakerReviewUseCaseInput) (*entity.Review, pkg.Error) {
 	validationErr := validateNewReviewInput(input)
 	if validationErr.Message != "" {
 		return nil, validationErr
 	}
 
-	dressmaker, err := usecase.DressmakerRepository.FindByID(input.DressmakerID)
+	ucErr := ensureUserCanReview(ctx, usecase.UserRepository, input.UserID)
+	if ucErr.Message != "" {
+		return nil, ucErr
+	}
+
+	dressmaker, err := usecase.DressmakerRepository.FindByID(ctx, input.DressmakerID)
 	if err != nil {
 		return nil, pkg.NewInternalServerError(err)
 	}
@@ -40,21 +58,34 @@ func (usecase *AddDressmakerReviewUseCase) Execute(input AddDressmakerReviewUseC
 		return nil, pkg.NewNotFoundError("dressmaker")
 	}
 
+	existing, err := usecase.DressmakerReviewsRepository.FindByDressmakerAndUser(ctx, input.DressmakerID, input.UserID)
+	if err != nil {
+		return nil, pkg.NewInternalServerError(err)
+	}
+
+	if existing != nil {
+		return nil, pkg.NewEntityAlreadyExistsError("review")
+	}
+
 	review := entity.NewReview(input.DressmakerID, input.UserID, input.Comment, input.Grade)
+	screenReview(usecase.Screener, review)
 
-	// dressmaker.AddReview(*review)
+	// the check above misses a concurrent review by the same user
+	err = usecase.DressmakerReviewsRepository.Create(ctx, review)
+	if errors.Is(err, database.ErrAlreadyExists) {
+		return nil, pkg.NewEntityAlreadyExistsError("review")
+	}
 
-	err = usecase.DressmakerRepository.Update(dressmaker)
 	if err != nil {
 		return nil, pkg.NewInternalServerError(err)
 	}
 
-	err = usecase.DressmakerReviewsRepository.Create(review)
-	if err != nil {
-		return nil, pkg.NewInternalServerError(err)
+	ucErr = applyReviewChange(ctx, usecase.DressmakerRepository, dressmaker.ID, nil, review)
+	if ucErr.Message != "" {
+		return nil, ucErr
 	}
 
-	return dressmaker, pkg.Error{}
+	return review, pkg.Error{}
 }
 
 func validateNewReviewInput(input AddDressmakerReviewUseCaseInput) pkg.Error {
@@ -66,11 +97,15 @@ func validateNewReviewInput(input AddDressmakerReviewUseCaseInput) pkg.Error {
 		return pkg.NewMissingFieldError("user_id")
 	}
 
-	if input.Comment == "" {
+	return validateReviewContent(input.Comment, input.Grade)
+}
+
+func validateReviewContent(comment string, grade float64) pkg.Error {
+	if comment == "" {
 		return pkg.NewMissingFieldError("comment")
 	}
 
-	if input.Grade < 1 || input.Grade > 5 {
+	if grade < 1 || grade > 5 {
 		return pkg.Error{
 			Message: "grade must be between 1 and 5",
 			Status:  400,
@@ -79,3 +114,67 @@ func validateNewReviewInput(input AddDressmakerReviewUseCaseInput) pkg.Error {
 
 	return pkg.Error{}
 }
+
+// ensureUserCanReview only lets registered users whose account was enabled
+// through OTP verification write reviews.
+func ensureUserCanReview(ctx context.Context, repo database.UserRepositoryInterface, userID string) pkg.Error {
+	user, err := repo.FindByID(ctx, userID)
+	if err != nil {
+		return pkg.NewInternalServerError(err)
+	}
+
+	if user == nil {
+		return pkg.NewForbiddenError("only registered users can review dressmakers")
+	}
+
+	if !user.Enabled {
+		return pkg.NewForbiddenError("user must be enabled to review dressmakers")
+	}
+
+	return pkg.Error{}
+}
+
+// screenReview holds a published review for moderation when its comment
+// trips the screener.
+func screenReview(screener *pkg.TextScreener, review *entity.Review) {
+	if !review.IsPublished() {
+		return
+	}
+
+	matches := screener.Screen(review.Comment)
+	if len(matches) == 0 {
+		return
+	}
+
+	review.Hold("automatic screening: " + strings.Join(matches, ", "))
+}
+
+// applyReviewChange updates the dressmaker's aggregates with the difference
+// between the review before and after a change. Only published reviews
+// count towards the grade; a nil review stands for one that doesn't exist.
+func applyReviewChange(ctx context.Context, repo database.DressmakerRepositoryInterface, dressmakerID string, before, after *entity.Review) pkg.Error {
+	beforeCount, beforeGrade := reviewContribution(before)
+	afterCount, afterGrade := reviewContribution(after)
+
+	countDelta := afterCount - beforeCount
+	gradeDelta := afterGrade - beforeGrade
+
+	if countDelta == 0 && gradeDelta == 0 {
+		return pkg.Error{}
+	}
+
+	err := repo.ApplyReviewDelta(ctx, dressmakerID, countDelta, gradeDelta)
+	if err != nil {
+		return pkg.NewInternalServerError(err)
+	}
+
+	return pkg.Error{}
+}
+
+func reviewContribution(review *entity.Review) (int64, float64) {
+	if review == nil || !review.IsPublished() {
+		return 0, 0
+	}
+
+	return 1, review.Grade
+}
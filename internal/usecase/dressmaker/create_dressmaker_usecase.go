@@ -1,6 +1,8 @@
 package usecases
 
 import (
+	"context"
+
 	"github.com/paulozy/costurai/internal/entity"
 	"github.com/paulozy/costurai/internal/infra/database"
 	"github.com/paulozy/costurai/pkg"
@@ -16,13 +18,19 @@ func NewCreateDressMakerUseCase(repo database.DressmakerRepositoryInterface) *Cr
 	}
 }
 
-func (useCase *CreateDressMakerUseCase) Execute(data entity.CreateDressmakerInput) (*entity.Dressmaker, pkg.Error) {
+func (useCase *CreateDressMakerUseCase) Execute(ctx context.Context, data entity.CreateDressmakerInput) (*entity.Dressmaker, pkg.Error) {
 	validationError := validateInput(data)
 	if validationError.Message != "" {
 		return nil, validationError
 	}
 
-	dressmakerAlradyExists, _ := useCase.DressmakerRepository.FindByEmail(data.Email)
+	contact, err := pkg.NormalizePhone(data.Contact)
+	if err != nil {
+		return nil, pkg.NewBadRequestError(err)
+	}
+	data.Contact = contact
+
+	dressmakerAlradyExists, _ := useCase.DressmakerRepository.FindByEmail(ctx, data.Email)
 
 	if dressmakerAlradyExists != nil {
 		return nil, pkg.NewEntityAlreadyExistsError("dressmaker")
@@ -33,7 +41,7 @@ func (useCase *CreateDressMakerUseCase) Execute(data entity.CreateDressmakerInpu
 		return nil, pkg.NewInternalServerError(err)
 	}
 
-	err = useCase.DressmakerRepository.Create(dressmaker)
+	err = useCase.DressmakerRepository.Create(ctx, dressmaker)
 	if err != nil {
 		return nil, pkg.NewInternalServerError(err)
 	}
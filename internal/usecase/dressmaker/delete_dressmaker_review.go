@@ -0,0 +1,55 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/paulozy/costurai/internal/infra/database"
+	"github.com/paulozy/costurai/pkg"
+)
+
+type DeleteDressmakerReviewUseCase struct {
+	DressmakerRepository        database.DressmakerRepositoryInterface
+	DressmakerReviewsRepository database.DressmakerReviewsRepositoryInterface
+}
+
+type DeleteDressmakerReviewUseCaseInput struct {
+	DressmakerID string
+	UserID       string
+}
+
+func NewDeleteDressmakerReviewUseCase(
+	dmRepo database.DressmakerRepositoryInterface,
+	dmrRepo database.DressmakerReviewsRepositoryInterface,
+) *DeleteDressmakerReviewUseCase {
+	return &DeleteDressmakerReviewUseCase{
+		DressmakerRepository:        dmRepo,
+		DressmakerReviewsRepository: dmrRepo,
+	}
+}
+
+func (usecase *DeleteDressmakerReviewUseCase) Execute(ctx context.Context, input DeleteDressmakerReviewUseCaseInput) pkg.Error {
+	dressmaker, err := usecase.DressmakerRepository.FindByID(ctx, input.DressmakerID)
+	if err != nil {
+		return pkg.NewInternalServerError(err)
+	}
+
+	if dressmaker == nil {
+		return pkg.NewNotFoundError("dressmaker")
+	}
+
+	review, err := usecase.DressmakerReviewsRepository.FindByDressmakerAndUser(ctx, input.DressmakerID, input.UserID)
+	if err != nil {
+		return pkg.NewInternalServerError(err)
+	}
+
+	if review == nil {
+		return pkg.NewNotFoundError("review")
+	}
+
+	err = usecase.DressmakerReviewsRepository.Delete(ctx, review.ID)
+	if err != nil {
+		return pkg.NewInternalServerError(err)
+	}
+
+	return applyReviewChange(ctx, usecase.DressmakerRepository, dressmaker.ID, review, nil)
+}
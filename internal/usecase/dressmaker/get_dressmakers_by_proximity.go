@@ -1,16 +1,29 @@
 package usecases
 
 import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+
 	"github.com/paulozy/costurai/internal/entity"
 	"github.com/paulozy/costurai/internal/infra/database"
 	"github.com/paulozy/costurai/pkg"
 	"github.com/paulozy/costurai/pkg/paginator"
 )
 
+const (
+	SortByDistance = "distance"
+	SortByGrade    = "grade"
+	SortByNewest   = "newest"
+)
+
 type GetDressmakersByProximityInput struct {
-	Latitude  float64 `form:"latitude"`
-	Longitude float64 `form:"longitude"`
-	Distance  int     `form:"distance"`
+	Latitude  float64  `form:"latitude"`
+	Longitude float64  `form:"longitude"`
+	Distance  int      `form:"distance"`
+	Services  []string `form:"services"`
+	Sort      string   `form:"sort"`
 
 	Limit int64 `form:"limit"`
 	Page  int64 `form:"page"`
@@ -26,26 +39,123 @@ func NewGetDressmakersByProximityUseCase(repo database.DressmakerRepositoryInter
 	}
 }
 
+type DressmakerSearchItem struct {
+	entity.Dressmaker
+	Distance float64 `json:"distance"` // meters from the searched location
+}
+
 type GetDressmakersByProximityOuput struct {
-	*paginator.Paginate[entity.Dressmaker]
+	*paginator.Paginate[DressmakerSearchItem]
 }
 
-func (useCase *GetDressmakersByProximityUseCase) Execute(data GetDressmakersByProximityInput) (*GetDressmakersByProximityOuput, pkg.Error) {
-	dressmakers, err := useCase.DressMakerRepository.FindByProximity(data.Latitude, data.Longitude, data.Distance)
+func (useCase *GetDressmakersByProximityUseCase) Execute(ctx context.Context, data GetDressmakersByProximityInput) (*GetDressmakersByProximityOuput, pkg.Error) {
+	if data.Sort == "" {
+		data.Sort = SortByDistance
+	}
+
+	if data.Sort != SortByDistance && data.Sort != SortByGrade && data.Sort != SortByNewest {
+		return nil, pkg.Error{
+			Message: "sort must be one of distance, grade or newest",
+			Status:  400,
+		}
+	}
+
+	dressmakers, err := useCase.DressMakerRepository.FindByProximity(ctx, data.Latitude, data.Longitude, data.Distance)
 
 	if err != nil {
 		return nil, pkg.NewInternalServerError(err)
 	}
 
-	offset := paginator.GetOffset(data.Limit, data.Page, dressmakers)
-	paginatedItems := dressmakers[offset.Start:offset.End]
+	services := normalizeServices(data.Services)
+
+	items := []DressmakerSearchItem{}
+	for _, dressmaker := range dressmakers {
+		// disabled dressmakers haven't verified their phone or let their
+		// subscription lapse
+		if !dressmaker.Enabled || dressmaker.Suspended || !offersServices(dressmaker, services) {
+			continue
+		}
+
+		distance := pkg.HaversineDistance(
+			data.Latitude,
+			data.Longitude,
+			dressmaker.Address.Location.Latitude,
+			dressmaker.Address.Location.Longitude,
+		)
+
+		items = append(items, DressmakerSearchItem{
+			Dressmaker: dressmaker,
+			Distance:   math.Round(distance),
+		})
+	}
+
+	sortSearchItems(items, data.Sort)
+
+	offset := paginator.GetOffset(data.Limit, data.Page, items)
+	paginatedItems := items[offset.Start:offset.End]
 
 	response := &GetDressmakersByProximityOuput{
-		Paginate: &paginator.Paginate[entity.Dressmaker]{
+		Paginate: &paginator.Paginate[DressmakerSearchItem]{
 			Items:          &paginatedItems,
-			PaginationInfo: paginator.NewPaginatation(data.Limit, data.Page, int64(len(dressmakers))),
+			PaginationInfo: paginator.NewPaginatation(data.Limit, data.Page, int64(len(items))),
 		},
 	}
 
 	return response, pkg.Error{}
 }
+
+// normalizeServices accepts both repeated (services=a&services=b) and comma
+// separated (services=a,b) values.
+func normalizeServices(values []string) []string {
+	var services []string
+
+	for _, value := range values {
+		for _, service := range strings.Split(value, ",") {
+			normalized := pkg.NormalizeText(service)
+			if normalized != "" {
+				services = append(services, normalized)
+			}
+		}
+	}
+
+	return services
+}
+
+// offersServices reports whether the dressmaker offers every requested
+// service, matching on accent and case insensitive substrings.
+func offersServices(dressmaker entity.Dressmaker, services []string) bool {
+	for _, wanted := range services {
+		found := false
+
+		for _, offered := range dressmaker.Services {
+			if strings.Contains(pkg.NormalizeText(offered), wanted) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+func sortSearchItems(items []DressmakerSearchItem, sortBy string) {
+	sort.SliceStable(items, func(i, j int) bool {
+		switch sortBy {
+		case SortByGrade:
+			// ranked by the Bayesian score rather than the raw average
+			if items[i].Score != items[j].Score {
+				return items[i].Score > items[j].Score
+			}
+		case SortByNewest:
+			if !items[i].CreatedAt.Equal(items[j].CreatedAt) {
+				return items[i].CreatedAt.After(items[j].CreatedAt)
+			}
+		}
+
+		return items[i].Distance < items[j].Distance
+	})
+}
@@ -0,0 +1,160 @@
+package usecases
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/paulozy/costurai/internal/entity"
+	"github.com/paulozy/costurai/internal/infra/database"
+	"github.com/paulozy/costurai/pkg"
+	"github.com/paulozy/costurai/pkg/paginator"
+)
+
+const (
+	SortReviewsByNewest  = "newest"
+	SortReviewsByHighest = "highest"
+	SortReviewsByLowest  = "lowest"
+)
+
+const (
+	defaultReviewsPageLimit = 10
+	maxReviewsPageLimit     = 100
+)
+
+type ListDressmakerReviewsInput struct {
+	DressmakerID string `form:"-"`
+	Sort         string `form:"sort"`
+
+	Limit int64 `form:"limit"`
+	Page  int64 `form:"page"`
+}
+
+type ReviewsSummary struct {
+	Count        int         `json:"count"`
+	Average      float64     `json:"average"`
+	Distribution map[int]int `json:"distribution"` // stars (1-5) -> number of reviews
+}
+
+type ListDressmakerReviewsOutput struct {
+	*paginator.Paginate[entity.Review]
+	Summary ReviewsSummary `json:"summary"`
+}
+
+type ListDressmakerReviewsUseCase struct {
+	DressmakerRepository        database.DressmakerRepositoryInterface
+	DressmakerReviewsRepository database.DressmakerReviewsRepositoryInterface
+}
+
+func NewListDressmakerReviewsUseCase(
+	dmRepo database.DressmakerRepositoryInterface,
+	dmrRepo database.DressmakerReviewsRepositoryInterface,
+) *ListDressmakerReviewsUseCase {
+	return &ListDressmakerReviewsUseCase{
+		DressmakerRepository:        dmRepo,
+		DressmakerReviewsRepository: dmrRepo,
+	}
+}
+
+func (usecase *ListDressmakerReviewsUseCase) Execute(ctx context.Context, input ListDressmakerReviewsInput) (*ListDressmakerReviewsOutput, pkg.Error) {
+	if input.Sort == "" {
+		input.Sort = SortReviewsByNewest
+	}
+
+	if input.Limit < 1 {
+		input.Limit = defaultReviewsPageLimit
+	}
+
+	input.Limit = min(input.Limit, maxReviewsPageLimit)
+	input.Page = max(input.Page, 1)
+
+	if input.Sort != SortReviewsByNewest && input.Sort != SortReviewsByHighest && input.Sort != SortReviewsByLowest {
+		return nil, pkg.Error{
+			Message: "sort must be one of newest, highest or lowest",
+			Status:  400,
+		}
+	}
+
+	dressmaker, err := usecase.DressmakerRepository.FindByID(ctx, input.DressmakerID)
+	if err != nil {
+		return nil, pkg.NewInternalServerError(err)
+	}
+
+	if dressmaker == nil {
+		return nil, pkg.NewNotFoundError("dressmaker")
+	}
+
+	found, err := usecase.DressmakerReviewsRepository.FindByDressmakerID(ctx, input.DressmakerID)
+	if err != nil {
+		return nil, pkg.NewInternalServerError(err)
+	}
+
+	reviews := make([]entity.Review, 0, len(found))
+	for _, review := range found {
+		if review.IsPublished() {
+			reviews = append(reviews, review)
+		}
+	}
+
+	sortReviews(reviews, input.Sort)
+
+	offset := paginator.GetOffset(input.Limit, input.Page, reviews)
+	paginatedItems := reviews[offset.Start:offset.End]
+
+	response := &ListDressmakerReviewsOutput{
+		Paginate: &paginator.Paginate[entity.Review]{
+			Items:          &paginatedItems,
+			PaginationInfo: paginator.NewPaginatation(input.Limit, input.Page, int64(len(reviews))),
+		},
+		Summary: summarizeReviews(reviews),
+	}
+
+	return response, pkg.Error{}
+}
+
+func summarizeReviews(reviews []entity.Review) ReviewsSummary {
+	summary := ReviewsSummary{
+		Count:        len(reviews),
+		Distribution: map[int]int{1: 0, 2: 0, 3: 0, 4: 0, 5: 0},
+	}
+
+	if len(reviews) == 0 {
+		return summary
+	}
+
+	total := 0.0
+	for _, review := range reviews {
+		total += review.Grade
+
+		stars := int(math.Round(review.Grade))
+		stars = max(1, min(5, stars))
+		summary.Distribution[stars]++
+	}
+
+	summary.Average = math.Round(total/float64(len(reviews))*100) / 100
+
+	return summary
+}
+
+func sortReviews(reviews []entity.Review, sortBy string) {
+	sort.SliceStable(reviews, func(i, j int) bool {
+		switch sortBy {
+		case SortReviewsByHighest:
+			if reviews[i].Grade != reviews[j].Grade {
+				return reviews[i].Grade > reviews[j].Grade
+			}
+		case SortReviewsByLowest:
+			if reviews[i].Grade != reviews[j].Grade {
+				return reviews[i].Grade < reviews[j].Grade
+			}
+		}
+
+		return reviewTime(reviews[i]).After(reviewTime(reviews[j]))
+	})
+}
+
+func reviewTime(review entity.Review) time.Time {
+	createdAt, _ := time.Parse(time.RFC3339, review.CreatedAt)
+	return createdAt
+}
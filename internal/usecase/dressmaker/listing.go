@@ -0,0 +1,55 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/paulozy/costurai/internal/entity"
+	"github.com/paulozy/costurai/internal/infra/database"
+)
+
+// FindCurrentSubscription returns the subscription the dressmaker currently
+// pays for, or nil when they have none, to recompute their listing with.
+func FindCurrentSubscription(ctx context.Context, repo database.SubscriptionRepositoryInterface, dressmaker *entity.Dressmaker) (*entity.Subscription, error) {
+	if dressmaker.SubscriptionId == nil {
+		return nil, nil
+	}
+
+	return repo.FindByID(ctx, *dressmaker.SubscriptionId)
+}
+
+// ApplySubscription lists or hides the dressmaker after the subscription
+// changed. A newer subscription only replaces the current one once it grants
+// access, so an abandoned checkout doesn't detach the dressmaker from the
+// subscription they pay for, and changes to a replaced one are ignored.
+func ApplySubscription(
+	ctx context.Context,
+	dmRepo database.DressmakerRepositoryInterface,
+	subRepo database.SubscriptionRepositoryInterface,
+	sub *entity.Subscription,
+) error {
+	dressmaker, err := dmRepo.FindByID(ctx, sub.DressmakerID)
+	if err != nil || dressmaker == nil {
+		return err
+	}
+
+	if dressmaker.SubscriptionId == nil || *dressmaker.SubscriptionId != sub.ID {
+		if !sub.HasAccess() {
+			return nil
+		}
+
+		current, err := FindCurrentSubscription(ctx, subRepo, dressmaker)
+		if err != nil {
+			return err
+		}
+
+		if current != nil && !current.CreatedAt.Before(sub.CreatedAt) {
+			return nil
+		}
+
+		dressmaker.AddSubscription(sub)
+	}
+
+	dressmaker.RefreshListing(sub)
+
+	return dmRepo.Update(ctx, dressmaker)
+}
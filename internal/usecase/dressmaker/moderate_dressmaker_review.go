@@ -0,0 +1,118 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/paulozy/costurai/internal/entity"
+	"github.com/paulozy/costurai/internal/infra/database"
+	"github.com/paulozy/costurai/pkg"
+)
+
+const (
+	ModerationActionApprove = "approve"
+	ModerationActionHide    = "hide"
+)
+
+type ListReviewsForModerationUseCase struct {
+	DressmakerReviewsRepository database.DressmakerReviewsRepositoryInterface
+}
+
+func NewListReviewsForModerationUseCase(dmrRepo database.DressmakerReviewsRepositoryInterface) *ListReviewsForModerationUseCase {
+	return &ListReviewsForModerationUseCase{
+		DressmakerReviewsRepository: dmrRepo,
+	}
+}
+
+// Execute returns the moderation queue: reviews held for moderation and
+// published reviews that have been reported, most reported first.
+func (usecase *ListReviewsForModerationUseCase) Execute(ctx context.Context) ([]ModerationReview, pkg.Error) {
+	reviews, err := usecase.DressmakerReviewsRepository.FindForModeration(ctx)
+	if err != nil {
+		return nil, pkg.NewInternalServerError(err)
+	}
+
+	queue := make([]ModerationReview, 0, len(reviews))
+	for _, review := range reviews {
+		queue = append(queue, newModerationReview(review))
+	}
+
+	return queue, pkg.Error{}
+}
+
+type ModerateDressmakerReviewUseCase struct {
+	DressmakerRepository        database.DressmakerRepositoryInterface
+	DressmakerReviewsRepository database.DressmakerReviewsRepositoryInterface
+	AuditLogRepository          database.AuditLogRepositoryInterface
+}
+
+type ModerateDressmakerReviewUseCaseInput struct {
+	ActorID  string `json:"-"`
+	ReviewID string `json:"-"`
+	Action   string `json:"-"`
+	Reason   string `json:"reason"`
+}
+
+func NewModerateDressmakerReviewUseCase(
+	dmRepo database.DressmakerRepositoryInterface,
+	dmrRepo database.DressmakerReviewsRepositoryInterface,
+	auditLogRepo database.AuditLogRepositoryInterface,
+) *ModerateDressmakerReviewUseCase {
+	return &ModerateDressmakerReviewUseCase{
+		DressmakerRepository:        dmRepo,
+		DressmakerReviewsRepository: dmrRepo,
+		AuditLogRepository:          auditLogRepo,
+	}
+}
+
+// Execute approves a review, publishing it and clearing its reports, or
+// hides it, removing it from listings and from the dressmaker's grade.
+func (usecase *ModerateDressmakerReviewUseCase) Execute(ctx context.Context, input ModerateDressmakerReviewUseCaseInput) (*ModerationReview, pkg.Error) {
+	if input.Action == ModerationActionHide && input.Reason == "" {
+		return nil, pkg.NewMissingFieldError("reason")
+	}
+
+	review, err := usecase.DressmakerReviewsRepository.FindByID(ctx, input.ReviewID)
+	if err != nil {
+		return nil, pkg.NewInternalServerError(err)
+	}
+
+	if review == nil {
+		return nil, pkg.NewNotFoundError("review")
+	}
+
+	previous := *review
+
+	switch input.Action {
+	case ModerationActionApprove:
+		review.Approve()
+	case ModerationActionHide:
+		review.Hide(input.Reason)
+	default:
+		return nil, pkg.Error{
+			Message: "action must be one of approve or hide",
+			Status:  400,
+		}
+	}
+
+	err = usecase.DressmakerReviewsRepository.Update(ctx, review)
+	if err != nil {
+		return nil, pkg.NewInternalServerError(err)
+	}
+
+	ucErr := applyReviewChange(ctx, usecase.DressmakerRepository, review.DressmakerID, &previous, review)
+	if ucErr.Message != "" {
+		return nil, ucErr
+	}
+
+	err = usecase.AuditLogRepository.Create(ctx, entity.NewAuditEntry(
+		input.ActorID, input.Action, entity.AuditTargetReview, review.ID, input.Reason,
+		map[string]any{"status": review.Status},
+	))
+	if err != nil {
+		return nil, pkg.NewInternalServerError(err)
+	}
+
+	moderated := newModerationReview(*review)
+
+	return &moderated, pkg.Error{}
+}
@@ -0,0 +1,98 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/paulozy/costurai/internal/entity"
+	"github.com/paulozy/costurai/internal/infra/database"
+	notification "github.com/paulozy/costurai/internal/infra/services/notification"
+	"github.com/paulozy/costurai/pkg"
+)
+
+type ReplyDressmakerReviewUseCase struct {
+	DressmakerRepository        database.DressmakerRepositoryInterface
+	DressmakerReviewsRepository database.DressmakerReviewsRepositoryInterface
+	UserRepository              database.UserRepositoryInterface
+	NotificationService         notification.NotificationServiceInterface
+}
+
+type ReplyDressmakerReviewUseCaseInput struct {
+	DressmakerID string `json:"-"`
+	ReviewID     string `json:"-"`
+	LoggedID     string `json:"-"`
+	Comment      string `json:"comment"`
+}
+
+func NewReplyDressmakerReviewUseCase(
+	dmRepo database.DressmakerRepositoryInterface,
+	dmrRepo database.DressmakerReviewsRepositoryInterface,
+	userRepo database.UserRepositoryInterface,
+	notificationService notification.NotificationServiceInterface,
+) *ReplyDressmakerReviewUseCase {
+	return &ReplyDressmakerReviewUseCase{
+		DressmakerRepository:        dmRepo,
+		DressmakerReviewsRepository: dmrRepo,
+		UserRepository:              userRepo,
+		NotificationService:         notificationService,
+	}
+}
+
+func (usecase *ReplyDressmakerReviewUseCase) Execute(ctx context.Context, input ReplyDressmakerReviewUseCaseInput) (*entity.Review, pkg.Error) {
+	if input.Comment == "" {
+		return nil, pkg.NewMissingFieldError("comment")
+	}
+
+	if input.LoggedID != input.DressmakerID {
+		return nil, pkg.NewForbiddenError("only the reviewed dressmaker can reply")
+	}
+
+	dressmaker, err := usecase.DressmakerRepository.FindByID(ctx, input.DressmakerID)
+	if err != nil {
+		return nil, pkg.NewInternalServerError(err)
+	}
+
+	if dressmaker == nil {
+		return nil, pkg.NewNotFoundError("dressmaker")
+	}
+
+	review, err := usecase.DressmakerReviewsRepository.FindByID(ctx, input.ReviewID)
+	if err != nil {
+		return nil, pkg.NewInternalServerError(err)
+	}
+
+	if review == nil || review.DressmakerID != dressmaker.ID || review.Status == entity.ReviewStatusHidden {
+		return nil, pkg.NewNotFoundError("review")
+	}
+
+	review.SetReply(input.Comment)
+
+	err = usecase.DressmakerReviewsRepository.Update(ctx, review)
+	if err != nil {
+		return nil, pkg.NewInternalServerError(err)
+	}
+
+	usecase.notifyReviewer(ctx, dressmaker, review)
+
+	return review, pkg.Error{}
+}
+
+// notifyReviewer tells the reviewing user about the reply. Delivery failures
+// are only logged, the reply itself was already saved.
+func (usecase *ReplyDressmakerReviewUseCase) notifyReviewer(ctx context.Context, dressmaker *entity.Dressmaker, review *entity.Review) {
+	user, err := usecase.UserRepository.FindByID(ctx, review.UserID)
+	if err != nil || user == nil {
+		log.Printf("could not load reviewer %s to notify reply: %v", review.UserID, err)
+		return
+	}
+
+	err = usecase.NotificationService.Notify(ctx, notification.Message{
+		To:      user.Email,
+		Subject: fmt.Sprintf("%s respondeu sua avaliação", dressmaker.Name),
+		Body:    review.Reply.Comment,
+	})
+	if err != nil {
+		log.Printf("could not notify reviewer %s: %v", user.ID, err)
+	}
+}
@@ -0,0 +1,102 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/paulozy/costurai/internal/entity"
+	"github.com/paulozy/costurai/internal/infra/database"
+	"github.com/paulozy/costurai/pkg"
+)
+
+const DefaultReviewReportThreshold = 3
+
+type ReportDressmakerReviewUseCase struct {
+	DressmakerRepository        database.DressmakerRepositoryInterface
+	DressmakerReviewsRepository database.DressmakerReviewsRepositoryInterface
+	Threshold                   int
+}
+
+type ReportDressmakerReviewUseCaseInput struct {
+	DressmakerID string `json:"-"`
+	ReviewID     string `json:"-"`
+	ReporterID   string `json:"-"`
+	Reason       string `json:"reason"`
+}
+
+func NewReportDressmakerReviewUseCase(
+	dmRepo database.DressmakerRepositoryInterface,
+	dmrRepo database.DressmakerReviewsRepositoryInterface,
+	threshold int,
+) *ReportDressmakerReviewUseCase {
+	if threshold <= 0 {
+		threshold = DefaultReviewReportThreshold
+	}
+
+	return &ReportDressmakerReviewUseCase{
+		DressmakerRepository:        dmRepo,
+		DressmakerReviewsRepository: dmrRepo,
+		Threshold:                   threshold,
+	}
+}
+
+// Execute records a report from a user or dressmaker. Once the review
+// collects Threshold reports it is held for moderation and stops counting
+// towards the dressmaker's grade.
+func (usecase *ReportDressmakerReviewUseCase) Execute(ctx context.Context, input ReportDressmakerReviewUseCaseInput) pkg.Error {
+	if input.Reason == "" {
+		return pkg.NewMissingFieldError("reason")
+	}
+
+	review, err := usecase.DressmakerReviewsRepository.FindByID(ctx, input.ReviewID)
+	if err != nil {
+		return pkg.NewInternalServerError(err)
+	}
+
+	if review == nil || review.DressmakerID != input.DressmakerID || !review.IsPublished() {
+		return pkg.NewNotFoundError("review")
+	}
+
+	if review.UserID == input.ReporterID {
+		return pkg.NewForbiddenError("users cannot report their own reviews")
+	}
+
+	previous := *review
+	if !review.Report(input.ReporterID, input.Reason) {
+		return pkg.NewEntityAlreadyExistsError("report")
+	}
+
+	if review.ReportCount >= usecase.Threshold {
+		review.Hold(fmt.Sprintf("reported by %d accounts", review.ReportCount))
+	}
+
+	err = usecase.DressmakerReviewsRepository.Update(ctx, review)
+	if err != nil {
+		return pkg.NewInternalServerError(err)
+	}
+
+	return applyReviewChange(ctx, usecase.DressmakerRepository, review.DressmakerID, &previous, review)
+}
+
+// ModerationReview exposes the moderation details that are hidden from the
+// public review listing.
+type ModerationReview struct {
+	entity.Review
+	Reports          []entity.ReviewReport `json:"reports"`
+	ReportCount      int                   `json:"reportCount"`
+	ModerationReason string                `json:"moderationReason"`
+}
+
+func newModerationReview(review entity.Review) ModerationReview {
+	reports := review.Reports
+	if reports == nil {
+		reports = []entity.ReviewReport{}
+	}
+
+	return ModerationReview{
+		Review:           review,
+		Reports:          reports,
+		ReportCount:      review.ReportCount,
+		ModerationReason: review.ModerationReason,
+	}
+}
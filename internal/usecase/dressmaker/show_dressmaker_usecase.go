@@ -1,6 +1,8 @@
 package usecases
 
 import (
+	"context"
+
 	"github.com/paulozy/costurai/internal/entity"
 	"github.com/paulozy/costurai/internal/infra/database"
 	"github.com/paulozy/costurai/pkg"
@@ -20,11 +22,16 @@ type ShowDressMakerInput struct {
 	ID string `json:"id"`
 }
 
-func (uc *ShowDressMakerUseCase) Execute(input ShowDressMakerInput) (*entity.Dressmaker, pkg.Error) {
-	dressMaker, err := uc.DressMakerRepository.FindByID(input.ID)
+func (uc *ShowDressMakerUseCase) Execute(ctx context.Context, input ShowDressMakerInput) (*entity.Dressmaker, pkg.Error) {
+	dressMaker, err := uc.DressMakerRepository.FindByID(ctx, input.ID)
 	if err != nil {
 		return nil, pkg.NewInternalServerError(err)
 	}
 
+	// suspended dressmakers are hidden from customers
+	if dressMaker == nil || dressMaker.Suspended {
+		return nil, pkg.NewNotFoundError("dressmaker")
+	}
+
 	return dressMaker, pkg.Error{}
 }
@@ -0,0 +1,83 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/paulozy/costurai/internal/entity"
+	"github.com/paulozy/costurai/internal/infra/database"
+	"github.com/paulozy/costurai/pkg"
+)
+
+type UpdateDressmakerReviewUseCase struct {
+	DressmakerRepository        database.DressmakerRepositoryInterface
+	DressmakerReviewsRepository database.DressmakerReviewsRepositoryInterface
+	UserRepository              database.UserRepositoryInterface
+	Screener                    *pkg.TextScreener
+}
+
+type UpdateDressmakerReviewUseCaseInput struct {
+	DressmakerID string  `json:"-"`
+	UserID       string  `json:"-"`
+	Comment      string  `json:"comment"`
+	Grade        float64 `json:"grade"`
+}
+
+func NewUpdateDressmakerReviewUseCase(
+	dmRepo database.DressmakerRepositoryInterface,
+	dmrRepo database.DressmakerReviewsRepositoryInterface,
+	userRepo database.UserRepositoryInterface,
+	screener *pkg.TextScreener,
+) *UpdateDressmakerReviewUseCase {
+	return &UpdateDressmakerReviewUseCase{
+		DressmakerRepository:        dmRepo,
+		DressmakerReviewsRepository: dmrRepo,
+		UserRepository:              userRepo,
+		Screener:                    screener,
+	}
+}
+
+func (usecase *UpdateDressmakerReviewUseCase) Execute(ctx context.Context, input UpdateDressmakerReviewUseCaseInput) (*entity.Review, pkg.Error) {
+	validationErr := validateReviewContent(input.Comment, input.Grade)
+	if validationErr.Message != "" {
+		return nil, validationErr
+	}
+
+	ucErr := ensureUserCanReview(ctx, usecase.UserRepository, input.UserID)
+	if ucErr.Message != "" {
+		return nil, ucErr
+	}
+
+	dressmaker, err := usecase.DressmakerRepository.FindByID(ctx, input.DressmakerID)
+	if err != nil {
+		return nil, pkg.NewInternalServerError(err)
+	}
+
+	if dressmaker == nil {
+		return nil, pkg.NewNotFoundError("dressmaker")
+	}
+
+	review, err := usecase.DressmakerReviewsRepository.FindByDressmakerAndUser(ctx, input.DressmakerID, input.UserID)
+	if err != nil {
+		return nil, pkg.NewInternalServerError(err)
+	}
+
+	if review == nil {
+		return nil, pkg.NewNotFoundError("review")
+	}
+
+	previous := *review
+	review.Update(input.Comment, input.Grade)
+	screenReview(usecase.Screener, review)
+
+	err = usecase.DressmakerReviewsRepository.Update(ctx, review)
+	if err != nil {
+		return nil, pkg.NewInternalServerError(err)
+	}
+
+	ucErr = applyReviewChange(ctx, usecase.DressmakerRepository, dressmaker.ID, &previous, review)
+	if ucErr.Message != "" {
+		return nil, ucErr
+	}
+
+	return review, pkg.Error{}
+}
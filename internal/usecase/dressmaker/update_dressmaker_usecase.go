@@ -1,30 +1,50 @@
 package usecases
 
 import (
+	"context"
+
 	"github.com/paulozy/costurai/internal/entity"
 	"github.com/paulozy/costurai/internal/infra/database"
 	"github.com/paulozy/costurai/pkg"
 )
 
 type UpdateDressMakerUseCase struct {
-	DressmakerRepository database.DressmakerRepositoryInterface
+	DressmakerRepository   database.DressmakerRepositoryInterface
+	SubscriptionRepository database.SubscriptionRepositoryInterface
 }
 
-func NewUpdateDressMakerUseCase(repo database.DressmakerRepositoryInterface) *UpdateDressMakerUseCase {
+func NewUpdateDressMakerUseCase(repo database.DressmakerRepositoryInterface, subRepo database.SubscriptionRepositoryInterface) *UpdateDressMakerUseCase {
 	return &UpdateDressMakerUseCase{
-		DressmakerRepository: repo,
+		DressmakerRepository:   repo,
+		SubscriptionRepository: subRepo,
 	}
 }
 
-func (uc *UpdateDressMakerUseCase) Execute(input entity.UpdateDressmakerInput) (*entity.Dressmaker, pkg.Error) {
-	dressmaker, err := uc.DressmakerRepository.FindByID(input.ID)
+func (uc *UpdateDressMakerUseCase) Execute(ctx context.Context, input entity.UpdateDressmakerInput) (*entity.Dressmaker, pkg.Error) {
+	if input.Contact != "" {
+		contact, err := pkg.NormalizePhone(input.Contact)
+		if err != nil {
+			return nil, pkg.NewBadRequestError(err)
+		}
+		input.Contact = contact
+	}
+
+	dressmaker, err := uc.DressmakerRepository.FindByID(ctx, input.ID)
 	if err != nil {
 		return nil, pkg.NewInternalServerError(err)
 	}
 
 	dressmaker.Update(input)
 
-	err = uc.DressmakerRepository.Update(dressmaker)
+	// a new contact hides the dressmaker until it is verified
+	sub, err := FindCurrentSubscription(ctx, uc.SubscriptionRepository, dressmaker)
+	if err != nil {
+		return nil, pkg.NewInternalServerError(err)
+	}
+
+	dressmaker.RefreshListing(sub)
+
+	err = uc.DressmakerRepository.Update(ctx, dressmaker)
 	if err != nil {
 		return nil, pkg.NewInternalServerError(err)
 	}
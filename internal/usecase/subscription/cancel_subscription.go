@@ -0,0 +1,70 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/paulozy/costurai/internal/entity"
+	"github.com/paulozy/costurai/internal/infra/database"
+	services "github.com/paulozy/costurai/internal/infra/services/payment"
+	dressmakerUseCases "github.com/paulozy/costurai/internal/usecase/dressmaker"
+	"github.com/paulozy/costurai/pkg"
+)
+
+type CancelSubscriptionUseCase struct {
+	SubscriptionRepository database.SubscriptionRepositoryInterface
+	DressmakerRepository   database.DressmakerRepositoryInterface
+	PaymentGatewayService  services.PaymentGatewayServiceInterface
+}
+
+func NewCancelSubscriptionUseCase(
+	subRepo database.SubscriptionRepositoryInterface,
+	dmRepo database.DressmakerRepositoryInterface,
+	paymentGatewayService services.PaymentGatewayServiceInterface,
+) *CancelSubscriptionUseCase {
+	return &CancelSubscriptionUseCase{
+		SubscriptionRepository: subRepo,
+		DressmakerRepository:   dmRepo,
+		PaymentGatewayService:  paymentGatewayService,
+	}
+}
+
+// Execute stops the subscription from renewing. The dressmaker stays listed
+// for the rest of the period already paid for, during which the cancellation
+// can be undone.
+func (uc *CancelSubscriptionUseCase) Execute(ctx context.Context, input SubscriptionOwnerInput) (*entity.Subscription, pkg.Error) {
+	sub, ucErr := findOwnedSubscription(ctx, uc.SubscriptionRepository, input)
+	if ucErr.Message != "" {
+		return nil, ucErr
+	}
+
+	if sub.Status == entity.StatusCanceled {
+		return nil, pkg.Error{
+			Message: "subscription is already canceled",
+			Status:  400,
+		}
+	}
+
+	// pending subscriptions were never paid for, so there is no period to
+	// keep and nothing to stop in the gateway
+	if sub.Status == entity.StatusPending {
+		sub.Cancel(0)
+	} else {
+		if sub.GatewayId != nil {
+			if err := uc.PaymentGatewayService.CancelAtPeriodEnd(*sub.GatewayId); err != nil {
+				return nil, paymentGatewayError(err)
+			}
+		}
+
+		sub.CancelAtPeriodEnd()
+	}
+
+	if err := uc.SubscriptionRepository.Update(ctx, sub); err != nil {
+		return nil, pkg.NewInternalServerError(err)
+	}
+
+	if err := dressmakerUseCases.ApplySubscription(ctx, uc.DressmakerRepository, uc.SubscriptionRepository, sub); err != nil {
+		return nil, pkg.NewInternalServerError(err)
+	}
+
+	return sub, pkg.Error{}
+}
@@ -0,0 +1,114 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/paulozy/costurai/internal/entity"
+	"github.com/paulozy/costurai/internal/infra/database"
+	services "github.com/paulozy/costurai/internal/infra/services/payment"
+	"github.com/paulozy/costurai/pkg"
+)
+
+type ChangePlanInput struct {
+	SubscriptionOwnerInput
+	PlanType        entity.PlanType        `json:"planType"`
+	PeriodicityType entity.PeriodicityType `json:"periodicityType"`
+	// ProrationDate is the one returned by the preview, so the amount charged
+	// is the one previewed. It defaults to now.
+	ProrationDate *time.Time `json:"prorationDate"`
+}
+
+type ChangePlanUseCase struct {
+	SubscriptionRepository database.SubscriptionRepositoryInterface
+	PaymentGatewayService  services.PaymentGatewayServiceInterface
+}
+
+func NewChangePlanUseCase(
+	subRepo database.SubscriptionRepositoryInterface,
+	paymentGatewayService services.PaymentGatewayServiceInterface,
+) *ChangePlanUseCase {
+	return &ChangePlanUseCase{
+		SubscriptionRepository: subRepo,
+		PaymentGatewayService:  paymentGatewayService,
+	}
+}
+
+// Execute moves the subscription to another plan type and/or periodicity,
+// charging or crediting the prorated difference.
+func (uc *ChangePlanUseCase) Execute(ctx context.Context, input ChangePlanInput) (*entity.Subscription, pkg.Error) {
+	sub, plan, ucErr := findPlanChange(ctx, uc.SubscriptionRepository, input)
+	if ucErr.Message != "" {
+		return nil, ucErr
+	}
+
+	prorationDate := time.Now()
+	if input.ProrationDate != nil {
+		prorationDate = *input.ProrationDate
+	}
+
+	if prorationDate.After(time.Now()) || (sub.StartedAt != nil && prorationDate.Before(*sub.StartedAt)) {
+		return nil, pkg.Error{
+			Message: "prorationDate must be within the current period",
+			Status:  400,
+		}
+	}
+
+	if err := uc.PaymentGatewayService.ChangePlan(*sub.GatewayId, plan, prorationDate); err != nil {
+		return nil, paymentGatewayError(err)
+	}
+
+	if err := sub.ChangePlan(plan); err != nil {
+		return nil, pkg.NewInternalServerError(err)
+	}
+
+	if err := uc.SubscriptionRepository.Update(ctx, sub); err != nil {
+		return nil, pkg.NewInternalServerError(err)
+	}
+
+	return sub, pkg.Error{}
+}
+
+// findPlanChange returns the subscription the dressmaker wants to change
+// together with the plan they want to move to.
+func findPlanChange(ctx context.Context, repo database.SubscriptionRepositoryInterface, input ChangePlanInput) (*entity.Subscription, entity.Plan, pkg.Error) {
+	if input.PlanType != entity.PlanTypeStandard && input.PlanType != entity.PlanTypePro {
+		return nil, entity.Plan{}, pkg.Error{
+			Message: "planType must be one of standard or pro",
+			Status:  400,
+		}
+	}
+
+	if input.PeriodicityType != entity.MonthlyPeriodicity && input.PeriodicityType != entity.YearlyPeriodicity {
+		return nil, entity.Plan{}, pkg.Error{
+			Message: "periodicityType must be one of monthly or yearly",
+			Status:  400,
+		}
+	}
+
+	sub, ucErr := findOwnedSubscription(ctx, repo, input.SubscriptionOwnerInput)
+	if ucErr.Message != "" {
+		return nil, entity.Plan{}, ucErr
+	}
+
+	if !sub.IsActive() || sub.GatewayId == nil {
+		return nil, entity.Plan{}, pkg.Error{
+			Message: "only active subscriptions paid through the payment gateway can change plans",
+			Status:  400,
+		}
+	}
+
+	plan, err := getPlan(input.PlanType, input.PeriodicityType)
+	if err != nil {
+		return nil, entity.Plan{}, pkg.NewInternalServerError(err)
+	}
+
+	if plan.Name == sub.Plan.Name && plan.Periodicity == sub.Plan.Periodicity {
+		return nil, entity.Plan{}, pkg.Error{
+			Message: "subscription is already on this plan",
+			Status:  400,
+		}
+	}
+
+	return sub, *plan, pkg.Error{}
+}
@@ -1,10 +1,14 @@
 package usecases
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/paulozy/costurai/configs"
 	"github.com/paulozy/costurai/internal/entity"
 	"github.com/paulozy/costurai/internal/infra/database"
 	services "github.com/paulozy/costurai/internal/infra/services/payment"
+	dressmakerUseCases "github.com/paulozy/costurai/internal/usecase/dressmaker"
 	"github.com/paulozy/costurai/pkg"
 )
 
@@ -30,13 +34,13 @@ func NewCreateSubscriptionUseCase(
 }
 
 type CreateSubscriptionInput struct {
-	DressmakerID    string                 `json:"dressmakerId"`
+	DressmakerID    string                 `json:"-"`
 	PlanType        entity.PlanType        `json:"planType"`
 	PeriodicityType entity.PeriodicityType `json:"periodicityType"`
 }
 
-func (uc *CreateSubscriptionUseCase) Execute(input CreateSubscriptionInput) (*entity.Subscription, pkg.Error) {
-	dressmaker, err := uc.DressmakerRepository.FindByID(input.DressmakerID)
+func (uc *CreateSubscriptionUseCase) Execute(ctx context.Context, input CreateSubscriptionInput) (*entity.Subscription, pkg.Error) {
+	dressmaker, err := uc.DressmakerRepository.FindByID(ctx, input.DressmakerID)
 	if err != nil {
 		return nil, pkg.Error{
 			Error:   err.Error(),
@@ -44,7 +48,22 @@ func (uc *CreateSubscriptionUseCase) Execute(input CreateSubscriptionInput) (*en
 		}
 	}
 
-	plan, err := uc.getPlan(input.PlanType, input.PeriodicityType)
+	// a second subscription would leave the current one billing at the
+	// gateway, so plans are changed on the current one instead
+	current, err := dressmakerUseCases.FindCurrentSubscription(ctx, uc.SubscriptionRepository, dressmaker)
+	if err != nil {
+		return nil, pkg.NewInternalServerError(err)
+	}
+
+	if current != nil && current.HasAccess() {
+		return nil, pkg.Error{
+			Message: "Dressmaker already has a subscription",
+			Error:   fmt.Sprintf("change the plan of subscription %s with POST /subscriptions/%s/plan", current.ID, current.ID),
+			Status:  409,
+		}
+	}
+
+	plan, err := getPlan(input.PlanType, input.PeriodicityType)
 	if err != nil {
 		return nil, pkg.Error{
 			Error:   err.Error(),
@@ -76,7 +95,7 @@ func (uc *CreateSubscriptionUseCase) Execute(input CreateSubscriptionInput) (*en
 	}
 
 	subscription.PaymentURL = &redirectURL
-	err = uc.SubscriptionRepository.Create(subscription)
+	err = uc.SubscriptionRepository.Create(ctx, subscription)
 	if err != nil {
 		return nil, pkg.Error{
 			Error:   err.Error(),
@@ -84,8 +103,14 @@ func (uc *CreateSubscriptionUseCase) Execute(input CreateSubscriptionInput) (*en
 		}
 	}
 
-	dressmaker.SubscriptionId = &subscription.ID
-	err = uc.DressmakerRepository.Update(dressmaker)
+	// a dressmaker already subscribed moves to the new subscription once its
+	// checkout completes, see dressmakerUseCases.ApplySubscription
+	if dressmaker.SubscriptionId != nil {
+		return subscription, pkg.Error{}
+	}
+
+	dressmaker.AddSubscription(subscription)
+	err = uc.DressmakerRepository.Update(ctx, dressmaker)
 	if err != nil {
 		return nil, pkg.Error{
 			Error:   err.Error(),
@@ -96,7 +121,7 @@ func (uc *CreateSubscriptionUseCase) Execute(input CreateSubscriptionInput) (*en
 	return subscription, pkg.Error{}
 }
 
-func (uc *CreateSubscriptionUseCase) getPlan(planType entity.PlanType, periodicity entity.PeriodicityType) (*entity.Plan, error) {
+func getPlan(planType entity.PlanType, periodicity entity.PeriodicityType) (*entity.Plan, error) {
 	plannerPrice := pkg.NewPlannerPrice()
 	planBuilder := entity.NewPlanBuilder()
 	prePlan := planBuilder.WithType(planType).WithPeriodicity(periodicity)
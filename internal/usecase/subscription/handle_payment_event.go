@@ -0,0 +1,138 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/paulozy/costurai/configs"
+	"github.com/paulozy/costurai/internal/entity"
+	"github.com/paulozy/costurai/internal/infra/database"
+	services "github.com/paulozy/costurai/internal/infra/services/payment"
+	dressmakerUseCases "github.com/paulozy/costurai/internal/usecase/dressmaker"
+	"github.com/paulozy/costurai/pkg"
+)
+
+const defaultGracePeriodDays = 7
+
+type HandlePaymentEventUseCase struct {
+	SubscriptionRepository database.SubscriptionRepositoryInterface
+	DressmakerRepository   database.DressmakerRepositoryInterface
+	GracePeriodDays        int
+}
+
+func NewHandlePaymentEventUseCase(
+	subRepo database.SubscriptionRepositoryInterface,
+	dmRepo database.DressmakerRepositoryInterface,
+	cfg *configs.Config,
+) *HandlePaymentEventUseCase {
+	graceDays := defaultGracePeriodDays
+	if cfg.SubscriptionGracePeriodDays > 0 {
+		graceDays = cfg.SubscriptionGracePeriodDays
+	}
+
+	return &HandlePaymentEventUseCase{
+		SubscriptionRepository: subRepo,
+		DressmakerRepository:   dmRepo,
+		GracePeriodDays:        graceDays,
+	}
+}
+
+// Execute applies a payment gateway event to the subscription it is about,
+// and lists or hides the dressmaker depending on whether it still grants
+// access. Events older than the last one applied are ignored.
+func (uc *HandlePaymentEventUseCase) Execute(ctx context.Context, event services.PaymentEvent) (entity.WebhookEventStatus, pkg.Error) {
+	if event.Type == services.PaymentEventIgnored {
+		return entity.WebhookEventIgnored, pkg.Error{}
+	}
+
+	sub, err := uc.findSubscription(ctx, event)
+	if err != nil {
+		return "", pkg.NewInternalServerError(err)
+	}
+
+	if sub == nil {
+		return "", pkg.NewNotFoundError("subscription")
+	}
+
+	if sub.IsStaleEvent(event.CreatedAt) {
+		return entity.WebhookEventIgnored, pkg.Error{}
+	}
+
+	if err := uc.apply(sub, event); err != nil {
+		return "", pkg.Error{
+			Message: "Error applying payment event",
+			Error:   err.Error(),
+			Status:  422,
+		}
+	}
+
+	sub.RecordEvent(event.CreatedAt)
+
+	if err := uc.SubscriptionRepository.Update(ctx, sub); err != nil {
+		return "", pkg.NewInternalServerError(err)
+	}
+
+	if err := dressmakerUseCases.ApplySubscription(ctx, uc.DressmakerRepository, uc.SubscriptionRepository, sub); err != nil {
+		return "", pkg.NewInternalServerError(err)
+	}
+
+	return entity.WebhookEventProcessed, pkg.Error{}
+}
+
+func (uc *HandlePaymentEventUseCase) findSubscription(ctx context.Context, event services.PaymentEvent) (*entity.Subscription, error) {
+	if event.SubscriptionID != "" {
+		return uc.SubscriptionRepository.FindByID(ctx, event.SubscriptionID)
+	}
+
+	if event.GatewaySubscriptionID != "" {
+		return uc.SubscriptionRepository.FindByGatewayID(ctx, event.GatewaySubscriptionID)
+	}
+
+	return nil, nil
+}
+
+func (uc *HandlePaymentEventUseCase) apply(sub *entity.Subscription, event services.PaymentEvent) error {
+	switch event.Type {
+	case services.PaymentEventCheckoutCompleted:
+		sub.Activate(event.GatewaySubscriptionID)
+	case services.PaymentEventInvoicePaid:
+		if sub.GatewayId == nil && event.GatewaySubscriptionID != "" {
+			sub.GatewayId = &event.GatewaySubscriptionID
+		}
+
+		return sub.Renew()
+	case services.PaymentEventInvoicePaymentFail:
+		sub.StartGracePeriod(uc.GracePeriodDays)
+	case services.PaymentEventSubscriptionDeleted:
+		uc.cancel(sub)
+	case services.PaymentEventSubscriptionUpdated:
+		switch event.GatewayStatus {
+		case services.GatewayStatusActive:
+			// a subscription canceled at period end stays active in the
+			// gateway until the period is over
+			if event.CancelAtPeriodEnd {
+				if sub.Status != entity.StatusCanceled {
+					sub.CancelAtPeriodEnd()
+				}
+			} else if sub.Status != entity.StatusActive {
+				sub.Activate(event.GatewaySubscriptionID)
+			}
+		case services.GatewayStatusPastDue:
+			sub.StartGracePeriod(uc.GracePeriodDays)
+		case services.GatewayStatusCanceled:
+			uc.cancel(sub)
+		}
+	}
+
+	return nil
+}
+
+// cancel ends access right away, since the gateway only ends a subscription
+// once its paid period or retries are over.
+func (uc *HandlePaymentEventUseCase) cancel(sub *entity.Subscription) {
+	if sub.Status == entity.StatusCanceled {
+		return
+	}
+
+	sub.Cancel(0)
+	sub.GraceUntil = nil
+}
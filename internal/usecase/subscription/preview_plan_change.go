@@ -0,0 +1,56 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/paulozy/costurai/internal/entity"
+	"github.com/paulozy/costurai/internal/infra/database"
+	services "github.com/paulozy/costurai/internal/infra/services/payment"
+	"github.com/paulozy/costurai/pkg"
+)
+
+type PlanChangePreviewOutput struct {
+	Plan entity.Plan `json:"plan"`
+	// ProratedAmount is charged right away when positive and credited
+	// towards the next invoices when negative, in the currency's minor unit.
+	ProratedAmount int64     `json:"proratedAmount"`
+	Currency       string    `json:"currency"`
+	ProrationDate  time.Time `json:"prorationDate"` // to send back when confirming
+}
+
+type PreviewPlanChangeUseCase struct {
+	SubscriptionRepository database.SubscriptionRepositoryInterface
+	PaymentGatewayService  services.PaymentGatewayServiceInterface
+}
+
+func NewPreviewPlanChangeUseCase(
+	subRepo database.SubscriptionRepositoryInterface,
+	paymentGatewayService services.PaymentGatewayServiceInterface,
+) *PreviewPlanChangeUseCase {
+	return &PreviewPlanChangeUseCase{
+		SubscriptionRepository: subRepo,
+		PaymentGatewayService:  paymentGatewayService,
+	}
+}
+
+// Execute returns what moving to the plan would cost, without changing
+// anything.
+func (uc *PreviewPlanChangeUseCase) Execute(ctx context.Context, input ChangePlanInput) (PlanChangePreviewOutput, pkg.Error) {
+	sub, plan, ucErr := findPlanChange(ctx, uc.SubscriptionRepository, input)
+	if ucErr.Message != "" {
+		return PlanChangePreviewOutput{}, ucErr
+	}
+
+	preview, err := uc.PaymentGatewayService.PreviewPlanChange(*sub.GatewayId, plan, time.Now().Truncate(time.Second))
+	if err != nil {
+		return PlanChangePreviewOutput{}, paymentGatewayError(err)
+	}
+
+	return PlanChangePreviewOutput{
+		Plan:           plan,
+		ProratedAmount: preview.Amount,
+		Currency:       preview.Currency,
+		ProrationDate:  preview.ProrationDate,
+	}, pkg.Error{}
+}
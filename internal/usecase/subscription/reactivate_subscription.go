@@ -0,0 +1,62 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/paulozy/costurai/internal/entity"
+	"github.com/paulozy/costurai/internal/infra/database"
+	services "github.com/paulozy/costurai/internal/infra/services/payment"
+	dressmakerUseCases "github.com/paulozy/costurai/internal/usecase/dressmaker"
+	"github.com/paulozy/costurai/pkg"
+)
+
+type ReactivateSubscriptionUseCase struct {
+	SubscriptionRepository database.SubscriptionRepositoryInterface
+	DressmakerRepository   database.DressmakerRepositoryInterface
+	PaymentGatewayService  services.PaymentGatewayServiceInterface
+}
+
+func NewReactivateSubscriptionUseCase(
+	subRepo database.SubscriptionRepositoryInterface,
+	dmRepo database.DressmakerRepositoryInterface,
+	paymentGatewayService services.PaymentGatewayServiceInterface,
+) *ReactivateSubscriptionUseCase {
+	return &ReactivateSubscriptionUseCase{
+		SubscriptionRepository: subRepo,
+		DressmakerRepository:   dmRepo,
+		PaymentGatewayService:  paymentGatewayService,
+	}
+}
+
+// Execute undoes a cancellation while the grace period lasts, so the
+// subscription renews again. Afterwards a new subscription is needed.
+func (uc *ReactivateSubscriptionUseCase) Execute(ctx context.Context, input SubscriptionOwnerInput) (*entity.Subscription, pkg.Error) {
+	sub, ucErr := findOwnedSubscription(ctx, uc.SubscriptionRepository, input)
+	if ucErr.Message != "" {
+		return nil, ucErr
+	}
+
+	if err := sub.Reactivate(); err != nil {
+		return nil, pkg.Error{
+			Message: "subscription can't be reactivated",
+			Error:   err.Error(),
+			Status:  400,
+		}
+	}
+
+	if sub.GatewayId != nil {
+		if err := uc.PaymentGatewayService.Resume(*sub.GatewayId); err != nil {
+			return nil, paymentGatewayError(err)
+		}
+	}
+
+	if err := uc.SubscriptionRepository.Update(ctx, sub); err != nil {
+		return nil, pkg.NewInternalServerError(err)
+	}
+
+	if err := dressmakerUseCases.ApplySubscription(ctx, uc.DressmakerRepository, uc.SubscriptionRepository, sub); err != nil {
+		return nil, pkg.NewInternalServerError(err)
+	}
+
+	return sub, pkg.Error{}
+}
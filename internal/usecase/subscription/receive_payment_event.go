@@ -0,0 +1,117 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/paulozy/costurai/configs"
+	"github.com/paulozy/costurai/internal/entity"
+	"github.com/paulozy/costurai/internal/infra/database"
+	services "github.com/paulozy/costurai/internal/infra/services/payment"
+	"github.com/paulozy/costurai/pkg"
+)
+
+// defaultWebhookEventLease is how long a delivery is given to process an
+// event when requests have no timeout.
+const defaultWebhookEventLease = 5 * time.Minute
+
+type ReceivePaymentEventUseCase struct {
+	WebhookEventRepository    database.WebhookEventRepositoryInterface
+	HandlePaymentEventUseCase *HandlePaymentEventUseCase
+	// Lease is how long an event stays claimed by the delivery processing
+	// it. Past it, the delivery is assumed dead and a retry takes over.
+	Lease time.Duration
+}
+
+func NewReceivePaymentEventUseCase(
+	webhookEventRepo database.WebhookEventRepositoryInterface,
+	handlePaymentEventUseCase *HandlePaymentEventUseCase,
+	cfg *configs.Config,
+) *ReceivePaymentEventUseCase {
+	return &ReceivePaymentEventUseCase{
+		WebhookEventRepository:    webhookEventRepo,
+		HandlePaymentEventUseCase: handlePaymentEventUseCase,
+		Lease:                     webhookEventLease(cfg),
+	}
+}
+
+func webhookEventLease(cfg *configs.Config) time.Duration {
+	if cfg != nil && cfg.RequestTimeout > 0 {
+		return time.Duration(cfg.RequestTimeout) * time.Second
+	}
+
+	return defaultWebhookEventLease
+}
+
+// Execute stores a delivered event before handling it. Gateways retry
+// deliveries, so an event already processed is acknowledged without being
+// applied again, while one that failed, or whose processing outlived the
+// lease, is retried.
+func (uc *ReceivePaymentEventUseCase) Execute(ctx context.Context, event services.PaymentEvent, payload []byte) pkg.Error {
+	stored := entity.NewWebhookEvent(event.ID, event.Gateway, event.GatewayType, payload, event.CreatedAt)
+
+	created, err := uc.WebhookEventRepository.Create(ctx, stored)
+	if err != nil {
+		return pkg.NewInternalServerError(err)
+	}
+
+	if !created {
+		stored, err = uc.WebhookEventRepository.FindByID(ctx, event.ID)
+		if err != nil {
+			return pkg.NewInternalServerError(err)
+		}
+
+		if stored == nil {
+			return pkg.NewNotFoundError("webhook event")
+		}
+
+		if stored.IsSettled() {
+			return pkg.Error{}
+		}
+
+		claimed, err := uc.WebhookEventRepository.Claim(ctx, event.ID, time.Now().Add(-uc.Lease), false)
+		if err != nil {
+			return pkg.NewInternalServerError(err)
+		}
+
+		// the gateway retries the delivery later, once the first one is done
+		if !claimed {
+			return pkg.Error{
+				Message: "event is already being processed",
+				Status:  409,
+			}
+		}
+
+		stored.Claim()
+	}
+
+	return processWebhookEvent(ctx, uc.WebhookEventRepository, uc.HandlePaymentEventUseCase, stored, event)
+}
+
+// processWebhookEvent handles the event and records the outcome on the
+// stored event.
+func processWebhookEvent(
+	ctx context.Context,
+	repo database.WebhookEventRepositoryInterface,
+	handler *HandlePaymentEventUseCase,
+	stored *entity.WebhookEvent,
+	event services.PaymentEvent,
+) pkg.Error {
+	status, ucErr := handler.Execute(ctx, event)
+	if ucErr.Message != "" {
+		reason := ucErr.Message
+		if ucErr.Error != "" {
+			reason += ": " + ucErr.Error
+		}
+
+		stored.Finish(entity.WebhookEventFailed, reason)
+	} else {
+		stored.Finish(status, "")
+	}
+
+	if err := repo.Update(ctx, stored); err != nil {
+		return pkg.NewInternalServerError(err)
+	}
+
+	return ucErr
+}
@@ -0,0 +1,84 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/paulozy/costurai/configs"
+	"github.com/paulozy/costurai/internal/entity"
+	"github.com/paulozy/costurai/internal/infra/database"
+	services "github.com/paulozy/costurai/internal/infra/services/payment"
+	"github.com/paulozy/costurai/pkg"
+)
+
+type ReplayPaymentEventUseCase struct {
+	WebhookEventRepository    database.WebhookEventRepositoryInterface
+	PaymentWebhook            services.PaymentWebhookInterface
+	HandlePaymentEventUseCase *HandlePaymentEventUseCase
+	// Lease is how long a live delivery keeps the event, see
+	// ReceivePaymentEventUseCase.
+	Lease time.Duration
+}
+
+func NewReplayPaymentEventUseCase(
+	webhookEventRepo database.WebhookEventRepositoryInterface,
+	paymentWebhook services.PaymentWebhookInterface,
+	handlePaymentEventUseCase *HandlePaymentEventUseCase,
+	cfg *configs.Config,
+) *ReplayPaymentEventUseCase {
+	return &ReplayPaymentEventUseCase{
+		WebhookEventRepository:    webhookEventRepo,
+		PaymentWebhook:            paymentWebhook,
+		HandlePaymentEventUseCase: handlePaymentEventUseCase,
+		Lease:                     webhookEventLease(cfg),
+	}
+}
+
+// Execute handles a stored event again. Events already processed or ignored
+// are only applied again when forced, since applying an event such as a paid
+// invoice twice changes the subscription again. Events a delivery is
+// processing are left alone, and events older than the last one applied to
+// the subscription are still ignored.
+func (uc *ReplayPaymentEventUseCase) Execute(ctx context.Context, id string, force bool) (*entity.WebhookEvent, pkg.Error) {
+	stored, err := uc.WebhookEventRepository.FindByID(ctx, id)
+	if err != nil {
+		return nil, pkg.NewInternalServerError(err)
+	}
+
+	if stored == nil {
+		return nil, pkg.NewNotFoundError("webhook event")
+	}
+
+	event, err := uc.PaymentWebhook.DecodeEvent(stored.Payload)
+	if err != nil {
+		return nil, pkg.Error{
+			Message: "Error decoding the stored event",
+			Error:   err.Error(),
+			Status:  422,
+		}
+	}
+
+	if stored.IsSettled() && !force {
+		return nil, pkg.Error{
+			Message: "Event was already " + string(stored.Status) + ", force the replay to apply it again",
+			Status:  409,
+		}
+	}
+
+	claimed, err := uc.WebhookEventRepository.Claim(ctx, id, time.Now().Add(-uc.Lease), force)
+	if err != nil {
+		return nil, pkg.NewInternalServerError(err)
+	}
+
+	if !claimed {
+		return nil, pkg.Error{
+			Message: "Event is being processed",
+			Status:  409,
+		}
+	}
+
+	stored.Claim()
+	ucErr := processWebhookEvent(ctx, uc.WebhookEventRepository, uc.HandlePaymentEventUseCase, stored, *event)
+
+	return stored, ucErr
+}
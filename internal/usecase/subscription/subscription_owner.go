@@ -0,0 +1,41 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/paulozy/costurai/internal/entity"
+	"github.com/paulozy/costurai/internal/infra/database"
+	"github.com/paulozy/costurai/pkg"
+)
+
+// SubscriptionOwnerInput identifies a subscription acted on by the
+// dressmaker who pays for it.
+type SubscriptionOwnerInput struct {
+	DressmakerID string `json:"-"`
+	ID           string `json:"-"`
+}
+
+func findOwnedSubscription(ctx context.Context, repo database.SubscriptionRepositoryInterface, input SubscriptionOwnerInput) (*entity.Subscription, pkg.Error) {
+	sub, err := repo.FindByID(ctx, input.ID)
+	if err != nil {
+		return nil, pkg.NewInternalServerError(err)
+	}
+
+	if sub == nil {
+		return nil, pkg.NewNotFoundError("subscription")
+	}
+
+	if sub.DressmakerID != input.DressmakerID {
+		return nil, pkg.NewForbiddenError("only the dressmaker who owns the subscription can change it")
+	}
+
+	return sub, pkg.Error{}
+}
+
+func paymentGatewayError(err error) pkg.Error {
+	return pkg.Error{
+		Message: "Error updating the subscription in the payment gateway",
+		Error:   err.Error(),
+		Status:  502,
+	}
+}
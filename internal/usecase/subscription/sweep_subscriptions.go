@@ -0,0 +1,138 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/paulozy/costurai/configs"
+	"github.com/paulozy/costurai/internal/entity"
+	"github.com/paulozy/costurai/internal/infra/database"
+	notification "github.com/paulozy/costurai/internal/infra/services/notification"
+	dressmakerUseCases "github.com/paulozy/costurai/internal/usecase/dressmaker"
+	"github.com/paulozy/costurai/pkg"
+)
+
+const defaultExpiryNoticeDays = 3
+
+type SweepSubscriptionsOutput struct {
+	Lapsed   int `json:"lapsed"` // moved into the grace period or expired
+	Notified int `json:"notified"`
+	Failed   int `json:"failed"`
+}
+
+type SweepSubscriptionsUseCase struct {
+	SubscriptionRepository database.SubscriptionRepositoryInterface
+	DressmakerRepository   database.DressmakerRepositoryInterface
+	NotificationService    notification.NotificationServiceInterface
+	GracePeriodDays        int
+	ExpiryNotice           time.Duration
+}
+
+func NewSweepSubscriptionsUseCase(
+	subRepo database.SubscriptionRepositoryInterface,
+	dmRepo database.DressmakerRepositoryInterface,
+	notificationService notification.NotificationServiceInterface,
+	cfg *configs.Config,
+) *SweepSubscriptionsUseCase {
+	graceDays := defaultGracePeriodDays
+	if cfg.SubscriptionGracePeriodDays > 0 {
+		graceDays = cfg.SubscriptionGracePeriodDays
+	}
+
+	noticeDays := defaultExpiryNoticeDays
+	if cfg.SubscriptionExpiryNoticeDays > 0 {
+		noticeDays = cfg.SubscriptionExpiryNoticeDays
+	}
+
+	return &SweepSubscriptionsUseCase{
+		SubscriptionRepository: subRepo,
+		DressmakerRepository:   dmRepo,
+		NotificationService:    notificationService,
+		GracePeriodDays:        graceDays,
+		ExpiryNotice:           time.Duration(noticeDays) * 24 * time.Hour,
+	}
+}
+
+// Execute catches up with subscriptions whose period ended without the
+// gateway renewing them: they get the grace period and then expire, hiding
+// the dressmaker. Dressmakers whose access is about to end are warned
+// beforehand. A subscription that fails is retried on the next sweep.
+func (uc *SweepSubscriptionsUseCase) Execute(ctx context.Context) (*SweepSubscriptionsOutput, pkg.Error) {
+	subs, err := uc.SubscriptionRepository.FindLapsing(ctx, time.Now().Add(uc.ExpiryNotice))
+	if err != nil {
+		return nil, pkg.NewInternalServerError(err)
+	}
+
+	output := &SweepSubscriptionsOutput{}
+	for i := range subs {
+		if err := uc.sweep(ctx, &subs[i], output); err != nil {
+			log.Printf("could not sweep subscription %s: %v", subs[i].ID, err)
+			output.Failed++
+		}
+	}
+
+	return output, pkg.Error{}
+}
+
+func (uc *SweepSubscriptionsUseCase) sweep(ctx context.Context, sub *entity.Subscription, output *SweepSubscriptionsOutput) error {
+	readUpdatedAt := sub.UpdatedAt
+	lapsed := sub.Lapse(uc.GracePeriodDays)
+	notify := sub.NeedsExpiryNotice(uc.ExpiryNotice)
+
+	if !lapsed && !notify {
+		return nil
+	}
+
+	if notify && uc.notify(ctx, sub) {
+		sub.RecordExpiryNotice()
+		output.Notified++
+	}
+
+	// a payment may have renewed the subscription since it was read, and the
+	// next sweep looks at it again anyway
+	saved, err := uc.SubscriptionRepository.UpdateIfUnchanged(ctx, sub, readUpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	if !saved {
+		return nil
+	}
+
+	if lapsed {
+		if err := dressmakerUseCases.ApplySubscription(ctx, uc.DressmakerRepository, uc.SubscriptionRepository, sub); err != nil {
+			return err
+		}
+
+		output.Lapsed++
+	}
+
+	return nil
+}
+
+// notify reports whether the dressmaker was warned, so failed notices are
+// sent again on the next sweep.
+func (uc *SweepSubscriptionsUseCase) notify(ctx context.Context, sub *entity.Subscription) bool {
+	dressmaker, err := uc.DressmakerRepository.FindByID(ctx, sub.DressmakerID)
+	if err != nil || dressmaker == nil {
+		log.Printf("could not find dressmaker %s to warn about subscription %s: %v", sub.DressmakerID, sub.ID, err)
+		return false
+	}
+
+	err = uc.NotificationService.Notify(ctx, notification.Message{
+		To:      dressmaker.Email,
+		Subject: "Sua assinatura está terminando",
+		Body: fmt.Sprintf(
+			"Olá, %s! Seu acesso ao Costurai termina em %s. Renove sua assinatura para continuar aparecendo nas buscas.",
+			dressmaker.Name, sub.AccessEndsAt().Format("02/01/2006"),
+		),
+	})
+	if err != nil {
+		log.Printf("could not warn dressmaker %s about subscription %s: %v", dressmaker.ID, sub.ID, err)
+		return false
+	}
+
+	return true
+}
@@ -1,6 +1,8 @@
 package usecases
 
 import (
+	"context"
+
 	"errors"
 
 	"github.com/paulozy/costurai/internal/entity"
@@ -13,9 +15,10 @@ type CreateUserUseCase struct {
 }
 
 type CreateUserUseCaseInput struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
-	Name     string `json:"name"`
+	Email     string  `json:"email"`
+	Password  string  `json:"password"`
+	Name      string  `json:"name"`
+	Phone     string  `json:"phone"`
 	Latitude  float64 `json:"latitude"`
 	Longitude float64 `json:"longitude"`
 }
@@ -26,13 +29,13 @@ func NewCreateUserUseCase(repo database.UserRepositoryInterface) *CreateUserUseC
 	}
 }
 
-func (useCase *CreateUserUseCase) Execute(data CreateUserUseCaseInput) (*entity.User, pkg.Error) {
+func (useCase *CreateUserUseCase) Execute(ctx context.Context, data CreateUserUseCaseInput) (*entity.User, pkg.Error) {
 	err := validateUserInput(data)
 	if err != nil {
 		return nil, pkg.NewBadRequestError(err)
 	}
 
-	userAlreadyExists, _ := useCase.UserRepository.FindByEmail(data.Email)
+	userAlreadyExists, _ := useCase.UserRepository.FindByEmail(ctx, data.Email)
 	if userAlreadyExists != nil {
 		return nil, pkg.NewEntityAlreadyExistsError("user")
 	}
@@ -47,7 +50,15 @@ func (useCase *CreateUserUseCase) Execute(data CreateUserUseCaseInput) (*entity.
 		return nil, pkg.NewInternalServerError(err)
 	}
 
-	err = useCase.UserRepository.Create(user)
+	if data.Phone != "" {
+		phone, err := pkg.NormalizePhone(data.Phone)
+		if err != nil {
+			return nil, pkg.NewBadRequestError(err)
+		}
+		user.SetPhone(phone)
+	}
+
+	err = useCase.UserRepository.Create(ctx, user)
 	if err != nil {
 		return nil, pkg.NewInternalServerError(err)
 	}
@@ -85,4 +96,4 @@ func validateUserInput(data CreateUserUseCaseInput) error {
 	}
 
 	return nil
-}
\ No newline at end of file
+}
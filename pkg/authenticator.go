@@ -1,66 +1,88 @@
 package pkg
 
 import (
+	"errors"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
-	"github.com/paulozy/costurai/configs"
 )
 
+// Role tells which kind of account a token was issued to.
+type Role string
+
+const (
+	RoleUser       Role = "user"
+	RoleDressmaker Role = "dressmaker"
+	RoleAdmin      Role = "admin"
+)
+
+var errKeyManagerNotInitialized = errors.New("jwt key manager not initialized")
+
 type GenerateTokenInput struct {
-	Issuer  string
-	Subject string
+	Issuer    string
+	Subject   string
+	Role      Role
+	SessionID string        // refresh token family the access token belongs to
+	ExpiresIn time.Duration // defaults to the key manager's TTL
 }
 
+// GenerateToken signs a token with the active key of the key manager set
+// by InitKeyManager.
 func GenerateToken(data GenerateTokenInput) (string, error) {
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"iss": data.Issuer,
-		"sub": data.Subject,
-		"exp": time.Now().Add(time.Hour * time.Duration(getJWTExpiration())).Unix(),
-	})
+	if keyManager == nil {
+		return "", errKeyManagerNotInitialized
+	}
 
-	tokenString, err := token.SignedString([]byte(getJWTSecretKey()))
-	if err != nil {
-		return "", err
+	expiresIn := data.ExpiresIn
+	if expiresIn == 0 {
+		expiresIn = keyManager.defaultTTL
 	}
 
-	return tokenString, nil
+	return keyManager.Sign(jwt.MapClaims{
+		"iss":  data.Issuer,
+		"sub":  data.Subject,
+		"role": data.Role,
+		"sid":  data.SessionID,
+		"exp":  time.Now().Add(expiresIn).Unix(),
+	})
 }
 
 func ParseToken(tokenString string) (*jwt.Token, error) {
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, jwt.ErrSignatureInvalid
-		}
+	if keyManager == nil {
+		return nil, errKeyManagerNotInitialized
+	}
 
-		return []byte(getJWTSecretKey()), nil
-	})
+	return keyManager.Parse(tokenString)
+}
 
-	if err != nil {
-		return nil, err
+// GetRole reads the role claim of a parsed token. Tokens issued before roles
+// existed have none and get an error.
+func GetRole(token *jwt.Token) (Role, error) {
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return "", jwt.ErrTokenInvalidClaims
 	}
 
-	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-		_ = claims
+	role, ok := claims["role"].(string)
+	if !ok || role == "" {
+		return "", jwt.ErrTokenInvalidClaims
 	}
 
-	return token, nil
+	return Role(role), nil
 }
 
-func getJWTSecretKey() string {
-	config, err := configs.LoadConfig("../")
-	if err != nil {
-		panic(err)
+// GetSessionID reads the session (refresh token family) a parsed access
+// token was issued for.
+func GetSessionID(token *jwt.Token) (string, error) {
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return "", jwt.ErrTokenInvalidClaims
 	}
 
-	return config.JWTSecret
-}
-
-func getJWTExpiration() int64 {
-	config, err := configs.LoadConfig("../")
-	if err != nil {
-		panic(err)
+	sid, ok := claims["sid"].(string)
+	if !ok || sid == "" {
+		return "", jwt.ErrTokenInvalidClaims
 	}
 
-	return config.JWTExpiresIn
+	return sid, nil
 }
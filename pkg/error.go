@@ -1,12 +1,25 @@
 package pkg
 
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+)
+
 type Error struct {
 	Message string `json:"message"`
 	Error   string `json:"error"`
 	Status  int    `json:"status"`
+	// RetryAfter is set, in seconds, on 429 errors.
+	RetryAfter int `json:"retryAfter,omitempty"`
 }
 
 func NewInternalServerError(err error) Error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return NewTimeoutError(err)
+	}
+
 	return Error{
 		Message: "internal server error",
 		Error:   err.Error(),
@@ -44,9 +57,33 @@ func NewNotFoundError(entity string) Error {
 	}
 }
 
+func NewForbiddenError(reason string) Error {
+	return Error{
+		Message: "Forbidden",
+		Error:   reason,
+		Status:  403,
+	}
+}
+
 func NewMissingFieldError(field string) Error {
 	return Error{
 		Message: field + " is required",
 		Status:  400,
 	}
 }
+
+func NewTimeoutError(err error) Error {
+	return Error{
+		Message: "request timed out",
+		Error:   err.Error(),
+		Status:  504,
+	}
+}
+
+func NewTooManyRequestsError(retryAfter time.Duration) Error {
+	return Error{
+		Message:    "Too many attempts, try again later",
+		Status:     429,
+		RetryAfter: int(math.Ceil(retryAfter.Seconds())),
+	}
+}
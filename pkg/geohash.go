@@ -0,0 +1,167 @@
+package pkg
+
+import (
+	"math"
+	"strings"
+)
+
+const (
+	geohashAlphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+	// GeohashPrecision is the length of the geohash stored on each dressmaker,
+	// roughly a 5m x 5m cell.
+	GeohashPrecision = 9
+)
+
+// geohashCellSizes holds the approximate height and width, in meters, of a
+// geohash cell at the equator for each precision (index 0 is precision 1).
+var geohashCellSizes = [][2]float64{
+	{5009400, 4992600},
+	{1252300, 624100},
+	{156500, 156000},
+	{39100, 19500},
+	{4890, 4890},
+	{1220, 610},
+	{153, 153},
+	{38.2, 19.1},
+	{4.77, 4.77},
+}
+
+func EncodeGeohash(latitude, longitude float64, precision int) string {
+	latRange := [2]float64{-90, 90}
+	lonRange := [2]float64{-180, 180}
+
+	var hash strings.Builder
+	bit, ch := 0, 0
+	even := true
+
+	for hash.Len() < precision {
+		if even {
+			mid := (lonRange[0] + lonRange[1]) / 2
+			if longitude >= mid {
+				ch |= 1 << (4 - bit)
+				lonRange[0] = mid
+			} else {
+				lonRange[1] = mid
+			}
+		} else {
+			mid := (latRange[0] + latRange[1]) / 2
+			if latitude >= mid {
+				ch |= 1 << (4 - bit)
+				latRange[0] = mid
+			} else {
+				latRange[1] = mid
+			}
+		}
+
+		even = !even
+
+		if bit < 4 {
+			bit++
+		} else {
+			hash.WriteByte(geohashAlphabet[ch])
+			bit, ch = 0, 0
+		}
+	}
+
+	return hash.String()
+}
+
+// DecodeGeohashBounds returns the latitude and longitude ranges covered by a
+// geohash cell.
+func DecodeGeohashBounds(hash string) (latRange, lonRange [2]float64) {
+	latRange = [2]float64{-90, 90}
+	lonRange = [2]float64{-180, 180}
+	even := true
+
+	for _, c := range hash {
+		idx := strings.IndexRune(geohashAlphabet, c)
+		for bit := 4; bit >= 0; bit-- {
+			on := idx&(1<<bit) != 0
+			if even {
+				mid := (lonRange[0] + lonRange[1]) / 2
+				if on {
+					lonRange[0] = mid
+				} else {
+					lonRange[1] = mid
+				}
+			} else {
+				mid := (latRange[0] + latRange[1]) / 2
+				if on {
+					latRange[0] = mid
+				} else {
+					latRange[1] = mid
+				}
+			}
+			even = !even
+		}
+	}
+
+	return latRange, lonRange
+}
+
+// GeohashNeighbors returns the cell itself plus its (up to) eight neighbours.
+func GeohashNeighbors(hash string) []string {
+	latRange, lonRange := DecodeGeohashBounds(hash)
+	centerLat := (latRange[0] + latRange[1]) / 2
+	centerLon := (lonRange[0] + lonRange[1]) / 2
+	dLat := latRange[1] - latRange[0]
+	dLon := lonRange[1] - lonRange[0]
+
+	seen := map[string]bool{}
+	var neighbors []string
+
+	for dy := -1; dy <= 1; dy++ {
+		lat := centerLat + float64(dy)*dLat
+		if lat > 90 || lat < -90 {
+			continue
+		}
+
+		for dx := -1; dx <= 1; dx++ {
+			lon := centerLon + float64(dx)*dLon
+			if lon > 180 {
+				lon -= 360
+			} else if lon < -180 {
+				lon += 360
+			}
+
+			neighbor := EncodeGeohash(lat, lon, len(hash))
+			if !seen[neighbor] {
+				seen[neighbor] = true
+				neighbors = append(neighbors, neighbor)
+			}
+		}
+	}
+
+	return neighbors
+}
+
+// GeohashPrecisionForRadius returns the longest precision whose cells are at
+// least radius meters on their shortest side at the given latitude, so that
+// a cell and its neighbours fully cover the circle. It returns 0 when the
+// radius is larger than any cell.
+func GeohashPrecisionForRadius(latitude float64, radius float64) int {
+	shrink := math.Cos(latitude * math.Pi / 180)
+
+	precision := 0
+	for i, size := range geohashCellSizes {
+		if math.Min(size[0], size[1]*shrink) < radius {
+			break
+		}
+		precision = i + 1
+	}
+
+	return precision
+}
+
+// GeohashCoveringPrefixes returns the geohash prefixes whose cells cover every
+// point within radius meters of the given coordinates. An empty result means
+// the radius is too large to be narrowed down and every point must be checked.
+func GeohashCoveringPrefixes(latitude, longitude float64, radius float64) []string {
+	precision := GeohashPrecisionForRadius(latitude, radius)
+	if precision == 0 {
+		return nil
+	}
+
+	return GeohashNeighbors(EncodeGeohash(latitude, longitude, precision))
+}
@@ -0,0 +1,283 @@
+package pkg
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	privateKeySuffix = ".pem"
+	publicKeySuffix  = ".pub.pem"
+)
+
+// SigningKey is a key tokens are signed or verified with. Keys with only a
+// public half are kept for verification after they have been rotated out.
+type SigningKey struct {
+	ID      string
+	Method  jwt.SigningMethod
+	Private crypto.Signer
+	Public  crypto.PublicKey
+}
+
+// KeyManager signs tokens with the active key and verifies them with any
+// known key, looked up by the token's kid header.
+type KeyManager struct {
+	active     *SigningKey
+	keys       map[string]*SigningKey
+	defaultTTL time.Duration
+}
+
+var keyManager *KeyManager
+
+// InitKeyManager sets the key manager used by GenerateToken and ParseToken.
+func InitKeyManager(manager *KeyManager) {
+	keyManager = manager
+}
+
+func GetKeyManager() *KeyManager {
+	return keyManager
+}
+
+func NewKeyManager(keys []*SigningKey, activeID string, defaultTTL time.Duration) (*KeyManager, error) {
+	manager := &KeyManager{
+		keys:       map[string]*SigningKey{},
+		defaultTTL: defaultTTL,
+	}
+
+	for _, key := range keys {
+		if _, ok := manager.keys[key.ID]; ok {
+			return nil, fmt.Errorf("duplicate signing key id: %s", key.ID)
+		}
+
+		manager.keys[key.ID] = key
+	}
+
+	active, ok := manager.keys[activeID]
+	if !ok {
+		return nil, fmt.Errorf("no signing key found with id: %s", activeID)
+	}
+
+	if active.Private == nil {
+		return nil, fmt.Errorf("signing key %s has no private key", activeID)
+	}
+
+	manager.active = active
+
+	return manager, nil
+}
+
+// LoadKeyManager reads the keys in dir: <kid>.pem files hold PKCS#8 (or
+// PKCS#1 RSA) private keys and <kid>.pub.pem files hold public keys kept only
+// to verify tokens signed before a rotation. When activeID is empty the last
+// private key by name signs, so naming keys by date rotates them in order.
+func LoadKeyManager(dir, activeID string, defaultTTL time.Duration) (*KeyManager, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), privateKeySuffix) {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	var keys []*SigningKey
+	for _, name := range names {
+		raw, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+
+		var key *SigningKey
+		if strings.HasSuffix(name, publicKeySuffix) {
+			key, err = ParsePublicSigningKey(strings.TrimSuffix(name, publicKeySuffix), raw)
+		} else {
+			key, err = ParsePrivateSigningKey(strings.TrimSuffix(name, privateKeySuffix), raw)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+
+		keys = append(keys, key)
+	}
+
+	if activeID == "" {
+		for _, key := range keys {
+			if key.Private != nil {
+				activeID = key.ID
+			}
+		}
+	}
+
+	if activeID == "" {
+		return nil, fmt.Errorf("no private signing key found in %s", dir)
+	}
+
+	return NewKeyManager(keys, activeID, defaultTTL)
+}
+
+// NewEphemeralKeyManager generates an Ed25519 key that only lives as long as
+// the process, for development setups without configured keys.
+func NewEphemeralKeyManager(defaultTTL time.Duration) (*KeyManager, error) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	key := &SigningKey{
+		ID:      "ephemeral-" + base64.RawURLEncoding.EncodeToString(public[:6]),
+		Method:  jwt.SigningMethodEdDSA,
+		Private: private,
+		Public:  public,
+	}
+
+	return NewKeyManager([]*SigningKey{key}, key.ID, defaultTTL)
+}
+
+func ParsePrivateSigningKey(id string, raw []byte) (*SigningKey, error) {
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+
+	var parsed any
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block: %s", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch key := parsed.(type) {
+	case *rsa.PrivateKey:
+		return &SigningKey{ID: id, Method: jwt.SigningMethodRS256, Private: key, Public: &key.PublicKey}, nil
+	case ed25519.PrivateKey:
+		return &SigningKey{ID: id, Method: jwt.SigningMethodEdDSA, Private: key, Public: key.Public()}, nil
+	default:
+		return nil, errors.New("unsupported key type, use RSA or Ed25519")
+	}
+}
+
+func ParsePublicSigningKey(id string, raw []byte) (*SigningKey, error) {
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+
+	if block.Type != "PUBLIC KEY" {
+		return nil, fmt.Errorf("unsupported PEM block: %s", block.Type)
+	}
+
+	parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	switch key := parsed.(type) {
+	case *rsa.PublicKey:
+		return &SigningKey{ID: id, Method: jwt.SigningMethodRS256, Public: key}, nil
+	case ed25519.PublicKey:
+		return &SigningKey{ID: id, Method: jwt.SigningMethodEdDSA, Public: key}, nil
+	default:
+		return nil, errors.New("unsupported key type, use RSA or Ed25519")
+	}
+}
+
+func (m *KeyManager) Sign(claims jwt.MapClaims) (string, error) {
+	token := jwt.NewWithClaims(m.active.Method, claims)
+	token.Header["kid"] = m.active.ID
+
+	return token.SignedString(m.active.Private)
+}
+
+func (m *KeyManager) Parse(tokenString string) (*jwt.Token, error) {
+	return jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+
+		key, ok := m.keys[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown signing key: %q", kid)
+		}
+
+		if token.Method.Alg() != key.Method.Alg() {
+			return nil, jwt.ErrSignatureInvalid
+		}
+
+		return key.Public, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg()}))
+}
+
+// JWK is the public half of a signing key in JSON Web Key format.
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+}
+
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS publishes every key tokens may still be signed with, so other
+// services can verify them.
+func (m *KeyManager) JWKS() JWKSet {
+	ids := make([]string, 0, len(m.keys))
+	for id := range m.keys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	set := JWKSet{Keys: []JWK{}}
+	for _, id := range ids {
+		key := m.keys[id]
+		jwk := JWK{
+			KeyID:     key.ID,
+			Use:       "sig",
+			Algorithm: key.Method.Alg(),
+		}
+
+		switch public := key.Public.(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		}
+
+		set.Keys = append(set.Keys, jwk)
+	}
+
+	return set
+}
@@ -0,0 +1,53 @@
+package pkg
+
+import (
+	"errors"
+	"strings"
+)
+
+// DefaultPhoneCountryCode is assumed for numbers written without one.
+const DefaultPhoneCountryCode = "55"
+
+var ErrInvalidPhone = errors.New("invalid phone number")
+
+// NormalizePhone converts a phone number to E.164 (+5511999999999). Spaces,
+// dashes, dots and parentheses are ignored; national numbers, with or without
+// the leading trunk 0, get DefaultPhoneCountryCode.
+func NormalizePhone(phone string) (string, error) {
+	cleaned := strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '.', '(', ')':
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(phone))
+
+	international := false
+	switch {
+	case strings.HasPrefix(cleaned, "+"):
+		cleaned = cleaned[1:]
+		international = true
+	case strings.HasPrefix(cleaned, "00"):
+		cleaned = cleaned[2:]
+		international = true
+	}
+
+	if cleaned == "" || strings.IndexFunc(cleaned, func(r rune) bool { return r < '0' || r > '9' }) >= 0 {
+		return "", ErrInvalidPhone
+	}
+
+	if !international {
+		cleaned = strings.TrimPrefix(cleaned, "0")
+
+		// area code plus an 8 or 9 digit number
+		if len(cleaned) == 10 || len(cleaned) == 11 {
+			cleaned = DefaultPhoneCountryCode + cleaned
+		}
+	}
+
+	if len(cleaned) < 8 || len(cleaned) > 15 || cleaned[0] == '0' {
+		return "", ErrInvalidPhone
+	}
+
+	return "+" + cleaned, nil
+}
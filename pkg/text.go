@@ -0,0 +1,23 @@
+package pkg
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// NormalizeText lowercases a text, strips its accents and collapses its
+// whitespace so "Ajuste de  Barrá" and "ajuste de barra" compare equal.
+func NormalizeText(text string) string {
+	stripAccents := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+
+	result, _, err := transform.String(stripAccents, text)
+	if err != nil {
+		result = text
+	}
+
+	return strings.Join(strings.Fields(strings.ToLower(result)), " ")
+}
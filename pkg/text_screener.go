@@ -0,0 +1,91 @@
+package pkg
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// TextScreener flags texts containing blocked words or matching blocked
+// patterns. Words are matched as whole words, ignoring case and accents;
+// patterns are matched against the original text.
+type TextScreener struct {
+	words    []string
+	patterns []*regexp.Regexp
+}
+
+func NewTextScreener(words []string, patterns []string) (*TextScreener, error) {
+	screener := &TextScreener{}
+
+	for _, word := range words {
+		normalized := NormalizeText(stripPunctuation(word))
+		if normalized != "" {
+			screener.words = append(screener.words, normalized)
+		}
+	}
+
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, err
+		}
+
+		screener.patterns = append(screener.patterns, compiled)
+	}
+
+	return screener, nil
+}
+
+// Screen returns the blocked words and patterns found in the text, or nil
+// when the text is clean. A nil screener lets every text through.
+func (s *TextScreener) Screen(text string) []string {
+	if s == nil {
+		return nil
+	}
+
+	var matches []string
+
+	normalized := " " + NormalizeText(stripPunctuation(text)) + " "
+	for _, word := range s.words {
+		if strings.Contains(normalized, " "+word+" ") {
+			matches = append(matches, word)
+		}
+	}
+
+	for _, pattern := range s.patterns {
+		if pattern.MatchString(text) {
+			matches = append(matches, pattern.String())
+		}
+	}
+
+	return matches
+}
+
+func stripPunctuation(text string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsPunct(r) || unicode.IsSymbol(r) {
+			return ' '
+		}
+		return r
+	}, text)
+}
+
+// SplitList splits a separator delimited configuration value, dropping
+// empty entries.
+func SplitList(value, separator string) []string {
+	var items []string
+
+	for _, item := range strings.Split(value, separator) {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}